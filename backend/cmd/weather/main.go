@@ -2,39 +2,182 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/config"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/logging"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/metrics"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tranport/http"
 	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/postgres"
 )
 
 func main() {
-	ctx := context.Background()
+	// SIGINT and SIGTERM cancel the root context, which starts the shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
+	err := run(ctx)
+
+	stop()
+
+	if err != nil {
+		slog.Error("exiting", "error", err)
+		os.Exit(1)
+	}
+}
+
+// run sets the service up and serves until ctx is cancelled. Teardown happens
+// in reverse order of setup: the server drains first, the database pool is
+// closed last.
+func run(ctx context.Context) (err error) {
 	cfg, err := config.New()
 	if err != nil {
-		log.Fatalf("failed to get config: %s", err)
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// the server may keep serving for the drain delay before it drains, the
+	// shutdown timeout covers draining and the steps after it
+	teardown := shutdown{timeout: cfg.DrainDelay + cfg.DrainTimeout}
+
+	defer func() {
+		// the root context is already cancelled, stopping gets a fresh one
+		err = errors.Join(err, teardown.run(context.WithoutCancel(ctx)))
+	}()
+
+	logger, err := logging.New(cfg.LoggingConfig, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up logging: %w", err)
 	}
 
+	slog.SetDefault(logger)
+
 	db, err := postgres.NewPostgres(cfg.PostgresConfig)
 	if err != nil {
-		log.Fatalf("failed to connect to db: %s", err)
+		return fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	teardown.add("postgres", func(context.Context) error {
+		return db.Close()
+	})
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, db, os.Args[2:]); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+
+		return nil
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	if cfg.AutoMigrate {
+		if err := migrator.Up(ctx); err != nil {
+			return fmt.Errorf("failed to migrate db: %w", err)
+		}
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.TracingConfig)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
 	}
 
-	defer db.Close()
+	// spans of the requests drained on shutdown are flushed here
+	teardown.add("tracing", shutdownTracing)
+
+	appMetrics := metrics.New()
+	appMetrics.RegisterDB("weather", db.SQL())
+	db.Instrument(appMetrics)
 
 	whetherRepo := repository.NewWeatherRepository(db)
 	whetherService := service.NewWeatherService(whetherRepo)
-	server := http.New(ctx, cfg.RESTServerPort, whetherService)
+	whetherService.SetMetrics(appMetrics)
 
-	if err := server.Start(); err != nil {
-		slog.Error(fmt.Sprintf("server.Start(): %s", err))
-		os.Exit(1)
+	userRepo := repository.NewUserRepository(db)
+	authService := service.NewAuthService(userRepo, cfg.AuthConfig)
+	userService := service.NewUserService(userRepo)
+
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+
+	locationRepo := repository.NewLocationRepository(db)
+	locationService := service.NewLocationService(locationRepo)
+
+	forecastRepo := repository.NewForecastRepository(db)
+	forecastService := service.NewForecastService(forecastRepo)
+
+	healthService := service.NewHealthService(cfg.HealthConfig)
+	healthService.Register("postgres", service.PostgresChecker(db, migrator))
+
+	if cfg.AdminUsername != "" {
+		err := userService.EnsureUser(ctx, cfg.AdminUsername, cfg.AdminPassword, models.RoleAdmin)
+		if err != nil {
+			return fmt.Errorf("failed to create admin user: %w", err)
+		}
 	}
+
+	server := http.New(
+		ctx,
+		cfg.ServerConfig,
+		whetherService,
+		authService,
+		userService,
+		apiKeyService,
+		locationService,
+		forecastService,
+		healthService,
+		appMetrics,
+		logger,
+	)
+
+	return serve(ctx, server, &teardown)
+}
+
+// serve runs the server until it fails or ctx is cancelled. Draining the
+// server is the first step of the teardown, skipped if it is not serving.
+func serve(ctx context.Context, server *http.Server, teardown *shutdown) error {
+	var startErr error
+
+	done := make(chan struct{})
+
+	go func() {
+		startErr = server.Start()
+		close(done)
+	}()
+
+	teardown.add("http server", func(ctx context.Context) error {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		if err := server.Shutdown(ctx); err != nil {
+			return err
+		}
+
+		<-done
+
+		return nil
+	})
+
+	select {
+	case <-done:
+		return startErr
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down")
+
+	return nil
 }
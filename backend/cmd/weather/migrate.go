@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/database"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/migrate"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/postgres"
+)
+
+const migrateUsage = `usage: weather migrate <command>
+
+commands:
+  up              apply every pending migration
+  down [steps]    roll back the last steps migrations (default 1)
+  goto <version>  migrate up or down to version, 0 rolls back everything
+  status          list migrations and whether they are applied`
+
+var errUsage = errors.New(migrateUsage)
+
+func newMigrator(db *postgres.DB) (*migrate.Migrator, error) {
+	migrations, err := fs.Sub(database.Migrations, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+
+	return migrate.New(db.SQL(), migrations)
+}
+
+// runMigrate implements the migrate subcommand.
+func runMigrate(ctx context.Context, db *postgres.DB, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	migrator, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch cmd, args := args[0], args[1:]; {
+	case cmd == "up" && len(args) == 0:
+		err = migrator.Up(ctx)
+	case cmd == "down" && len(args) <= 1:
+		steps := 1
+		if len(args) == 1 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps <= 0 {
+				return fmt.Errorf("invalid steps %q\n%w", args[0], errUsage)
+			}
+		}
+
+		err = migrator.Down(ctx, steps)
+	case cmd == "goto" && len(args) == 1:
+		version, convErr := strconv.Atoi(args[0])
+		if convErr != nil || version < 0 {
+			return fmt.Errorf("invalid version %q\n%w", args[0], errUsage)
+		}
+
+		err = migrator.Goto(ctx, version)
+	case cmd == "status" && len(args) == 0:
+		return printStatus(ctx, migrator)
+	default:
+		return errUsage
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return printStatus(ctx, migrator)
+}
+
+func printStatus(ctx context.Context, migrator *migrate.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, st := range status {
+		state := "pending"
+		if st.Applied {
+			state = "applied"
+		}
+
+		fmt.Fprintf(os.Stdout, "%06d %-8s %s\n", st.Version, state, st.Name)
+	}
+
+	return nil
+}
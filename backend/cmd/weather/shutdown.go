@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// shutdown is the teardown sequence of the service. Components join it as
+// they start and are stopped in reverse order, so anything started later,
+// such as a background worker, is stopped before the tracing and database it
+// depends on.
+type shutdown struct {
+	// timeout bounds the whole sequence, so a hanging step cannot keep the
+	// process from exiting.
+	timeout time.Duration
+	steps   []shutdownStep
+}
+
+type shutdownStep struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+func (s *shutdown) add(name string, stop func(ctx context.Context) error) {
+	s.steps = append(s.steps, shutdownStep{name: name, stop: stop})
+}
+
+// run stops every component, even if some fail, and returns their errors.
+func (s *shutdown) run(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var errs []error
+
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := s.steps[i]
+
+		slog.InfoContext(ctx, "stopping", "component", step.name)
+
+		if err := stop(ctx, step); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// stop waits for the step until ctx is done; a step that ignores ctx, such
+// as closing the database pool, is left behind rather than waited for.
+func stop(ctx context.Context, step shutdownStep) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- step.stop(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("failed to stop %s: %w", step.name, ctx.Err())
+	}
+}
@@ -0,0 +1,8 @@
+// Package database embeds the SQL migrations so that the weather binary can
+// apply them without the source tree at hand.
+package database
+
+import "embed"
+
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS weather_weather_status_idx;
+DROP INDEX IF EXISTS weather_city_country_timestamp_id_idx;
+DROP INDEX IF EXISTS weather_wind_speed_id_idx;
+DROP INDEX IF EXISTS weather_pressure_id_idx;
+DROP INDEX IF EXISTS weather_humidity_id_idx;
+DROP INDEX IF EXISTS weather_temperature_id_idx;
+DROP INDEX IF EXISTS weather_timestamp_id_idx;
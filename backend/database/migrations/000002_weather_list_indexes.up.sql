@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS weather_timestamp_id_idx ON weather (timestamp, id);
+CREATE INDEX IF NOT EXISTS weather_temperature_id_idx ON weather (temperature, id);
+CREATE INDEX IF NOT EXISTS weather_humidity_id_idx ON weather (humidity, id);
+CREATE INDEX IF NOT EXISTS weather_pressure_id_idx ON weather (pressure, id);
+CREATE INDEX IF NOT EXISTS weather_wind_speed_id_idx ON weather (wind_speed, id);
+CREATE INDEX IF NOT EXISTS weather_city_country_timestamp_id_idx ON weather (city, country, timestamp, id);
+CREATE INDEX IF NOT EXISTS weather_weather_status_idx ON weather (weather_status);
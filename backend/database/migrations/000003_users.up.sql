@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS users
+(
+  id            BIGINT    NOT NULL GENERATED ALWAYS AS IDENTITY,
+  username      TEXT      NOT NULL,
+  password_hash TEXT      NOT NULL,
+  role          TEXT      NOT NULL,
+  created_at    timestamp NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id),
+  UNIQUE (username)
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens
+(
+  id         TEXT      NOT NULL,
+  user_id    BIGINT    NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  expires_at timestamp NOT NULL,
+  revoked_at timestamp,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_user_id_idx ON refresh_tokens (user_id);
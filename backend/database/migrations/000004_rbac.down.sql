@@ -0,0 +1,14 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'viewer';
+
+UPDATE users u
+SET role = CASE
+             WHEN EXISTS (SELECT 1 FROM user_roles WHERE user_id = u.id AND role = 'admin') THEN 'admin'
+             ELSE 'viewer'
+           END;
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
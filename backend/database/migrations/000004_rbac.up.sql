@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS roles
+(
+  name        TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS permissions
+(
+  name        TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (name)
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions
+(
+  role       TEXT NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+  permission TEXT NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+  PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles
+(
+  user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  role    TEXT   NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role)
+);
+
+INSERT INTO roles (name, description)
+VALUES ('viewer', 'Read-only access'),
+       ('observer', 'Can add weather readings'),
+       ('editor', 'Can add, update and delete weather readings'),
+       ('admin', 'Full access including user management')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO permissions (name, description)
+VALUES ('weather:create', 'Add weather observations'),
+       ('weather:update', 'Update weather observations'),
+       ('weather:delete', 'Delete weather observations'),
+       ('users:manage', 'Manage users and role assignments')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+VALUES ('observer', 'weather:create'),
+       ('editor', 'weather:create'),
+       ('editor', 'weather:update'),
+       ('editor', 'weather:delete'),
+       ('admin', 'weather:create'),
+       ('admin', 'weather:update'),
+       ('admin', 'weather:delete'),
+       ('admin', 'users:manage')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO user_roles (user_id, role)
+SELECT id, role
+FROM users
+WHERE role IN (SELECT name FROM roles)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE name = 'api_keys:manage';
+
+ALTER TABLE weather DROP COLUMN IF EXISTS api_key_id;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+  id           BIGINT    NOT NULL GENERATED ALWAYS AS IDENTITY,
+  name         TEXT      NOT NULL,
+  prefix       TEXT      NOT NULL,
+  key_hash     TEXT      NOT NULL,
+  cities       TEXT[]    NOT NULL DEFAULT '{}',
+  permissions  TEXT[]    NOT NULL DEFAULT '{}',
+  created_by   BIGINT    REFERENCES users (id) ON DELETE SET NULL,
+  created_at   timestamp NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  last_used_at timestamp,
+  revoked_at   timestamp,
+  PRIMARY KEY (id),
+  UNIQUE (prefix)
+);
+
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS api_key_id BIGINT REFERENCES api_keys (id) ON DELETE SET NULL;
+
+INSERT INTO permissions (name, description)
+VALUES ('api_keys:manage', 'Create, rotate and revoke station API keys')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+VALUES ('admin', 'api_keys:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1 @@
+ALTER TABLE weather DROP COLUMN IF EXISTS version;
@@ -0,0 +1,20 @@
+DELETE FROM permissions WHERE name = 'locations:manage';
+
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS city TEXT;
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS country TEXT;
+
+UPDATE weather
+SET city = locations.name,
+    country = locations.country
+FROM locations
+WHERE locations.id = weather.location_id;
+
+ALTER TABLE weather ALTER COLUMN city SET NOT NULL;
+ALTER TABLE weather ALTER COLUMN country SET NOT NULL;
+
+DROP INDEX IF EXISTS weather_location_id_timestamp_id_idx;
+CREATE INDEX IF NOT EXISTS weather_city_country_timestamp_id_idx ON weather (city, country, timestamp, id);
+
+ALTER TABLE weather DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS locations
+(
+  id        BIGINT           NOT NULL GENERATED ALWAYS AS IDENTITY,
+  name      TEXT             NOT NULL,
+  country   TEXT             NOT NULL,
+  latitude  double precision,
+  longitude double precision,
+  elevation double precision,
+  timezone  TEXT             NOT NULL DEFAULT 'UTC',
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS locations_name_country_key ON locations (lower(name), lower(country));
+
+-- "Minsk", "minsk" and "Minsk " are one place; the most recently used
+-- spelling becomes the location name.
+INSERT INTO locations (name, country)
+SELECT DISTINCT ON (lower(btrim(city)), lower(btrim(country))) btrim(city), btrim(country)
+FROM weather
+ORDER BY lower(btrim(city)), lower(btrim(country)), timestamp DESC
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS location_id BIGINT REFERENCES locations (id) ON DELETE RESTRICT;
+
+UPDATE weather
+SET location_id = locations.id
+FROM locations
+WHERE lower(locations.name) = lower(btrim(weather.city))
+  AND lower(locations.country) = lower(btrim(weather.country));
+
+ALTER TABLE weather ALTER COLUMN location_id SET NOT NULL;
+
+DROP INDEX IF EXISTS weather_city_country_timestamp_id_idx;
+CREATE INDEX IF NOT EXISTS weather_location_id_timestamp_id_idx ON weather (location_id, timestamp, id);
+
+ALTER TABLE weather DROP COLUMN IF EXISTS city;
+ALTER TABLE weather DROP COLUMN IF EXISTS country;
+
+INSERT INTO permissions (name, description)
+VALUES ('locations:manage', 'Create, update and delete locations')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+VALUES ('editor', 'locations:manage'),
+       ('admin', 'locations:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS weather_latitude_longitude_idx;
+
+DROP FUNCTION IF EXISTS haversine_km(double precision, double precision, double precision, double precision);
+
+ALTER TABLE weather DROP COLUMN IF EXISTS longitude;
+ALTER TABLE weather DROP COLUMN IF EXISTS latitude;
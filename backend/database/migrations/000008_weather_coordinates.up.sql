@@ -0,0 +1,31 @@
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS latitude double precision;
+ALTER TABLE weather ADD COLUMN IF NOT EXISTS longitude double precision;
+
+UPDATE weather
+SET latitude = locations.latitude,
+    longitude = locations.longitude
+FROM locations
+WHERE locations.id = weather.location_id
+  AND locations.latitude IS NOT NULL
+  AND locations.longitude IS NOT NULL;
+
+-- Great-circle distance in kilometres on a spherical Earth (mean radius).
+-- LEAST guards asin against rounding slightly above 1 for antipodal points.
+CREATE OR REPLACE FUNCTION haversine_km(
+  lat1 double precision,
+  lon1 double precision,
+  lat2 double precision,
+  lon2 double precision
+) RETURNS double precision
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+  SELECT 2 * 6371.0088 * asin(least(1, sqrt(
+    power(sin(radians(lat2 - lat1) / 2), 2) +
+    cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lon2 - lon1) / 2), 2)
+  )))
+$$;
+
+-- Both geo queries prefilter on a latitude/longitude box before computing
+-- distances, so a plain btree is enough.
+CREATE INDEX IF NOT EXISTS weather_latitude_longitude_idx ON weather (latitude, longitude)
+WHERE latitude IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS weather_location_id_timestamp_desc_id_desc_idx;
+CREATE INDEX IF NOT EXISTS weather_location_id_timestamp_id_idx ON weather (location_id, timestamp, id);
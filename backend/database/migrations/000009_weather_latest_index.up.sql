@@ -0,0 +1,4 @@
+-- Serves DISTINCT ON (location_id) ... ORDER BY location_id, timestamp DESC
+-- and still covers per-location listings in both directions.
+DROP INDEX IF EXISTS weather_location_id_timestamp_id_idx;
+CREATE INDEX IF NOT EXISTS weather_location_id_timestamp_desc_id_desc_idx ON weather (location_id, timestamp DESC, id DESC);
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS forecasts
+(
+  id                BIGINT           NOT NULL GENERATED ALWAYS AS IDENTITY,
+  location_id       BIGINT           NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+  model             TEXT             NOT NULL,
+  granularity       TEXT             NOT NULL CHECK (granularity IN ('hour', 'day')),
+  issued_at         timestamp        NOT NULL,
+  target_time       timestamp        NOT NULL,
+  temperature       double precision NOT NULL,
+  temperature_lower double precision NOT NULL,
+  temperature_upper double precision NOT NULL,
+  humidity          double precision NOT NULL,
+  humidity_lower    double precision NOT NULL,
+  humidity_upper    double precision NOT NULL,
+  pressure          double precision NOT NULL,
+  pressure_lower    double precision NOT NULL,
+  pressure_upper    double precision NOT NULL,
+  wind_speed        double precision NOT NULL,
+  wind_speed_lower  double precision NOT NULL,
+  wind_speed_upper  double precision NOT NULL,
+  created_at        timestamp        NOT NULL DEFAULT (now() AT TIME ZONE 'utc'),
+  PRIMARY KEY (id)
+);
+
+-- A run is identified by its location, model, granularity and issue time;
+-- regenerating it within the same period overwrites the stored predictions.
+CREATE UNIQUE INDEX IF NOT EXISTS forecasts_run_target_key
+ON forecasts (location_id, model, granularity, issued_at, target_time);
@@ -0,0 +1,31 @@
+DELETE FROM permissions WHERE name = 'forecasts:upload';
+
+DROP INDEX IF EXISTS forecasts_granularity_target_time_idx;
+
+DELETE FROM forecasts
+WHERE temperature IS NULL
+   OR temperature_lower IS NULL
+   OR temperature_upper IS NULL
+   OR humidity IS NULL
+   OR humidity_lower IS NULL
+   OR humidity_upper IS NULL
+   OR pressure IS NULL
+   OR pressure_lower IS NULL
+   OR pressure_upper IS NULL
+   OR wind_speed IS NULL
+   OR wind_speed_lower IS NULL
+   OR wind_speed_upper IS NULL;
+
+ALTER TABLE forecasts
+  ALTER COLUMN temperature SET NOT NULL,
+  ALTER COLUMN temperature_lower SET NOT NULL,
+  ALTER COLUMN temperature_upper SET NOT NULL,
+  ALTER COLUMN humidity SET NOT NULL,
+  ALTER COLUMN humidity_lower SET NOT NULL,
+  ALTER COLUMN humidity_upper SET NOT NULL,
+  ALTER COLUMN pressure SET NOT NULL,
+  ALTER COLUMN pressure_lower SET NOT NULL,
+  ALTER COLUMN pressure_upper SET NOT NULL,
+  ALTER COLUMN wind_speed SET NOT NULL,
+  ALTER COLUMN wind_speed_lower SET NOT NULL,
+  ALTER COLUMN wind_speed_upper SET NOT NULL;
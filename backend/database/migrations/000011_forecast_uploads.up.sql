@@ -0,0 +1,25 @@
+-- Uploaded forecasts may cover only some variables and omit intervals.
+ALTER TABLE forecasts
+  ALTER COLUMN temperature DROP NOT NULL,
+  ALTER COLUMN temperature_lower DROP NOT NULL,
+  ALTER COLUMN temperature_upper DROP NOT NULL,
+  ALTER COLUMN humidity DROP NOT NULL,
+  ALTER COLUMN humidity_lower DROP NOT NULL,
+  ALTER COLUMN humidity_upper DROP NOT NULL,
+  ALTER COLUMN pressure DROP NOT NULL,
+  ALTER COLUMN pressure_lower DROP NOT NULL,
+  ALTER COLUMN pressure_upper DROP NOT NULL,
+  ALTER COLUMN wind_speed DROP NOT NULL,
+  ALTER COLUMN wind_speed_lower DROP NOT NULL,
+  ALTER COLUMN wind_speed_upper DROP NOT NULL;
+
+CREATE INDEX IF NOT EXISTS forecasts_granularity_target_time_idx ON forecasts (granularity, target_time);
+
+INSERT INTO permissions (name, description)
+VALUES ('forecasts:upload', 'Upload third-party forecasts')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role, permission)
+VALUES ('editor', 'forecasts:upload'),
+       ('admin', 'forecasts:upload')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,28 @@
+ALTER TABLE api_keys
+  ALTER COLUMN created_at TYPE timestamp USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT (now() AT TIME ZONE 'utc'),
+  ALTER COLUMN last_used_at TYPE timestamp USING last_used_at AT TIME ZONE 'UTC',
+  ALTER COLUMN revoked_at TYPE timestamp USING revoked_at AT TIME ZONE 'UTC';
+
+ALTER TABLE refresh_tokens
+  ALTER COLUMN expires_at TYPE timestamp USING expires_at AT TIME ZONE 'UTC',
+  ALTER COLUMN revoked_at TYPE timestamp USING revoked_at AT TIME ZONE 'UTC';
+
+ALTER TABLE users
+  ALTER COLUMN created_at TYPE timestamp USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT (now() AT TIME ZONE 'utc');
+
+ALTER TABLE forecasts
+  ALTER COLUMN issued_at TYPE timestamp USING issued_at AT TIME ZONE 'UTC',
+  ALTER COLUMN target_time TYPE timestamp USING target_time AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE timestamp USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT (now() AT TIME ZONE 'utc');
+
+-- Observations keep their local wall clock, as they did before.
+UPDATE weather
+SET timestamp = (weather.timestamp AT TIME ZONE locations.timezone) AT TIME ZONE 'UTC'
+FROM locations
+WHERE locations.id = weather.location_id
+  AND locations.timezone <> 'UTC';
+
+ALTER TABLE weather ALTER COLUMN timestamp TYPE timestamp USING timestamp AT TIME ZONE 'UTC';
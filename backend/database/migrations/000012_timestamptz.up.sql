@@ -0,0 +1,31 @@
+-- Observations were stored without their offset, so the stored wall clock is
+-- whatever the client sent. It is read in the location's time zone, which is
+-- UTC unless a location says otherwise.
+ALTER TABLE weather ALTER COLUMN timestamp TYPE timestamptz USING timestamp AT TIME ZONE 'UTC';
+
+UPDATE weather
+SET timestamp = (weather.timestamp AT TIME ZONE 'UTC') AT TIME ZONE locations.timezone
+FROM locations
+WHERE locations.id = weather.location_id
+  AND locations.timezone <> 'UTC';
+
+-- Every other timestamp was written in UTC.
+ALTER TABLE forecasts
+  ALTER COLUMN issued_at TYPE timestamptz USING issued_at AT TIME ZONE 'UTC',
+  ALTER COLUMN target_time TYPE timestamptz USING target_time AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at TYPE timestamptz USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT now();
+
+ALTER TABLE users
+  ALTER COLUMN created_at TYPE timestamptz USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT now();
+
+ALTER TABLE refresh_tokens
+  ALTER COLUMN expires_at TYPE timestamptz USING expires_at AT TIME ZONE 'UTC',
+  ALTER COLUMN revoked_at TYPE timestamptz USING revoked_at AT TIME ZONE 'UTC';
+
+ALTER TABLE api_keys
+  ALTER COLUMN created_at TYPE timestamptz USING created_at AT TIME ZONE 'UTC',
+  ALTER COLUMN created_at SET DEFAULT now(),
+  ALTER COLUMN last_used_at TYPE timestamptz USING last_used_at AT TIME ZONE 'UTC',
+  ALTER COLUMN revoked_at TYPE timestamptz USING revoked_at AT TIME ZONE 'UTC';
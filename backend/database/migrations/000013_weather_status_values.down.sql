@@ -0,0 +1 @@
+-- The original wording is not kept, the mapped statuses stay as they are.
@@ -0,0 +1,25 @@
+-- weather_status used to be free text. Bring it to the snake_case form the
+-- service normalizes to, then map the common wordings onto the known
+-- statuses; the more severe condition wins when a text names several.
+UPDATE weather
+SET weather_status = regexp_replace(lower(trim(weather_status)), '[\s_-]+', '_', 'g');
+
+UPDATE weather
+SET weather_status = CASE
+    WHEN weather_status ~ 'thunder|storm|lightning' THEN 'thunderstorm'
+    WHEN weather_status ~ 'hail' THEN 'hail'
+    WHEN weather_status ~ 'sleet|freezing' THEN 'sleet'
+    WHEN weather_status ~ 'snow|flurr|blizzard' THEN 'snow'
+    WHEN weather_status ~ 'drizzle' THEN 'drizzle'
+    WHEN weather_status ~ 'rain|shower' THEN 'rain'
+    WHEN weather_status ~ 'fog|mist|haze' THEN 'fog'
+    WHEN weather_status ~ 'overcast' THEN 'overcast'
+    WHEN weather_status ~ 'partly|partial|scattered|few_clouds|partly_sunny' THEN 'partly_cloudy'
+    WHEN weather_status ~ 'cloud' THEN 'cloudy'
+    WHEN weather_status ~ 'sun|clear|fair' THEN 'clear'
+    ELSE weather_status
+END
+WHERE weather_status NOT IN (
+    'clear', 'partly_cloudy', 'cloudy', 'overcast', 'fog', 'drizzle',
+    'rain', 'thunderstorm', 'snow', 'sleet', 'hail'
+);
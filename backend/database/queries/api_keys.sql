@@ -0,0 +1,37 @@
+-- name: AddAPIKey :one
+INSERT INTO api_keys (name, prefix, key_hash, cities, permissions, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetAPIKey :one
+SELECT *
+FROM api_keys
+WHERE id = $1;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT *
+FROM api_keys
+WHERE prefix = $1;
+
+-- name: ListAPIKeys :many
+SELECT *
+FROM api_keys
+ORDER BY id;
+
+-- name: RotateAPIKey :one
+UPDATE api_keys
+SET prefix = $2,
+    key_hash = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: RevokeAPIKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, now())
+WHERE id = $1
+RETURNING *;
+
+-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1;
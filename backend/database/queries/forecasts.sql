@@ -0,0 +1,77 @@
+-- name: UpsertForecast :exec
+INSERT INTO forecasts (
+    location_id, model, granularity, issued_at, target_time,
+    temperature, temperature_lower, temperature_upper,
+    humidity, humidity_lower, humidity_upper,
+    pressure, pressure_lower, pressure_upper,
+    wind_speed, wind_speed_lower, wind_speed_upper
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+ON CONFLICT (location_id, model, granularity, issued_at, target_time) DO UPDATE
+SET
+    temperature = excluded.temperature,
+    temperature_lower = excluded.temperature_lower,
+    temperature_upper = excluded.temperature_upper,
+    humidity = excluded.humidity,
+    humidity_lower = excluded.humidity_lower,
+    humidity_upper = excluded.humidity_upper,
+    pressure = excluded.pressure,
+    pressure_lower = excluded.pressure_lower,
+    pressure_upper = excluded.pressure_upper,
+    wind_speed = excluded.wind_speed,
+    wind_speed_lower = excluded.wind_speed_lower,
+    wind_speed_upper = excluded.wind_speed_upper,
+    created_at = excluded.created_at;
+
+-- The horizon counts periods from the issue time to the end of the predicted
+-- period, rounded up, so the first period of an hourly run has horizon 1.
+-- Forecast periods are UTC periods matched with the mean of the observations
+-- within them; observed_to is to_timestamp plus one period.
+-- name: ForecastAccuracy :many
+WITH actual AS (
+    SELECT weather.location_id,
+           date_trunc(sqlc.arg('granularity')::text, weather.timestamp, 'UTC')::timestamptz AS period_start,
+           avg(weather.temperature)::float8 AS temperature,
+           avg(weather.humidity)::float8 AS humidity,
+           avg(weather.pressure)::float8 AS pressure,
+           avg(weather.wind_speed)::float8 AS wind_speed
+    FROM weather
+    WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+      AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR weather.timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+      AND (sqlc.narg('observed_to')::timestamptz IS NULL OR weather.timestamp < sqlc.narg('observed_to')::timestamptz)
+    GROUP BY weather.location_id, period_start
+)
+SELECT forecasts.model,
+       locations.id AS location_id,
+       locations.name AS city,
+       locations.country,
+       ceil(extract(epoch FROM forecasts.target_time - forecasts.issued_at)
+            / CASE forecasts.granularity WHEN 'day' THEN 86400 ELSE 3600 END + 1)::int AS horizon,
+       count(forecasts.temperature) AS temperature_count,
+       COALESCE(avg(abs(forecasts.temperature - actual.temperature)), 0)::float8 AS temperature_mae,
+       COALESCE(sqrt(avg(power(forecasts.temperature - actual.temperature, 2))), 0)::float8 AS temperature_rmse,
+       COALESCE(avg(forecasts.temperature - actual.temperature), 0)::float8 AS temperature_bias,
+       count(forecasts.humidity) AS humidity_count,
+       COALESCE(avg(abs(forecasts.humidity - actual.humidity)), 0)::float8 AS humidity_mae,
+       COALESCE(sqrt(avg(power(forecasts.humidity - actual.humidity, 2))), 0)::float8 AS humidity_rmse,
+       COALESCE(avg(forecasts.humidity - actual.humidity), 0)::float8 AS humidity_bias,
+       count(forecasts.pressure) AS pressure_count,
+       COALESCE(avg(abs(forecasts.pressure - actual.pressure)), 0)::float8 AS pressure_mae,
+       COALESCE(sqrt(avg(power(forecasts.pressure - actual.pressure, 2))), 0)::float8 AS pressure_rmse,
+       COALESCE(avg(forecasts.pressure - actual.pressure), 0)::float8 AS pressure_bias,
+       count(forecasts.wind_speed) AS wind_speed_count,
+       COALESCE(avg(abs(forecasts.wind_speed - actual.wind_speed)), 0)::float8 AS wind_speed_mae,
+       COALESCE(sqrt(avg(power(forecasts.wind_speed - actual.wind_speed, 2))), 0)::float8 AS wind_speed_rmse,
+       COALESCE(avg(forecasts.wind_speed - actual.wind_speed), 0)::float8 AS wind_speed_bias
+FROM forecasts
+JOIN locations ON locations.id = forecasts.location_id
+JOIN actual ON actual.location_id = forecasts.location_id AND actual.period_start = forecasts.target_time
+WHERE forecasts.granularity = sqlc.arg('granularity')::text
+  AND (sqlc.narg('model')::text IS NULL OR forecasts.model = sqlc.narg('model')::text)
+  AND (sqlc.narg('location_id')::bigint IS NULL OR forecasts.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR forecasts.target_time >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR forecasts.target_time < sqlc.narg('to_timestamp')::timestamptz)
+GROUP BY forecasts.model, locations.id, horizon
+ORDER BY forecasts.model, locations.name, locations.country, locations.id, horizon;
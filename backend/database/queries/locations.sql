@@ -0,0 +1,58 @@
+-- name: AddLocation :one
+INSERT INTO locations (name, country, latitude, longitude, elevation, timezone)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetLocation :one
+SELECT *
+FROM locations
+WHERE id = $1;
+
+-- name: ListLocations :many
+SELECT *
+FROM locations
+ORDER BY country, name, id;
+
+-- name: UpdateLocation :one
+UPDATE locations
+SET
+    name = sqlc.arg('name'),
+    country = sqlc.arg('country'),
+    latitude = sqlc.narg('latitude'),
+    longitude = sqlc.narg('longitude'),
+    elevation = sqlc.narg('elevation'),
+    timezone = sqlc.arg('timezone')
+WHERE id = sqlc.arg('id')
+RETURNING *;
+
+-- name: DeleteLocation :one
+DELETE FROM locations
+WHERE id = $1
+RETURNING *;
+
+-- EnsureLocation returns the location matching name and country case-insensitively,
+-- creating it if needed. The no-op update makes RETURNING yield the existing row.
+-- name: EnsureLocation :one
+INSERT INTO locations (name, country)
+VALUES ($1, $2)
+ON CONFLICT (lower(name), lower(country)) DO UPDATE SET name = locations.name
+RETURNING *;
+
+-- EnsureLocations is EnsureLocation for many name and country pairs at once.
+-- The pairs must be distinct case-insensitively, as an upsert cannot touch a
+-- row twice.
+-- name: EnsureLocations :many
+INSERT INTO locations (name, country)
+SELECT unnest(sqlc.arg('names')::text[]), unnest(sqlc.arg('countries')::text[])
+ON CONFLICT (lower(name), lower(country)) DO UPDATE SET name = locations.name
+RETURNING *;
+
+-- name: ListLocationsByID :many
+SELECT *
+FROM locations
+WHERE id = ANY(sqlc.arg('ids')::bigint[]);
+
+-- name: GetLocationByName :one
+SELECT *
+FROM locations
+WHERE lower(name) = lower(sqlc.arg('name')) AND lower(country) = lower(sqlc.arg('country'));
@@ -1,32 +1,394 @@
 -- name: AddWeather :one
-INSERT INTO weather (timestamp, temperature, humidity, pressure, wind_speed, city, country, weather_status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO weather (timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
+-- Ids are drawn from the identity sequence up front so that every inserted row
+-- can be matched back to the position of its input, as RETURNING has no order.
+-- Arrays cannot carry NULLs here: a zero api key id and NaN coordinates stand
+-- for them.
+-- name: AddWeathers :many
+WITH input AS (
+    SELECT nextval(pg_get_serial_sequence('weather', 'id')) AS id, rows.*
+    FROM ROWS FROM (
+        unnest(sqlc.arg('timestamps')::timestamptz[]),
+        unnest(sqlc.arg('temperatures')::double precision[]),
+        unnest(sqlc.arg('humidities')::double precision[]),
+        unnest(sqlc.arg('pressures')::double precision[]),
+        unnest(sqlc.arg('wind_speeds')::double precision[]),
+        unnest(sqlc.arg('location_ids')::bigint[]),
+        unnest(sqlc.arg('weather_statuses')::text[]),
+        unnest(sqlc.arg('api_key_ids')::bigint[]),
+        unnest(sqlc.arg('latitudes')::double precision[]),
+        unnest(sqlc.arg('longitudes')::double precision[])
+    ) WITH ORDINALITY AS rows (timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude, ord)
+), inserted AS (
+    INSERT INTO weather (id, timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude)
+    OVERRIDING SYSTEM VALUE
+    SELECT id, timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status,
+        NULLIF(api_key_id, 0), NULLIF(latitude, 'NaN'), NULLIF(longitude, 'NaN')
+    FROM input
+    RETURNING id
+)
+SELECT input.ord::bigint AS ord, inserted.id
+FROM inserted
+JOIN input ON input.id = inserted.id;
+
 -- name: GetWeather :one
-SELECT * 
+SELECT sqlc.embed(weather), sqlc.embed(locations)
 FROM weather
-WHERE id = $1;
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.id = $1;
 
+-- A NULL version skips the optimistic concurrency check (If-Match: *).
 -- name: UpdateWeather :one
 UPDATE weather
 SET 
-    timestamp = $2,
-    temperature = COALESCE(NULLIF($3, 0), temperature),
-    humidity = COALESCE(NULLIF($4, 0), humidity),
-    pressure = COALESCE(NULLIF($5, 0), pressure),
-    wind_speed = COALESCE(NULLIF($6, 0), wind_speed),
-    city = COALESCE(NULLIF($7, ''), city),
-    country = COALESCE(NULLIF($8, ''), country),
-    weather_status = COALESCE(NULLIF($9, ''), weather_status)
-WHERE id = $1
+    timestamp = sqlc.arg('timestamp'),
+    temperature = sqlc.arg('temperature'),
+    humidity = sqlc.arg('humidity'),
+    pressure = sqlc.arg('pressure'),
+    wind_speed = sqlc.arg('wind_speed'),
+    location_id = sqlc.arg('location_id'),
+    weather_status = sqlc.arg('weather_status'),
+    latitude = sqlc.narg('latitude'),
+    longitude = sqlc.narg('longitude'),
+    version = version + 1
+WHERE id = sqlc.arg('id')
+  AND (sqlc.narg('version')::int IS NULL OR version = sqlc.narg('version')::int)
+RETURNING *;
+
+-- Apart from the coordinates every column is NOT NULL, so a NULL argument
+-- unambiguously means that the field was not provided and keeps its current
+-- value. Coordinates may be cleared, hence the explicit set_coordinates flag.
+-- name: PatchWeather :one
+UPDATE weather
+SET
+    timestamp = COALESCE(sqlc.narg('timestamp')::timestamptz, timestamp),
+    temperature = COALESCE(sqlc.narg('temperature')::float8, temperature),
+    humidity = COALESCE(sqlc.narg('humidity')::float8, humidity),
+    pressure = COALESCE(sqlc.narg('pressure')::float8, pressure),
+    wind_speed = COALESCE(sqlc.narg('wind_speed')::float8, wind_speed),
+    location_id = COALESCE(sqlc.narg('location_id')::bigint, location_id),
+    weather_status = COALESCE(sqlc.narg('weather_status')::text, weather_status),
+    latitude = CASE WHEN sqlc.arg('set_coordinates')::bool THEN sqlc.narg('latitude')::float8 ELSE latitude END,
+    longitude = CASE WHEN sqlc.arg('set_coordinates')::bool THEN sqlc.narg('longitude')::float8 ELSE longitude END,
+    version = version + 1
+WHERE id = sqlc.arg('id')
+  AND (sqlc.narg('version')::int IS NULL OR version = sqlc.narg('version')::int)
 RETURNING *;
 
 -- name: DeleteWeather :one
 DELETE FROM weather
-WHERE id = $1
+WHERE id = sqlc.arg('id')
+  AND (sqlc.narg('version')::int IS NULL OR version = sqlc.narg('version')::int)
 RETURNING *;
 
--- name: ListWeathers :many
-SELECT * 
-FROM weather;
+-- name: ListWeathersByTimestampAsc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (timestamp, weather.id) > (sqlc.narg('cursor_timestamp')::timestamptz, sqlc.narg('cursor_id')::bigint))
+ORDER BY timestamp ASC, weather.id ASC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByTimestampDesc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (timestamp, weather.id) < (sqlc.narg('cursor_timestamp')::timestamptz, sqlc.narg('cursor_id')::bigint))
+ORDER BY timestamp DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByTemperatureAsc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (temperature, weather.id) > (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY temperature ASC, weather.id ASC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByTemperatureDesc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (temperature, weather.id) < (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY temperature DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByHumidityAsc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (humidity, weather.id) > (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY humidity ASC, weather.id ASC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByHumidityDesc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (humidity, weather.id) < (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY humidity DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByPressureAsc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (pressure, weather.id) > (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY pressure ASC, weather.id ASC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByPressureDesc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (pressure, weather.id) < (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY pressure DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByWindSpeedAsc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (wind_speed, weather.id) > (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY wind_speed ASC, weather.id ASC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersByWindSpeedDesc :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('weather_status')::text IS NULL OR weather_status = sqlc.narg('weather_status')::text)
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR timestamp < sqlc.narg('to_timestamp')::timestamptz)
+  AND (sqlc.narg('min_temperature')::float8 IS NULL OR temperature >= sqlc.narg('min_temperature')::float8)
+  AND (sqlc.narg('max_temperature')::float8 IS NULL OR temperature <= sqlc.narg('max_temperature')::float8)
+  AND (sqlc.narg('min_humidity')::float8 IS NULL OR humidity >= sqlc.narg('min_humidity')::float8)
+  AND (sqlc.narg('max_humidity')::float8 IS NULL OR humidity <= sqlc.narg('max_humidity')::float8)
+  AND (sqlc.narg('min_pressure')::float8 IS NULL OR pressure >= sqlc.narg('min_pressure')::float8)
+  AND (sqlc.narg('max_pressure')::float8 IS NULL OR pressure <= sqlc.narg('max_pressure')::float8)
+  AND (sqlc.narg('min_wind_speed')::float8 IS NULL OR wind_speed >= sqlc.narg('min_wind_speed')::float8)
+  AND (sqlc.narg('max_wind_speed')::float8 IS NULL OR wind_speed <= sqlc.narg('max_wind_speed')::float8)
+  AND (sqlc.narg('cursor_id')::bigint IS NULL
+       OR (wind_speed, weather.id) < (sqlc.narg('cursor_value')::float8, sqlc.narg('cursor_id')::bigint))
+ORDER BY wind_speed DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- The latitude/longitude box is a coarse prefilter served by
+-- weather_latitude_longitude_idx; min_longitude > max_longitude means that the
+-- box crosses the antimeridian.
+-- name: ListWeathersNear :many
+SELECT sqlc.embed(weather), sqlc.embed(locations),
+       haversine_km(sqlc.arg('latitude')::float8, sqlc.arg('longitude')::float8, weather.latitude, weather.longitude)::float8 AS distance_km
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.latitude BETWEEN sqlc.arg('min_latitude')::float8 AND sqlc.arg('max_latitude')::float8
+  AND ((sqlc.arg('min_longitude')::float8 <= sqlc.arg('max_longitude')::float8
+        AND weather.longitude BETWEEN sqlc.arg('min_longitude')::float8 AND sqlc.arg('max_longitude')::float8)
+       OR (sqlc.arg('min_longitude')::float8 > sqlc.arg('max_longitude')::float8
+           AND (weather.longitude >= sqlc.arg('min_longitude')::float8 OR weather.longitude <= sqlc.arg('max_longitude')::float8)))
+  AND haversine_km(sqlc.arg('latitude')::float8, sqlc.arg('longitude')::float8, weather.latitude, weather.longitude) <= sqlc.arg('radius_km')::float8
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR weather.timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR weather.timestamp < sqlc.narg('to_timestamp')::timestamptz)
+ORDER BY distance_km ASC, weather.timestamp DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- name: ListWeathersInBBox :many
+SELECT sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.latitude BETWEEN sqlc.arg('min_latitude')::float8 AND sqlc.arg('max_latitude')::float8
+  AND ((sqlc.arg('min_longitude')::float8 <= sqlc.arg('max_longitude')::float8
+        AND weather.longitude BETWEEN sqlc.arg('min_longitude')::float8 AND sqlc.arg('max_longitude')::float8)
+       OR (sqlc.arg('min_longitude')::float8 > sqlc.arg('max_longitude')::float8
+           AND (weather.longitude >= sqlc.arg('min_longitude')::float8 OR weather.longitude <= sqlc.arg('max_longitude')::float8)))
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR weather.timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR weather.timestamp < sqlc.narg('to_timestamp')::timestamptz)
+ORDER BY weather.timestamp DESC, weather.id DESC
+LIMIT sqlc.arg('page_size');
+
+-- bucket is one of hour, day, week or month and is validated by the caller.
+-- Periods start at local midnight (or hour, week, month) of every location
+-- unless time_zone overrides the location's own time zone.
+-- name: WeatherStats :many
+SELECT locations.id AS location_id,
+       locations.name AS city,
+       locations.country,
+       COALESCE(sqlc.narg('time_zone')::text, locations.timezone)::text AS timezone,
+       date_trunc(sqlc.arg('bucket')::text, weather.timestamp,
+                  COALESCE(sqlc.narg('time_zone')::text, locations.timezone))::timestamptz AS period_start,
+       count(*) AS count,
+       min(weather.temperature)::float8 AS min_temperature,
+       max(weather.temperature)::float8 AS max_temperature,
+       avg(weather.temperature)::float8 AS mean_temperature,
+       stddev_pop(weather.temperature)::float8 AS stddev_temperature,
+       min(weather.humidity)::float8 AS min_humidity,
+       max(weather.humidity)::float8 AS max_humidity,
+       avg(weather.humidity)::float8 AS mean_humidity,
+       stddev_pop(weather.humidity)::float8 AS stddev_humidity,
+       min(weather.pressure)::float8 AS min_pressure,
+       max(weather.pressure)::float8 AS max_pressure,
+       avg(weather.pressure)::float8 AS mean_pressure,
+       stddev_pop(weather.pressure)::float8 AS stddev_pressure,
+       min(weather.wind_speed)::float8 AS min_wind_speed,
+       max(weather.wind_speed)::float8 AS max_wind_speed,
+       avg(weather.wind_speed)::float8 AS mean_wind_speed,
+       stddev_pop(weather.wind_speed)::float8 AS stddev_wind_speed
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('location_id')::bigint IS NULL OR weather.location_id = sqlc.narg('location_id')::bigint)
+  AND (sqlc.narg('city')::text IS NULL OR lower(locations.name) = lower(sqlc.narg('city')::text))
+  AND (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('from_timestamp')::timestamptz IS NULL OR weather.timestamp >= sqlc.narg('from_timestamp')::timestamptz)
+  AND (sqlc.narg('to_timestamp')::timestamptz IS NULL OR weather.timestamp < sqlc.narg('to_timestamp')::timestamptz)
+GROUP BY locations.id, period_start
+ORDER BY locations.name, locations.country, locations.id, period_start;
+
+-- Cities are compared in lower case, so callers pass them lowered.
+-- name: LatestWeathers :many
+SELECT DISTINCT ON (weather.location_id) sqlc.embed(weather), sqlc.embed(locations)
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE (sqlc.narg('country')::text IS NULL OR lower(locations.country) = lower(sqlc.narg('country')::text))
+  AND (sqlc.narg('cities')::text[] IS NULL OR lower(locations.name) = ANY(sqlc.narg('cities')::text[]))
+ORDER BY weather.location_id, weather.timestamp DESC, weather.id DESC;
@@ -0,0 +1,38 @@
+-- name: ListRoles :many
+SELECT r.name,
+       r.description,
+       COALESCE(
+         array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL),
+         '{}'
+       )::text[] AS permissions
+FROM roles r
+LEFT JOIN role_permissions rp ON rp.role = r.name
+GROUP BY r.name
+ORDER BY r.name;
+
+-- name: ListUserRoles :many
+SELECT role
+FROM user_roles
+WHERE user_id = $1
+ORDER BY role;
+
+-- name: ListAllUserRoles :many
+SELECT *
+FROM user_roles
+ORDER BY user_id, role;
+
+-- name: ListUserPermissions :many
+SELECT DISTINCT rp.permission
+FROM user_roles ur
+JOIN role_permissions rp ON rp.role = ur.role
+WHERE ur.user_id = $1
+ORDER BY rp.permission;
+
+-- name: AddUserRole :exec
+INSERT INTO user_roles (user_id, role)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteUserRoles :exec
+DELETE FROM user_roles
+WHERE user_id = $1;
@@ -0,0 +1,35 @@
+-- name: AddUser :one
+INSERT INTO users (username, password_hash)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetUser :one
+SELECT *
+FROM users
+WHERE id = $1;
+
+-- name: GetUserByUsername :one
+SELECT *
+FROM users
+WHERE username = $1;
+
+-- name: ListUsers :many
+SELECT *
+FROM users
+ORDER BY id;
+
+-- name: AddRefreshToken :one
+INSERT INTO refresh_tokens (id, user_id, expires_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetRefreshToken :one
+SELECT *
+FROM refresh_tokens
+WHERE id = $1;
+
+-- name: RevokeRefreshToken :one
+UPDATE refresh_tokens
+SET revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING id;
@@ -1,13 +1,138 @@
+CREATE TABLE locations
+(
+  id        BIGINT           NOT NULL GENERATED ALWAYS AS IDENTITY,
+  name      TEXT             NOT NULL,
+  country   TEXT             NOT NULL,
+  latitude  double precision,
+  longitude double precision,
+  elevation double precision,
+  timezone  TEXT             NOT NULL DEFAULT 'UTC',
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX locations_name_country_key ON locations (lower(name), lower(country));
+
 CREATE TABLE weather
 (
   id             BIGINT           NOT NULL GENERATED ALWAYS AS IDENTITY,
-  timestamp      timestamp        NOT NULL,
-  city           TEXT             NOT NULL,
-  country        TEXT             NOT NULL,
+  timestamp      timestamptz      NOT NULL,
+  location_id    BIGINT           NOT NULL REFERENCES locations (id) ON DELETE RESTRICT,
   temperature    double precision NOT NULL,
   humidity       double precision NOT NULL,
   pressure       double precision NOT NULL,
   wind_speed     double precision NOT NULL,
   weather_status TEXT             NOT NULL,
+  api_key_id     BIGINT           REFERENCES api_keys (id) ON DELETE SET NULL,
+  version        INTEGER          NOT NULL DEFAULT 1,
+  latitude       double precision,
+  longitude      double precision,
+  PRIMARY KEY (id)
+);
+
+CREATE INDEX weather_latitude_longitude_idx ON weather (latitude, longitude)
+WHERE latitude IS NOT NULL;
+
+CREATE FUNCTION haversine_km(
+  lat1 double precision,
+  lon1 double precision,
+  lat2 double precision,
+  lon2 double precision
+) RETURNS double precision
+LANGUAGE sql IMMUTABLE STRICT PARALLEL SAFE
+AS $$
+  SELECT 2 * 6371.0088 * asin(least(1, sqrt(
+    power(sin(radians(lat2 - lat1) / 2), 2) +
+    cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lon2 - lon1) / 2), 2)
+  )))
+$$;
+
+CREATE TABLE forecasts
+(
+  id                BIGINT           NOT NULL GENERATED ALWAYS AS IDENTITY,
+  location_id       BIGINT           NOT NULL REFERENCES locations (id) ON DELETE CASCADE,
+  model             TEXT             NOT NULL,
+  granularity       TEXT             NOT NULL CHECK (granularity IN ('hour', 'day')),
+  issued_at         timestamptz      NOT NULL,
+  target_time       timestamptz      NOT NULL,
+  temperature       double precision,
+  temperature_lower double precision,
+  temperature_upper double precision,
+  humidity          double precision,
+  humidity_lower    double precision,
+  humidity_upper    double precision,
+  pressure          double precision,
+  pressure_lower    double precision,
+  pressure_upper    double precision,
+  wind_speed        double precision,
+  wind_speed_lower  double precision,
+  wind_speed_upper  double precision,
+  created_at        timestamptz      NOT NULL DEFAULT now(),
+  PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX forecasts_run_target_key ON forecasts (location_id, model, granularity, issued_at, target_time);
+
+CREATE INDEX forecasts_granularity_target_time_idx ON forecasts (granularity, target_time);
+
+CREATE TABLE users
+(
+  id            BIGINT      NOT NULL GENERATED ALWAYS AS IDENTITY,
+  username      TEXT        NOT NULL,
+  password_hash TEXT        NOT NULL,
+  created_at    timestamptz NOT NULL DEFAULT now(),
+  PRIMARY KEY (id),
+  UNIQUE (username)
+);
+
+CREATE TABLE refresh_tokens
+(
+  id         TEXT        NOT NULL,
+  user_id    BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  expires_at timestamptz NOT NULL,
+  revoked_at timestamptz,
   PRIMARY KEY (id)
-);
\ No newline at end of file
+);
+
+CREATE TABLE roles
+(
+  name        TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (name)
+);
+
+CREATE TABLE permissions
+(
+  name        TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  PRIMARY KEY (name)
+);
+
+CREATE TABLE role_permissions
+(
+  role       TEXT NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+  permission TEXT NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+  PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE user_roles
+(
+  user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  role    TEXT   NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+  PRIMARY KEY (user_id, role)
+);
+
+CREATE TABLE api_keys
+(
+  id           BIGINT      NOT NULL GENERATED ALWAYS AS IDENTITY,
+  name         TEXT        NOT NULL,
+  prefix       TEXT        NOT NULL,
+  key_hash     TEXT        NOT NULL,
+  cities       TEXT[]      NOT NULL DEFAULT '{}',
+  permissions  TEXT[]      NOT NULL DEFAULT '{}',
+  created_by   BIGINT      REFERENCES users (id) ON DELETE SET NULL,
+  created_at   timestamptz NOT NULL DEFAULT now(),
+  last_used_at timestamptz,
+  revoked_at   timestamptz,
+  PRIMARY KEY (id),
+  UNIQUE (prefix)
+);
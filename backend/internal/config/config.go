@@ -3,13 +3,22 @@ package config
 import (
 	"fmt"
 
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/logging"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tranport/http"
 	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/postgres"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
 	postgres.PostgresConfig
-	RESTServerPort int `env:"REST_SERVER_PORT" env-default:"8080"`
+	service.AuthConfig
+	service.HealthConfig
+	http.ServerConfig
+	tracing.TracingConfig
+	logging.LoggingConfig
+	AutoMigrate bool `env:"AUTO_MIGRATE" env-default:"false"`
 }
 
 func New() (*Config, error) {
@@ -0,0 +1,221 @@
+// Package forecast fits exponential smoothing models to evenly spaced series
+// and extrapolates them with approximate prediction intervals.
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	ModelHolt        = "holt"
+	ModelHoltWinters = "holt_winters"
+
+	// MinPoints is the shortest series Predict accepts.
+	MinPoints = 3
+
+	// phi damps the trend so that long horizons level off instead of
+	// extrapolating a short-term slope indefinitely.
+	phi = 0.98
+	// z95 is the two-sided 95% quantile of the standard normal distribution.
+	z95 = 1.959964
+)
+
+var ErrNotEnoughData = errors.New("not enough data to forecast")
+
+// grid holds the candidate smoothing parameters. Every combination is tried
+// and the one with the smallest one-step-ahead squared error wins.
+var grid = []float64{0.05, 0.1, 0.2, 0.3, 0.5, 0.7, 0.9}
+
+type Prediction struct {
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+type Result struct {
+	Model       string
+	Predictions []Prediction
+}
+
+type params struct {
+	alpha float64
+	beta  float64
+	gamma float64
+}
+
+type state struct {
+	level  float64
+	trend  float64
+	season []float64
+	sse    float64
+	count  int
+}
+
+// Predict forecasts horizon steps after the last value. Additive Holt-Winters
+// is used when period > 1 and the series spans at least two seasons, damped
+// Holt otherwise. The 95% interval assumes normally distributed one-step
+// errors whose variance grows linearly with the horizon.
+func Predict(values []float64, period, horizon int) (Result, error) {
+	if len(values) < MinPoints {
+		return Result{}, ErrNotEnoughData
+	}
+
+	model := ModelHolt
+	if period > 1 && len(values) >= 2*period {
+		model = ModelHoltWinters
+	} else {
+		period = 1
+	}
+
+	gammas := grid
+	if period == 1 {
+		gammas = []float64{0}
+	}
+
+	var (
+		best    state
+		bestSSE = math.Inf(1)
+	)
+
+	for _, alpha := range grid {
+		for _, beta := range grid {
+			for _, gamma := range gammas {
+				s := run(values, period, params{alpha: alpha, beta: beta, gamma: gamma})
+				if s.sse < bestSSE {
+					best, bestSSE = s, s.sse
+				}
+			}
+		}
+	}
+
+	sigma := 0.0
+	if best.count > 0 {
+		sigma = math.Sqrt(best.sse / float64(best.count))
+	}
+
+	res := Result{
+		Model:       model,
+		Predictions: make([]Prediction, horizon),
+	}
+
+	damped := 0.0
+	for h := 1; h <= horizon; h++ {
+		damped += math.Pow(phi, float64(h))
+
+		value := best.level + damped*best.trend
+		if best.season != nil {
+			value += best.season[(len(values)+h-1)%period]
+		}
+
+		width := z95 * sigma * math.Sqrt(float64(h))
+		res.Predictions[h-1] = Prediction{
+			Value: value,
+			Lower: value - width,
+			Upper: value + width,
+		}
+	}
+
+	return res, nil
+}
+
+// run smooths the series with the given parameters. Errors made while the
+// components are still warming up are not counted.
+func run(values []float64, period int, p params) state {
+	var (
+		s      state
+		start  int
+		warmup int
+	)
+
+	if period > 1 {
+		first := mean(values[:period])
+		second := mean(values[period : 2*period])
+
+		s.level = first
+		s.trend = (second - first) / float64(period)
+		s.season = make([]float64, period)
+
+		for i := range s.season {
+			s.season[i] = values[i] - first
+		}
+
+		warmup = period
+	} else {
+		s.level = values[0]
+		s.trend = values[1] - values[0]
+		start, warmup = 1, 2
+	}
+
+	for t := start; t < len(values); t++ {
+		var seasonal float64
+		if s.season != nil {
+			seasonal = s.season[t%period]
+		}
+
+		y := values[t]
+
+		if t >= warmup {
+			e := y - (s.level + phi*s.trend + seasonal)
+			s.sse += e * e
+			s.count++
+		}
+
+		prevLevel := s.level
+		s.level = p.alpha*(y-seasonal) + (1-p.alpha)*(prevLevel+phi*s.trend)
+		s.trend = p.beta*(s.level-prevLevel) + (1-p.beta)*phi*s.trend
+
+		if s.season != nil {
+			s.season[t%period] = p.gamma*(y-s.level) + (1-p.gamma)*seasonal
+		}
+	}
+
+	return s
+}
+
+// FillGaps replaces NaN values in place by linear interpolation between their
+// neighbours. Leading and trailing gaps take the nearest known value. It
+// returns the number of known values.
+func FillGaps(values []float64) int {
+	known := 0
+	prev := -1
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		known++
+
+		switch {
+		case prev == -1:
+			for j := 0; j < i; j++ {
+				values[j] = v
+			}
+		case i-prev > 1:
+			step := (v - values[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				values[j] = values[prev] + step*float64(j-prev)
+			}
+		}
+
+		prev = i
+	}
+
+	if prev != -1 {
+		for j := prev + 1; j < len(values); j++ {
+			values[j] = values[prev]
+		}
+	}
+
+	return known
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
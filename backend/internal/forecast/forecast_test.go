@@ -0,0 +1,112 @@
+package forecast_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/forecast"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPredict(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name          string
+		values        []float64
+		period        int
+		horizon       int
+		expectedModel string
+		expected      []float64
+		tolerance     float64
+		err           error
+	}
+
+	// cycle is a clean daily cycle; the fourth day is what a model should predict
+	cycle := func(days int) []float64 {
+		values := make([]float64, days*24)
+		for i := range values {
+			values[i] = 10 + 5*math.Sin(2*math.Pi*float64(i)/24)
+		}
+
+		return values
+	}
+
+	tt := []TestCase{
+		{
+			name:          "constant series",
+			values:        []float64{7, 7, 7, 7, 7},
+			period:        24,
+			horizon:       3,
+			expectedModel: forecast.ModelHolt,
+			expected:      []float64{7, 7, 7},
+			tolerance:     1e-9,
+		},
+		{
+			name:          "daily cycle",
+			values:        cycle(3),
+			period:        24,
+			horizon:       24,
+			expectedModel: forecast.ModelHoltWinters,
+			expected:      cycle(4)[72:],
+			tolerance:     0.5,
+		},
+		{
+			name:    "too short",
+			values:  []float64{1, 2},
+			period:  1,
+			horizon: 1,
+			err:     forecast.ErrNotEnoughData,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := forecast.Predict(tc.values, tc.period, tc.horizon)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedModel, res.Model)
+			require.Len(t, res.Predictions, tc.horizon)
+
+			for i, p := range res.Predictions {
+				assert.InDelta(t, tc.expected[i], p.Value, tc.tolerance, "step %d", i+1)
+				assert.LessOrEqual(t, p.Lower, p.Value)
+				assert.GreaterOrEqual(t, p.Upper, p.Value)
+			}
+		})
+	}
+}
+
+func TestPredictIntervalsWiden(t *testing.T) {
+	t.Parallel()
+
+	values := []float64{10, 12, 9, 13, 11, 10, 14, 9, 12, 11}
+
+	res, err := forecast.Predict(values, 1, 5)
+	require.NoError(t, err)
+
+	for i := 1; i < len(res.Predictions); i++ {
+		prev, cur := res.Predictions[i-1], res.Predictions[i]
+		assert.Greater(t, cur.Upper-cur.Lower, prev.Upper-prev.Lower)
+	}
+}
+
+func TestFillGaps(t *testing.T) {
+	t.Parallel()
+
+	nan := math.NaN()
+	values := []float64{nan, 1, nan, nan, 4, nan}
+
+	known := forecast.FillGaps(values)
+
+	assert.Equal(t, 2, known)
+	assert.Equal(t, []float64{1, 1, 2, 3, 4, 4}, values)
+}
@@ -0,0 +1,187 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/logging"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var res []map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+
+		res = append(res, record)
+	}
+
+	return res
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger, err := logging.New(logging.LoggingConfig{LogLevel: "warn", LogFormat: logging.FormatJSON}, &buf)
+	require.NoError(t, err)
+
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "dropped")
+	logger.WarnContext(ctx, "kept", "city", "Minsk")
+
+	rs := records(t, &buf)
+	require.Len(t, rs, 1)
+	assert.Equal(t, "kept", rs[0]["msg"])
+	assert.Equal(t, "req-1", rs[0]["request_id"])
+	assert.Equal(t, "Minsk", rs[0]["city"])
+
+	_, err = logging.New(logging.LoggingConfig{LogLevel: "loud", LogFormat: logging.FormatJSON}, &buf)
+	assert.Error(t, err)
+
+	_, err = logging.New(logging.LoggingConfig{LogLevel: "info", LogFormat: "xml"}, &buf)
+	assert.ErrorIs(t, err, logging.ErrUnsupportedFormat)
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		requestID     string
+		path          string
+		principal     *models.Principal
+		expectedID    string
+		expectedLevel string
+		expectedError string
+		status        int
+	}
+
+	tt := []testCase{
+		{
+			name:          "Propagates request ID",
+			requestID:     "abc-123",
+			path:          "/weather/1",
+			principal:     &models.Principal{UserID: 4, Username: "alice"},
+			expectedID:    "abc-123",
+			expectedLevel: "INFO",
+			status:        http.StatusOK,
+		},
+		{
+			name:          "Generates request ID",
+			path:          "/weather/1",
+			expectedLevel: "INFO",
+			status:        http.StatusOK,
+		},
+		{
+			name:          "Replaces malformed request ID",
+			requestID:     "bad id\n",
+			path:          "/weather/1",
+			expectedLevel: "INFO",
+			status:        http.StatusOK,
+		},
+		{
+			name:          "Failed request",
+			requestID:     "abc-500",
+			path:          "/weather/0",
+			expectedID:    "abc-500",
+			expectedLevel: "ERROR",
+			expectedError: "code=500, message=boom",
+			status:        http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			logger, err := logging.New(logging.LoggingConfig{LogLevel: "info", LogFormat: logging.FormatJSON}, &buf)
+			require.NoError(t, err)
+
+			var handlerID string
+
+			e := echo.New()
+			e.HTTPErrorHandler = apierror.Handler
+			e.Use(logging.Middleware(logger))
+			e.GET("/weather/:id", func(c echo.Context) error {
+				ctx := c.Request().Context()
+				handlerID, _ = logging.RequestIDFromContext(ctx)
+
+				if tc.principal != nil {
+					c.SetRequest(c.Request().WithContext(auth.WithPrincipal(ctx, tc.principal)))
+				}
+
+				if c.Param("id") == "0" {
+					return echo.NewHTTPError(http.StatusInternalServerError, "boom")
+				}
+
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.requestID != "" {
+				req.Header.Set(echo.HeaderXRequestID, tc.requestID)
+			}
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.status, rec.Code)
+
+			id := rec.Header().Get(echo.HeaderXRequestID)
+			if tc.expectedID != "" {
+				assert.Equal(t, tc.expectedID, id)
+			} else {
+				assert.Len(t, id, 32)
+			}
+
+			assert.Equal(t, id, handlerID)
+
+			rs := records(t, &buf)
+			require.Len(t, rs, 1)
+
+			access := rs[0]
+			assert.Equal(t, "request", access["msg"])
+			assert.Equal(t, tc.expectedLevel, access["level"])
+			assert.Equal(t, id, access["request_id"])
+			assert.Equal(t, "/weather/:id", access["route"])
+			assert.Equal(t, tc.path, access["path"])
+			assert.EqualValues(t, tc.status, access["status"])
+			assert.Contains(t, access, "latency")
+
+			if tc.expectedError != "" {
+				assert.Equal(t, tc.expectedError, access["error"])
+			} else {
+				assert.NotContains(t, access, "error")
+			}
+
+			if tc.principal != nil {
+				assert.Equal(t, map[string]any{
+					"user_id":    float64(4),
+					"username":   "alice",
+					"api_key_id": float64(0),
+				}, access["principal"])
+			} else {
+				assert.NotContains(t, access, "principal")
+			}
+		})
+	}
+}
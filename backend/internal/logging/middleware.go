@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+const maxRequestIDLength = 128
+
+// Middleware assigns every request an ID, keeping the X-Request-ID of the
+// caller if it sent a sane one, returns it in the response and puts it into
+// the request context. Once the request is done it logs one access record,
+// with the handler error if there was one. Being the outermost middleware, it
+// is the one to hand that error to the error handler.
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			req := c.Request()
+
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(id) {
+				id = newRequestID()
+			}
+
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			c.SetRequest(req.WithContext(WithRequestID(req.Context(), id)))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			// the handlers may have replaced the request, e.g. to add the principal
+			ctx := c.Request().Context()
+			status := c.Response().Status
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+				slog.String("path", req.URL.Path),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.Int64("bytes", c.Response().Size),
+				slog.String("remote_ip", c.RealIP()),
+			}
+
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			if principal, ok := auth.PrincipalFromContext(ctx); ok {
+				attrs = append(attrs, slog.Group("principal",
+					slog.Int("user_id", principal.UserID),
+					slog.String("username", principal.Username),
+					slog.Int("api_key_id", principal.APIKeyID),
+				))
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+
+			logger.LogAttrs(ctx, level, "request", attrs...)
+
+			return nil
+		}
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	return hex.EncodeToString(b[:])
+}
@@ -0,0 +1,97 @@
+// Package meteo derives comfort and moisture indices from temperature (°C),
+// relative humidity (%) and wind speed (m/s).
+package meteo
+
+import "math"
+
+const (
+	// Magnus coefficients over water, valid from -45°C to 60°C.
+	magnusA = 17.62
+	magnusB = 243.12
+
+	// The Rothfusz regression of the heat index is only meaningful in the heat.
+	minHeatIndexTemperature = 26.7
+	// The wind chill formula is defined for cold air and noticeable wind.
+	maxWindChillTemperature = 10.0
+	minWindChillSpeedKmh    = 4.8
+)
+
+// beaufortLimits holds the upper wind speed bound (m/s) of Beaufort forces
+// 0 through 11. Anything faster is force 12.
+var beaufortLimits = []float64{
+	0.5, 1.5, 3.3, 5.5, 7.9, 10.7, 13.8, 17.1, 20.7, 24.4, 28.4, 32.6,
+}
+
+// DewPoint returns the temperature to which air must be cooled to become
+// saturated. It is undefined for completely dry air.
+func DewPoint(temperature, humidity float64) (float64, bool) {
+	if humidity <= 0 {
+		return 0, false
+	}
+
+	gamma := math.Log(humidity/100) + magnusA*temperature/(magnusB+temperature)
+
+	return magnusB * gamma / (magnusA - gamma), true
+}
+
+// VaporPressure returns the partial pressure of water vapour in hPa.
+func VaporPressure(temperature, humidity float64) float64 {
+	return humidity / 100 * 6.112 * math.Exp(magnusA*temperature/(magnusB+temperature))
+}
+
+// AbsoluteHumidity returns the mass of water vapour in g/m³.
+func AbsoluteHumidity(temperature, humidity float64) float64 {
+	return 216.7 * VaporPressure(temperature, humidity) / (273.15 + temperature)
+}
+
+// HeatIndex returns the NWS heat index. It is only defined from 26.7°C up.
+func HeatIndex(temperature, humidity float64) (float64, bool) {
+	if temperature < minHeatIndexTemperature {
+		return 0, false
+	}
+
+	t, rh := temperature*9/5+32, humidity
+
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh -
+		0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+		0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+	switch {
+	case rh < 13 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+
+	return (hi - 32) * 5 / 9, true
+}
+
+// WindChill returns the North American wind chill index. It is only defined
+// at or below 10°C with wind above 4.8 km/h.
+func WindChill(temperature, windSpeed float64) (float64, bool) {
+	kmh := windSpeed * 3.6
+	if temperature > maxWindChillTemperature || kmh <= minWindChillSpeedKmh {
+		return 0, false
+	}
+
+	v := math.Pow(kmh, 0.16)
+
+	return 13.12 + 0.6215*temperature - 11.37*v + 0.3965*temperature*v, true
+}
+
+// ApparentTemperature returns Steadman's apparent temperature for shade as
+// used by the Australian Bureau of Meteorology.
+func ApparentTemperature(temperature, humidity, windSpeed float64) float64 {
+	return temperature + 0.33*VaporPressure(temperature, humidity) - 0.7*windSpeed - 4
+}
+
+// Beaufort returns the Beaufort force, 0 to 12, of a wind speed.
+func Beaufort(windSpeed float64) int {
+	for force, limit := range beaufortLimits {
+		if windSpeed < limit {
+			return force
+		}
+	}
+
+	return len(beaufortLimits)
+}
@@ -0,0 +1,73 @@
+package meteo_test
+
+import (
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/meteo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDewPoint(t *testing.T) {
+	t.Parallel()
+
+	dp, ok := meteo.DewPoint(20, 50)
+	assert.True(t, ok)
+	assert.InDelta(t, 9.3, dp, 0.1)
+
+	dp, ok = meteo.DewPoint(15, 100)
+	assert.True(t, ok)
+	assert.InDelta(t, 15, dp, 1e-9)
+
+	_, ok = meteo.DewPoint(20, 0)
+	assert.False(t, ok)
+}
+
+func TestAbsoluteHumidity(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 17.3, meteo.AbsoluteHumidity(20, 100), 0.1)
+	assert.InDelta(t, 0, meteo.AbsoluteHumidity(20, 0), 1e-9)
+}
+
+func TestHeatIndex(t *testing.T) {
+	t.Parallel()
+
+	// 90°F at 70% is 106°F according to the NWS table
+	hi, ok := meteo.HeatIndex(32.22, 70)
+	assert.True(t, ok)
+	assert.InDelta(t, 41.1, hi, 0.3)
+
+	_, ok = meteo.HeatIndex(20, 70)
+	assert.False(t, ok)
+}
+
+func TestWindChill(t *testing.T) {
+	t.Parallel()
+
+	// -10°C with 30 km/h wind is -20°C according to the MSC table
+	wc, ok := meteo.WindChill(-10, 30/3.6)
+	assert.True(t, ok)
+	assert.InDelta(t, -19.5, wc, 0.5)
+
+	_, ok = meteo.WindChill(15, 10)
+	assert.False(t, ok)
+
+	_, ok = meteo.WindChill(-10, 1)
+	assert.False(t, ok)
+}
+
+func TestApparentTemperature(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 18.4, meteo.ApparentTemperature(20, 50, 2), 0.1)
+}
+
+func TestBeaufort(t *testing.T) {
+	t.Parallel()
+
+	tt := map[float64]int{0: 0, 0.5: 1, 3: 2, 10: 5, 20: 8, 32.6: 12, 60: 12}
+	for speed, force := range tt {
+		assert.Equal(t, force, meteo.Beaufort(speed), "wind speed %v", speed)
+	}
+}
@@ -0,0 +1,136 @@
+// Package metrics collects the Prometheus metrics of the service: HTTP
+// requests, database queries and pool, and ingested observations. Everything
+// is registered on a registry of its own, exposed by Handler.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "weather"
+
+// routeUnmatched labels requests that matched no route at all. Echo reports
+// the closest registered template otherwise, so random paths never create a
+// series per path.
+const routeUnmatched = "unmatched"
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+	dbErrors     *prometheus.CounterVec
+	ingested     *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Database query latency by query name.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"query"}),
+		dbErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_errors_total",
+			Help:      "Failed database queries by query name.",
+		}, []string{"query"}),
+		ingested: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "observations_ingested_total",
+			Help:      "Stored weather observations by location id and its stored city and country.",
+		}, []string{"location_id", "city", "country"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.dbDuration,
+		m.dbErrors,
+		m.ingested,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records every request under its route template. Errors are
+// returned as is, their status code is the one the error handler will send.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = routeUnmatched
+			}
+
+			labels := prometheus.Labels{
+				"method": c.Request().Method,
+				"route":  route,
+				"status": strconv.Itoa(apierror.Status(c, err)),
+			}
+
+			m.httpRequests.With(labels).Inc()
+			m.httpDuration.With(labels).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
+
+// ObserveQuery records the duration of a database query and whether it failed.
+func (m *Metrics) ObserveQuery(name string, duration time.Duration, err error) {
+	m.dbDuration.WithLabelValues(name).Observe(duration.Seconds())
+
+	if err != nil {
+		m.dbErrors.WithLabelValues(name).Inc()
+	}
+}
+
+// ObservationsIngested counts n observations stored for a location.
+func (m *Metrics) ObservationsIngested(locationID int, city, country string, n int) {
+	m.ingested.WithLabelValues(strconv.Itoa(locationID), city, country).Add(float64(n))
+}
+
+// RegisterDB exposes the connection pool statistics of db.
+func (m *Metrics) RegisterDB(name string, db *sql.DB) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
@@ -0,0 +1,125 @@
+package metrics_test
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/metrics"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+
+	"github.com/labstack/echo/v4"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+
+	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
+	e.Use(m.Middleware())
+	e.GET("/weather/:id", func(c echo.Context) error {
+		if c.Param("id") == "0" {
+			return echo.NewHTTPError(http.StatusNotFound, "not found")
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/weather/1", "/weather/2", "/weather/0", "/nowhere/at/all"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `weather_http_requests_total{method="GET",route="/weather/:id",status="200"} 2`)
+	assert.Contains(t, body, `weather_http_requests_total{method="GET",route="/weather/:id",status="404"} 1`)
+	assert.Contains(t, body, `weather_http_request_duration_seconds_count{method="GET",route="/weather/:id",status="200"} 2`)
+	assert.NotContains(t, body, `/nowhere/at/all`)
+}
+
+func TestMiddlewareReturnsError(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/weather/5", nil), httptest.NewRecorder())
+	c.SetPath("/weather/:id")
+
+	errNotFound := repository.NewErrNotFound(5)
+
+	err := m.Middleware()(func(echo.Context) error { return errNotFound })(c)
+	assert.ErrorIs(t, err, errNotFound)
+
+	// the status is the one the error handler is going to send
+	assert.Contains(t, scrape(t, m), `weather_http_requests_total{method="GET",route="/weather/:id",status="404"} 1`)
+}
+
+func TestObserveQuery(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+	m.ObserveQuery("GetWeather", 3*time.Millisecond, nil)
+	m.ObserveQuery("GetWeather", 30*time.Millisecond, errors.New("connection reset"))
+	m.ObserveQuery("ListLocations", time.Millisecond, nil)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `weather_db_query_duration_seconds_count{query="GetWeather"} 2`)
+	assert.Contains(t, body, `weather_db_query_duration_seconds_bucket{query="GetWeather",le="0.005"} 1`)
+	assert.Contains(t, body, `weather_db_query_errors_total{query="GetWeather"} 1`)
+	assert.NotContains(t, body, `weather_db_query_errors_total{query="ListLocations"}`)
+}
+
+func TestObservationsIngested(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.New()
+	m.ObservationsIngested(3, "Minsk", "Belarus", 2)
+	m.ObservationsIngested(3, "Minsk", "Belarus", 1)
+	m.ObservationsIngested(7, "Brest", "Belarus", 1)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `weather_observations_ingested_total{city="Minsk",country="Belarus",location_id="3"} 3`)
+	assert.Contains(t, body, `weather_observations_ingested_total{city="Brest",country="Belarus",location_id="7"} 1`)
+}
+
+func TestRegisterDB(t *testing.T) {
+	t.Parallel()
+
+	// sql.Open does not connect, the pool stats are there all the same
+	db, err := sql.Open("postgres", "host=localhost")
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	m := metrics.New()
+	m.RegisterDB("weather", db)
+
+	body := scrape(t, m)
+
+	assert.Contains(t, body, `go_sql_open_connections{db_name="weather"} 0`)
+	assert.Contains(t, body, `go_sql_max_open_connections{db_name="weather"} 0`)
+}
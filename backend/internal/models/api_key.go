@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type APIKey struct {
+	ID          int          `json:"id"`
+	Name        string       `json:"name"`
+	Prefix      string       `json:"prefix"`
+	KeyHash     string       `json:"-"`
+	Cities      []string     `json:"cities"`
+	Permissions []Permission `json:"permissions"`
+	CreatedBy   *int         `json:"created_by,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	LastUsedAt  *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time   `json:"revoked_at,omitempty"`
+}
+
+// IssuedAPIKey carries the plaintext key, which is only shown once on
+// creation or rotation.
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
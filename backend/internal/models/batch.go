@@ -0,0 +1,29 @@
+package models
+
+type BatchMode string
+
+const (
+	// BatchAtomic rejects the whole batch if any item is invalid.
+	BatchAtomic BatchMode = "atomic"
+	// BatchPartial stores the valid items and reports the invalid ones.
+	BatchPartial BatchMode = "partial"
+)
+
+type BatchItem struct {
+	Index   int
+	Weather *Weather
+	Error   string
+}
+
+type BatchItemResult struct {
+	Index  int          `json:"index"`
+	ID     int          `json:"id,omitempty"`
+	Error  string       `json:"error,omitempty"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+type BatchResult struct {
+	Inserted int               `json:"inserted"`
+	Failed   int               `json:"failed"`
+	Items    []BatchItemResult `json:"items"`
+}
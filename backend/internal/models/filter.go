@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+type WeatherSortField string
+
+const (
+	SortByTimestamp   WeatherSortField = "timestamp"
+	SortByTemperature WeatherSortField = "temperature"
+	SortByHumidity    WeatherSortField = "humidity"
+	SortByPressure    WeatherSortField = "pressure"
+	SortByWindSpeed   WeatherSortField = "wind_speed"
+)
+
+type FloatRange struct {
+	Min *float64
+	Max *float64
+}
+
+type WeatherFilter struct {
+	LocationID    *int
+	City          *string
+	Country       *string
+	WeatherStatus *string
+	From          *time.Time
+	To            *time.Time
+	Temperature   FloatRange
+	Humidity      FloatRange
+	Pressure      FloatRange
+	WindSpeed     FloatRange
+
+	SortBy WeatherSortField
+	Order  SortOrder
+	After  *WeatherCursor
+	Limit  int
+}
+
+// WeatherCursor points at the last row of a page. Timestamp is used when
+// sorting by timestamp, Value for every numeric sort field.
+type WeatherCursor struct {
+	SortBy    WeatherSortField `json:"s"`
+	Order     SortOrder        `json:"o"`
+	ID        int              `json:"i"`
+	Timestamp time.Time        `json:"t,omitempty"`
+	Value     float64          `json:"v,omitempty"`
+}
+
+func NewWeatherCursor(sortBy WeatherSortField, order SortOrder, w *Weather) *WeatherCursor {
+	cursor := &WeatherCursor{
+		SortBy: sortBy,
+		Order:  order,
+		ID:     w.ID,
+	}
+
+	switch sortBy {
+	case SortByTimestamp:
+		cursor.Timestamp = w.Timestamp
+	case SortByTemperature:
+		cursor.Value = w.Temperature
+	case SortByHumidity:
+		cursor.Value = w.Humidity
+	case SortByPressure:
+		cursor.Value = w.Pressure
+	case SortByWindSpeed:
+		cursor.Value = w.WindSpeed
+	}
+
+	return cursor
+}
+
+type WeatherPage struct {
+	Items []*Weather
+	Next  *WeatherCursor
+}
+
+// LatestFilter narrows the latest observation per location to a country
+// and/or a set of cities, both matched case-insensitively.
+type LatestFilter struct {
+	Country *string
+	Cities  []string
+}
@@ -0,0 +1,95 @@
+package models
+
+import "time"
+
+// ForecastRequest selects the location either by LocationID or by City and
+// Country. Granularity is BucketHour or BucketDay.
+type ForecastRequest struct {
+	LocationID  *int
+	City        string
+	Country     string
+	Granularity StatsBucket
+	Horizon     int
+}
+
+// Prediction is a point forecast with its 95% prediction interval. Uploaded
+// forecasts may come without an interval.
+type Prediction struct {
+	Value float64  `json:"value"`
+	Lower *float64 `json:"lower,omitempty"`
+	Upper *float64 `json:"upper,omitempty"`
+}
+
+// ForecastPoint predicts the mean of the observations within the period
+// starting at Time. Uploaded forecasts may leave out variables.
+type ForecastPoint struct {
+	Time        time.Time   `json:"time"`
+	Temperature *Prediction `json:"temperature,omitempty"`
+	Humidity    *Prediction `json:"humidity,omitempty"`
+	Pressure    *Prediction `json:"pressure,omitempty"`
+	WindSpeed   *Prediction `json:"wind_speed,omitempty"`
+}
+
+// Forecast is one forecast run, either generated or uploaded. IssuedAt is when
+// the forecast was made; generated runs only use observations before it.
+type Forecast struct {
+	LocationID  int             `json:"location_id"`
+	City        string          `json:"city"`
+	Country     string          `json:"country"`
+	Model       string          `json:"model"`
+	Granularity StatsBucket     `json:"granularity"`
+	IssuedAt    time.Time       `json:"issued_at"`
+	Points      []ForecastPoint `json:"points"`
+}
+
+// AccuracyFilter selects the forecasts of one granularity whose target time
+// lies within [From, To).
+type AccuracyFilter struct {
+	Model       *string
+	LocationID  *int
+	City        *string
+	Country     *string
+	From        *time.Time
+	To          *time.Time
+	Granularity StatsBucket
+}
+
+// ErrorStats compares forecasts with the observed means. Bias is the mean of
+// forecast minus observation, so a positive bias means overestimation.
+type ErrorStats struct {
+	Count int     `json:"count"`
+	MAE   float64 `json:"mae"`
+	RMSE  float64 `json:"rmse"`
+	Bias  float64 `json:"bias"`
+}
+
+// VariableErrors holds the error statistics per variable; variables without
+// any forecast are nil.
+type VariableErrors struct {
+	Temperature *ErrorStats `json:"temperature,omitempty"`
+	Humidity    *ErrorStats `json:"humidity,omitempty"`
+	Pressure    *ErrorStats `json:"pressure,omitempty"`
+	WindSpeed   *ErrorStats `json:"wind_speed,omitempty"`
+}
+
+// ForecastAccuracy is the accuracy of one model for one location and horizon.
+type ForecastAccuracy struct {
+	Model      string `json:"model"`
+	LocationID int    `json:"location_id"`
+	City       string `json:"city"`
+	Country    string `json:"country"`
+	Horizon    int    `json:"horizon"`
+	VariableErrors
+}
+
+// ModelAccuracy summarizes a model over every location and horizon.
+type ModelAccuracy struct {
+	Model string `json:"model"`
+	VariableErrors
+}
+
+type AccuracyReport struct {
+	Granularity StatsBucket         `json:"granularity"`
+	Models      []*ModelAccuracy    `json:"models"`
+	Items       []*ForecastAccuracy `json:"items"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// BBox is a latitude/longitude rectangle in degrees. MinLongitude greater than
+// MaxLongitude describes a box crossing the antimeridian.
+type BBox struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+// NearFilter selects observations within RadiusKm of a point, nearest first.
+type NearFilter struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	From      *time.Time
+	To        *time.Time
+	Limit     int
+}
+
+// BBoxFilter selects observations inside a box, newest first.
+type BBoxFilter struct {
+	BBox
+	From  *time.Time
+	To    *time.Time
+	Limit int
+}
@@ -0,0 +1,22 @@
+package models
+
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthFail     HealthStatus = "fail"
+	HealthDraining HealthStatus = "draining"
+)
+
+// CheckResult is the state of a single dependency. Details are whatever the
+// checker reports, e.g. the schema version of the database.
+type CheckResult struct {
+	Status  HealthStatus   `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+type HealthReport struct {
+	Status HealthStatus           `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
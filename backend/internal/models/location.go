@@ -0,0 +1,13 @@
+package models
+
+// Location is a place observations are taken at, e.g. a city or a station.
+// Name and country are unique regardless of case.
+type Location struct {
+	ID        int      `json:"id"`
+	Name      string   `json:"name"`
+	Country   string   `json:"country"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Elevation *float64 `json:"elevation,omitempty"`
+	Timezone  string   `json:"timezone"`
+}
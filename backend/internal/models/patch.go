@@ -0,0 +1,116 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Optional tracks whether a JSON member was present at all, and if so whether
+// it was an explicit null, which plain pointers cannot tell apart.
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+
+	if bytes.Equal(data, []byte("null")) {
+		o.Null = true
+		return nil
+	}
+
+	return json.Unmarshal(data, &o.Value)
+}
+
+// Ptr returns the provided value, or nil if the member was absent or null.
+func (o Optional[T]) Ptr() *T {
+	if !o.Set || o.Null {
+		return nil
+	}
+
+	return &o.Value
+}
+
+// WeatherPatch is a JSON Merge Patch (RFC 7386) document for Weather. As on
+// other writes, city and country take precedence over a bare location_id.
+type WeatherPatch struct {
+	Timestamp     Optional[time.Time] `json:"timestamp"`
+	LocationID    Optional[int]       `json:"location_id"`
+	City          Optional[string]    `json:"city"`
+	Country       Optional[string]    `json:"country"`
+	Latitude      Optional[float64]   `json:"latitude"`
+	Longitude     Optional[float64]   `json:"longitude"`
+	Temperature   Optional[float64]   `json:"temperature"`
+	Humidity      Optional[float64]   `json:"humidity"`
+	Pressure      Optional[float64]   `json:"pressure"`
+	WindSpeed     Optional[float64]   `json:"wind_speed"`
+	WeatherStatus Optional[string]    `json:"weather_status"`
+}
+
+// NullFields lists members set to null. Every weather field except the
+// coordinates is mandatory, so removing one is not allowed.
+func (p *WeatherPatch) NullFields() []string {
+	var fields []string
+
+	for _, f := range []struct {
+		name string
+		null bool
+	}{
+		{name: "timestamp", null: p.Timestamp.Null},
+		{name: "location_id", null: p.LocationID.Null},
+		{name: "city", null: p.City.Null},
+		{name: "country", null: p.Country.Null},
+		{name: "temperature", null: p.Temperature.Null},
+		{name: "humidity", null: p.Humidity.Null},
+		{name: "pressure", null: p.Pressure.Null},
+		{name: "wind_speed", null: p.WindSpeed.Null},
+		{name: "weather_status", null: p.WeatherStatus.Null},
+	} {
+		if f.null {
+			fields = append(fields, f.name)
+		}
+	}
+
+	return fields
+}
+
+// Apply writes the provided members onto ob.
+func (p *WeatherPatch) Apply(ob *Weather) {
+	applyOptional(&ob.Timestamp, p.Timestamp)
+	applyOptional(&ob.LocationID, p.LocationID)
+	applyOptional(&ob.City, p.City)
+	applyOptional(&ob.Country, p.Country)
+	applyOptional(&ob.Temperature, p.Temperature)
+	applyOptional(&ob.Humidity, p.Humidity)
+	applyOptional(&ob.Pressure, p.Pressure)
+	applyOptional(&ob.WindSpeed, p.WindSpeed)
+	applyOptional(&ob.WeatherStatus, p.WeatherStatus)
+
+	// coordinates are optional, so an explicit null clears them
+	if p.Latitude.Set {
+		ob.Latitude = p.Latitude.Ptr()
+	}
+
+	if p.Longitude.Set {
+		ob.Longitude = p.Longitude.Ptr()
+	}
+}
+
+// OptionalOf returns a provided member holding *v, or an explicit null if v is
+// nil.
+func OptionalOf[T any](v *T) Optional[T] {
+	if v == nil {
+		return Optional[T]{Set: true, Null: true}
+	}
+
+	return Optional[T]{Set: true, Value: *v}
+}
+
+func applyOptional[T any](dst *T, o Optional[T]) {
+	if v := o.Ptr(); v != nil {
+		*dst = *v
+	}
+}
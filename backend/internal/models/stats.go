@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+type StatsBucket string
+
+const (
+	BucketHour  StatsBucket = "hour"
+	BucketDay   StatsBucket = "day"
+	BucketWeek  StatsBucket = "week"
+	BucketMonth StatsBucket = "month"
+)
+
+var StatsBuckets = []StatsBucket{BucketHour, BucketDay, BucketWeek, BucketMonth}
+
+// StatsFilter selects the observations aggregated by WeatherStats. Periods are
+// aligned to the local time of every location, or to TimeZone if set; weeks
+// start on Monday.
+type StatsFilter struct {
+	LocationID *int
+	City       *string
+	Country    *string
+	From       *time.Time
+	To         *time.Time
+	Bucket     StatsBucket
+	TimeZone   *string
+}
+
+type MetricStats struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Stddev float64 `json:"stddev"`
+}
+
+// WeatherStats aggregates the observations of one location within one period.
+// Stddev is the population standard deviation, so a single observation has 0.
+type WeatherStats struct {
+	LocationID  int         `json:"location_id"`
+	City        string      `json:"city"`
+	Country     string      `json:"country"`
+	Timezone    string      `json:"timezone"`
+	PeriodStart time.Time   `json:"period_start"`
+	Count       int         `json:"count"`
+	Temperature MetricStats `json:"temperature"`
+	Humidity    MetricStats `json:"humidity"`
+	Pressure    MetricStats `json:"pressure"`
+	WindSpeed   MetricStats `json:"wind_speed"`
+}
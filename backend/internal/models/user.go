@@ -0,0 +1,81 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleObserver Role = "observer"
+	RoleEditor   Role = "editor"
+	RoleAdmin    Role = "admin"
+)
+
+type Permission string
+
+const (
+	PermissionWeatherCreate   Permission = "weather:create"
+	PermissionWeatherUpdate   Permission = "weather:update"
+	PermissionWeatherDelete   Permission = "weather:delete"
+	PermissionUsersManage     Permission = "users:manage"
+	PermissionAPIKeysManage   Permission = "api_keys:manage"
+	PermissionLocationsManage Permission = "locations:manage"
+	PermissionForecastsUpload Permission = "forecasts:upload"
+)
+
+type RoleDefinition struct {
+	Name        Role         `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
+
+type User struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"-"`
+	Roles        []Role    `json:"roles"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Principal struct {
+	UserID      int          `json:"user_id"`
+	Username    string       `json:"username"`
+	Roles       []Role       `json:"roles"`
+	Permissions []Permission `json:"permissions"`
+	APIKeyID    int          `json:"api_key_id,omitempty"`
+	Cities      []string     `json:"cities,omitempty"`
+}
+
+func (p *Principal) HasPermission(permission Permission) bool {
+	return slices.Contains(p.Permissions, permission)
+}
+
+// CanAccessCity reports whether the principal may write observations for the
+// city. Only API keys are scoped to cities; users may access any city.
+func (p *Principal) CanAccessCity(city string) bool {
+	if p.APIKeyID == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(p.Cities, func(c string) bool {
+		return strings.EqualFold(c, city)
+	})
+}
+
+type RefreshToken struct {
+	ID        string
+	UserID    int
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+}
+
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
@@ -0,0 +1,15 @@
+package models
+
+const (
+	CodeRequired     = "required"
+	CodeOutOfRange   = "out_of_range"
+	CodeInvalidValue = "invalid_value"
+	CodeInFuture     = "in_future"
+	CodeTooLong      = "too_long"
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
@@ -2,14 +2,70 @@ package models
 
 import "time"
 
+const (
+	StatusClear        = "clear"
+	StatusPartlyCloudy = "partly_cloudy"
+	StatusCloudy       = "cloudy"
+	StatusOvercast     = "overcast"
+	StatusFog          = "fog"
+	StatusDrizzle      = "drizzle"
+	StatusRain         = "rain"
+	StatusThunderstorm = "thunderstorm"
+	StatusSnow         = "snow"
+	StatusSleet        = "sleet"
+	StatusHail         = "hail"
+)
+
+var WeatherStatuses = []string{
+	StatusClear,
+	StatusPartlyCloudy,
+	StatusCloudy,
+	StatusOvercast,
+	StatusFog,
+	StatusDrizzle,
+	StatusRain,
+	StatusThunderstorm,
+	StatusSnow,
+	StatusSleet,
+	StatusHail,
+}
+
+// Weather is a single observation. On writes the location is given either by
+// city and country, which are resolved to a location (created if missing), or
+// by LocationID alone. Reads always fill City, Country and Location from the
+// stored location. Coordinates default to those of the location.
 type Weather struct {
 	ID            int       `json:"id"`
 	Timestamp     time.Time `json:"timestamp"`
+	LocationID    int       `json:"location_id,omitempty"`
+	Location      *Location `json:"location,omitempty"`
 	City          string    `json:"city"`
 	Country       string    `json:"country"`
+	Latitude      *float64  `json:"latitude,omitempty"`
+	Longitude     *float64  `json:"longitude,omitempty"`
 	Temperature   float64   `json:"temperature"`
 	Humidity      float64   `json:"humidity"`
 	Pressure      float64   `json:"pressure"`
 	WindSpeed     float64   `json:"wind_speed"`
 	WeatherStatus string    `json:"weather_status"`
+	APIKeyID      *int      `json:"api_key_id,omitempty"`
+	Version       int       `json:"version"`
+
+	// Distance is only set by proximity searches; it is stored in km and
+	// converted like every other quantity.
+	Distance *float64 `json:"distance,omitempty"`
+
+	// Derived is only set when requested.
+	Derived *DerivedMetrics `json:"derived,omitempty"`
+}
+
+// DerivedMetrics are computed from the stored temperature, humidity and wind
+// speed. Indices outside their domain of validity are omitted.
+type DerivedMetrics struct {
+	HeatIndex           *float64 `json:"heat_index,omitempty"`
+	WindChill           *float64 `json:"wind_chill,omitempty"`
+	ApparentTemperature float64  `json:"apparent_temperature"`
+	DewPoint            *float64 `json:"dew_point,omitempty"`
+	AbsoluteHumidity    float64  `json:"absolute_humidity"`
+	Beaufort            int      `json:"beaufort"`
 }
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name APIKeyDatabase --structname MockAPIKeyDatabase --filename mock_api_key_database_test.go --outpkg repository_test --output .
+type APIKeyDatabase interface {
+	AddAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	GetAPIKey(ctx context.Context, id int) (*models.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int, prefix, keyHash string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id int) error
+}
+
+type APIKeyRepository struct {
+	db APIKeyDatabase
+}
+
+func NewAPIKeyRepository(db APIKeyDatabase) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func (r *APIKeyRepository) AddAPIKey(
+	ctx context.Context,
+	key *models.APIKey,
+) (*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.AddAPIKey")
+	defer span.End()
+
+	res, err := r.db.AddAPIKey(ctx, key)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add api key: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) GetAPIKey(
+	ctx context.Context,
+	id int,
+) (*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.GetAPIKey")
+	defer span.End()
+
+	res, err := r.db.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get api key: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByPrefix(
+	ctx context.Context,
+	prefix string,
+) (*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.GetAPIKeyByPrefix")
+	defer span.End()
+
+	res, err := r.db.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get api key: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.ListAPIKeys")
+	defer span.End()
+
+	res, err := r.db.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list api keys: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) RotateAPIKey(
+	ctx context.Context,
+	id int,
+	prefix, keyHash string,
+) (*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.RotateAPIKey")
+	defer span.End()
+
+	res, err := r.db.RotateAPIKey(ctx, id, prefix, keyHash)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to rotate api key: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(
+	ctx context.Context,
+	id int,
+) (*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.RevokeAPIKey")
+	defer span.End()
+
+	res, err := r.db.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to revoke api key: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(
+	ctx context.Context,
+	id int,
+) error {
+	ctx, span := tracer.Start(ctx, "APIKeyRepository.TouchAPIKey")
+	defer span.End()
+
+	if err := r.db.TouchAPIKey(ctx, id); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to touch api key: %w", err))
+	}
+
+	return nil
+}
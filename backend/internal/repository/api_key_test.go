@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyDatabaseBuilder func(t *testing.T) repository.APIKeyDatabase
+
+func TestGetAPIKeyByPrefixWithError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		dbBuilder  apiKeyDatabaseBuilder
+		prefix     string
+		isNotFound bool
+	}{
+		{
+			name: "Missing key",
+			dbBuilder: func(t *testing.T) repository.APIKeyDatabase {
+				t.Helper()
+
+				mockDB := NewMockAPIKeyDatabase(t)
+				mockDB.
+					On("GetAPIKeyByPrefix", mock.Anything, "abc").
+					Return(nil, fmt.Errorf("failed to get api key by prefix: %w", repository.NewErrNotFoundBy("prefix", "abc"))).
+					Once()
+
+				return mockDB
+			},
+			prefix:     "abc",
+			isNotFound: true,
+		},
+		{
+			name: "Database error",
+			dbBuilder: func(t *testing.T) repository.APIKeyDatabase {
+				t.Helper()
+
+				mockDB := NewMockAPIKeyDatabase(t)
+				mockDB.
+					On("GetAPIKeyByPrefix", mock.Anything, "abc").
+					Return(nil, fmt.Errorf("%w: connection refused", repository.ErrUnavailable)).
+					Once()
+
+				return mockDB
+			},
+			prefix:     "abc",
+			isNotFound: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewAPIKeyRepository(tc.dbBuilder(t))
+
+			_, err := repo.GetAPIKeyByPrefix(context.Background(), tc.prefix)
+			require.Error(t, err)
+			assert.Equal(t, tc.isNotFound, errors.As(err, &repository.ErrNotFound{}))
+		})
+	}
+}
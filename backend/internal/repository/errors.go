@@ -1,9 +1,31 @@
 package repository
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Storage failures are reported as one of the kinds below (or ErrNotFound),
+// wrapping the driver error, so callers never inspect sql or pq errors.
+var (
+	// ErrConflict is returned when a write clashes with existing data, e.g. a
+	// unique or foreign key violation.
+	ErrConflict = errors.New("record conflicts with existing data")
+	// ErrInvalid is returned when the database rejects a value, e.g. a check
+	// constraint or an out-of-range number.
+	ErrInvalid = errors.New("record violates a data constraint")
+	// ErrUnavailable is returned when the database cannot be reached or is
+	// shutting down; retrying later may succeed.
+	ErrUnavailable = errors.New("database is unavailable")
+)
+
+// ErrVersionConflict is returned when a record was changed since the version
+// the caller based its write on.
+var ErrVersionConflict = errors.New("record version does not match")
 
 type ErrNotFound struct {
-	id int
+	id  int
+	key string
 }
 
 func NewErrNotFound(id int) error {
@@ -12,6 +34,22 @@ func NewErrNotFound(id int) error {
 	}
 }
 
+func NewErrNotFoundBy(field string, value any) error {
+	return ErrNotFound{
+		key: fmt.Sprintf("%s=%v", field, value),
+	}
+}
+
+// Expected reports that a missing record is a normal outcome of a lookup, so
+// it does not fail the span of the query.
+func (ErrNotFound) Expected() bool {
+	return true
+}
+
 func (e ErrNotFound) Error() string {
+	if e.key != "" {
+		return fmt.Sprintf("no record with %s", e.key)
+	}
+
 	return fmt.Sprintf("no record with id=%d", e.id)
 }
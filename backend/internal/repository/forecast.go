@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name ForecastDatabase --structname MockForecastDatabase --filename mock_forecast_database_test.go --outpkg repository_test --output .
+type ForecastDatabase interface {
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	GetLocationByName(ctx context.Context, name, country string) (*models.Location, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	SaveForecast(ctx context.Context, fc *models.Forecast) error
+	ForecastAccuracy(ctx context.Context, filter models.AccuracyFilter) ([]*models.ForecastAccuracy, error)
+}
+
+type ForecastRepository struct {
+	db ForecastDatabase
+}
+
+func NewForecastRepository(db ForecastDatabase) *ForecastRepository {
+	return &ForecastRepository{
+		db: db,
+	}
+}
+
+func (r *ForecastRepository) GetLocation(ctx context.Context, id int) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "ForecastRepository.GetLocation")
+	defer span.End()
+
+	res, err := r.db.GetLocation(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *ForecastRepository) GetLocationByName(
+	ctx context.Context,
+	name string,
+	country string,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "ForecastRepository.GetLocationByName")
+	defer span.End()
+
+	res, err := r.db.GetLocationByName(ctx, name, country)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *ForecastRepository) WeatherStats(
+	ctx context.Context,
+	filter models.StatsFilter,
+) ([]*models.WeatherStats, error) {
+	ctx, span := tracer.Start(ctx, "ForecastRepository.WeatherStats")
+	defer span.End()
+
+	res, err := r.db.WeatherStats(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather stats: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *ForecastRepository) SaveForecast(ctx context.Context, fc *models.Forecast) error {
+	ctx, span := tracer.Start(ctx, "ForecastRepository.SaveForecast")
+	defer span.End()
+
+	if err := r.db.SaveForecast(ctx, fc); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to save forecast: %w", err))
+	}
+
+	return nil
+}
+
+func (r *ForecastRepository) ForecastAccuracy(
+	ctx context.Context,
+	filter models.AccuracyFilter,
+) ([]*models.ForecastAccuracy, error) {
+	ctx, span := tracer.Start(ctx, "ForecastRepository.ForecastAccuracy")
+	defer span.End()
+
+	res, err := r.db.ForecastAccuracy(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get forecast accuracy: %w", err))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name LocationDatabase --structname MockLocationDatabase --filename mock_location_database_test.go --outpkg repository_test --output .
+type LocationDatabase interface {
+	AddLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	ListLocations(ctx context.Context) ([]*models.Location, error)
+	UpdateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id int) (*models.Location, error)
+}
+
+type LocationRepository struct {
+	db LocationDatabase
+}
+
+func NewLocationRepository(db LocationDatabase) *LocationRepository {
+	return &LocationRepository{
+		db: db,
+	}
+}
+
+func (r *LocationRepository) AddLocation(
+	ctx context.Context,
+	location *models.Location,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationRepository.AddLocation")
+	defer span.End()
+
+	res, err := r.db.AddLocation(ctx, location)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *LocationRepository) GetLocation(
+	ctx context.Context,
+	id int,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationRepository.GetLocation")
+	defer span.End()
+
+	res, err := r.db.GetLocation(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *LocationRepository) ListLocations(ctx context.Context) ([]*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationRepository.ListLocations")
+	defer span.End()
+
+	res, err := r.db.ListLocations(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list locations: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *LocationRepository) UpdateLocation(
+	ctx context.Context,
+	location *models.Location,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationRepository.UpdateLocation")
+	defer span.End()
+
+	res, err := r.db.UpdateLocation(ctx, location)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to update location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *LocationRepository) DeleteLocation(
+	ctx context.Context,
+	id int,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationRepository.DeleteLocation")
+	defer span.End()
+
+	res, err := r.db.DeleteLocation(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to delete location: %w", err))
+	}
+
+	return res, nil
+}
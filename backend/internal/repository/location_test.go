@@ -0,0 +1,92 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type locationDatabaseBuilder func(t *testing.T) repository.LocationDatabase
+
+func TestDeleteLocationWithError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		dbBuilder  locationDatabaseBuilder
+		id         int
+		isNotFound bool
+		isConflict bool
+	}{
+		{
+			name: "Missing location",
+			dbBuilder: func(t *testing.T) repository.LocationDatabase {
+				t.Helper()
+
+				mockDB := NewMockLocationDatabase(t)
+				mockDB.
+					On("DeleteLocation", mock.Anything, 4).
+					Return(nil, fmt.Errorf("failed to delete location: %w", repository.NewErrNotFound(4))).
+					Once()
+
+				return mockDB
+			},
+			id:         4,
+			isNotFound: true,
+		},
+		{
+			name: "Location in use",
+			dbBuilder: func(t *testing.T) repository.LocationDatabase {
+				t.Helper()
+
+				mockDB := NewMockLocationDatabase(t)
+				mockDB.
+					On("DeleteLocation", mock.Anything, 1).
+					Return(nil, fmt.Errorf("%w: foreign key violation", repository.ErrConflict)).
+					Once()
+
+				return mockDB
+			},
+			id:         1,
+			isConflict: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewLocationRepository(tc.dbBuilder(t))
+
+			_, err := repo.DeleteLocation(context.Background(), tc.id)
+			require.Error(t, err)
+			assert.Equal(t, tc.isNotFound, errors.As(err, &repository.ErrNotFound{}))
+			assert.Equal(t, tc.isConflict, errors.Is(err, repository.ErrConflict))
+		})
+	}
+}
+
+func TestListLocationsWithoutError(t *testing.T) {
+	t.Parallel()
+
+	locations := []*models.Location{
+		{ID: 1, Name: "Minsk", Country: "Belarus", Timezone: "Europe/Minsk"},
+	}
+
+	mockDB := NewMockLocationDatabase(t)
+	mockDB.On("ListLocations", mock.Anything).Return(locations, nil).Once()
+
+	repo := repository.NewLocationRepository(mockDB)
+
+	res, err := repo.ListLocations(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, locations, res)
+}
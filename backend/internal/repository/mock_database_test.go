@@ -44,9 +44,78 @@ func (_m *MockDatabase) AddWeather(ctx context.Context, weather *models.Weather)
 	return r0, r1
 }
 
-// DeleteWeather provides a mock function with given fields: ctx, id
-func (_m *MockDatabase) DeleteWeather(ctx context.Context, id int) (*models.Weather, error) {
-	ret := _m.Called(ctx, id)
+// AddWeathers provides a mock function with given fields: ctx, weathers
+func (_m *MockDatabase) AddWeathers(ctx context.Context, weathers []*models.Weather) ([]int, error) {
+	ret := _m.Called(ctx, weathers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWeathers")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) ([]int, error)); ok {
+		return rf(ctx, weathers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) []int); ok {
+		r0 = rf(ctx, weathers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Weather) error); ok {
+		r1 = rf(ctx, weathers)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddWeathersPartial provides a mock function with given fields: ctx, weathers
+func (_m *MockDatabase) AddWeathersPartial(ctx context.Context, weathers []*models.Weather) ([]int, []error, error) {
+	ret := _m.Called(ctx, weathers)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWeathersPartial")
+	}
+
+	var r0 []int
+	var r1 []error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) ([]int, []error, error)); ok {
+		return rf(ctx, weathers)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) []int); ok {
+		r0 = rf(ctx, weathers)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Weather) []error); ok {
+		r1 = rf(ctx, weathers)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*models.Weather) error); ok {
+		r2 = rf(ctx, weathers)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteWeather provides a mock function with given fields: ctx, id, version
+func (_m *MockDatabase) DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteWeather")
@@ -54,19 +123,19 @@ func (_m *MockDatabase) DeleteWeather(ctx context.Context, id int) (*models.Weat
 
 	var r0 *models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Weather, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Weather, error)); ok {
+		return rf(ctx, id, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Weather); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Weather); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -104,9 +173,39 @@ func (_m *MockDatabase) GetWeather(ctx context.Context, id int) (*models.Weather
 	return r0, r1
 }
 
-// ListWeathers provides a mock function with given fields: ctx
-func (_m *MockDatabase) ListWeathers(ctx context.Context) ([]*models.Weather, error) {
-	ret := _m.Called(ctx)
+// LatestWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestWeathers")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.LatestFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) ListWeathers(ctx context.Context, filter models.WeatherFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWeathers")
@@ -114,19 +213,109 @@ func (_m *MockDatabase) ListWeathers(ctx context.Context) ([]*models.Weather, er
 
 	var r0 []*models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Weather, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.WeatherFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersInBBox provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersInBBox")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.BBoxFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersNear provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersNear")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Weather); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, models.NearFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchWeather provides a mock function with given fields: ctx, id, version, patch
+func (_m *MockDatabase) PatchWeather(ctx context.Context, id int, version int, patch *models.WeatherPatch) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchWeather")
+	}
+
+	var r0 *models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) (*models.Weather, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) *models.Weather); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *models.WeatherPatch) error); ok {
+		r1 = rf(ctx, id, version, patch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -164,6 +353,36 @@ func (_m *MockDatabase) UpdateWeather(ctx context.Context, weather *models.Weath
 	return r0, r1
 }
 
+// WeatherStats provides a mock function with given fields: ctx, filter
+func (_m *MockDatabase) WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WeatherStats")
+	}
+
+	var r0 []*models.WeatherStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) ([]*models.WeatherStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) []*models.WeatherStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WeatherStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockDatabase creates a new instance of MockDatabase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDatabase(t interface {
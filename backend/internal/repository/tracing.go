@@ -0,0 +1,6 @@
+package repository
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts the spans of every repository method.
+var tracer = otel.Tracer("github.com/LLIEPJIOK/weather-forecast/backend/internal/repository")
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name UserDatabase --structname MockUserDatabase --filename mock_user_database_test.go --outpkg repository_test --output .
+type UserDatabase interface {
+	AddUser(ctx context.Context, user *models.User) (*models.User, error)
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	SetUserRoles(ctx context.Context, userID int, roles []models.Role) error
+	ListUserPermissions(ctx context.Context, userID int) ([]models.Permission, error)
+	ListRoles(ctx context.Context) ([]*models.RoleDefinition, error)
+	AddRefreshToken(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, id string) (*models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id string) error
+}
+
+type UserRepository struct {
+	db UserDatabase
+}
+
+func NewUserRepository(db UserDatabase) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+func (r *UserRepository) AddUser(
+	ctx context.Context,
+	user *models.User,
+) (int, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.AddUser")
+	defer span.End()
+
+	res, err := r.db.AddUser(ctx, user)
+	if err != nil {
+		return 0, tracing.Fail(span, fmt.Errorf("failed to add user: %w", err))
+	}
+
+	return res.ID, nil
+}
+
+func (r *UserRepository) GetUser(
+	ctx context.Context,
+	id int,
+) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetUser")
+	defer span.End()
+
+	res, err := r.db.GetUser(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) GetUserByUsername(
+	ctx context.Context,
+	username string,
+) (*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetUserByUsername")
+	defer span.End()
+
+	res, err := r.db.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) ListUsers(ctx context.Context) ([]*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.ListUsers")
+	defer span.End()
+
+	res, err := r.db.ListUsers(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list users: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) SetUserRoles(
+	ctx context.Context,
+	userID int,
+	roles []models.Role,
+) error {
+	ctx, span := tracer.Start(ctx, "UserRepository.SetUserRoles")
+	defer span.End()
+
+	if err := r.db.SetUserRoles(ctx, userID, roles); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to set user roles: %w", err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) ListUserPermissions(
+	ctx context.Context,
+	userID int,
+) ([]models.Permission, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.ListUserPermissions")
+	defer span.End()
+
+	res, err := r.db.ListUserPermissions(ctx, userID)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list user permissions: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) ListRoles(ctx context.Context) ([]*models.RoleDefinition, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.ListRoles")
+	defer span.End()
+
+	res, err := r.db.ListRoles(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list roles: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) AddRefreshToken(
+	ctx context.Context,
+	token *models.RefreshToken,
+) error {
+	ctx, span := tracer.Start(ctx, "UserRepository.AddRefreshToken")
+	defer span.End()
+
+	if _, err := r.db.AddRefreshToken(ctx, token); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to add refresh token: %w", err))
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetRefreshToken(
+	ctx context.Context,
+	id string,
+) (*models.RefreshToken, error) {
+	ctx, span := tracer.Start(ctx, "UserRepository.GetRefreshToken")
+	defer span.End()
+
+	res, err := r.db.GetRefreshToken(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get refresh token: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *UserRepository) RevokeRefreshToken(
+	ctx context.Context,
+	id string,
+) error {
+	ctx, span := tracer.Start(ctx, "UserRepository.RevokeRefreshToken")
+	defer span.End()
+
+	if err := r.db.RevokeRefreshToken(ctx, id); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to revoke refresh token: %w", err))
+	}
+
+	return nil
+}
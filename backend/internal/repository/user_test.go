@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type userDatabaseBuilder func(t *testing.T) repository.UserDatabase
+
+func TestAddUserWithoutError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		dbBuilder userDatabaseBuilder
+		user      *models.User
+		id        int
+	}{
+		{
+			name: "Add admin user",
+			dbBuilder: func(t *testing.T) repository.UserDatabase {
+				t.Helper()
+
+				mockDB := NewMockUserDatabase(t)
+				mockDB.
+					On("AddUser", mock.Anything, mock.Anything).
+					Return(&models.User{ID: 3, Username: "admin", Roles: []models.Role{models.RoleAdmin}}, nil).
+					Once()
+
+				return mockDB
+			},
+			user: &models.User{Username: "admin", PasswordHash: "hash", Roles: []models.Role{models.RoleAdmin}},
+			id:   3,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewUserRepository(tc.dbBuilder(t))
+
+			id, err := repo.AddUser(context.Background(), tc.user)
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestGetUserByUsernameWithError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		dbBuilder  userDatabaseBuilder
+		username   string
+		isNotFound bool
+	}{
+		{
+			name: "Missing user",
+			dbBuilder: func(t *testing.T) repository.UserDatabase {
+				t.Helper()
+
+				mockDB := NewMockUserDatabase(t)
+				mockDB.
+					On("GetUserByUsername", mock.Anything, "bob").
+					Return(nil, fmt.Errorf("failed to get user by username: %w", repository.NewErrNotFoundBy("username", "bob"))).
+					Once()
+
+				return mockDB
+			},
+			username:   "bob",
+			isNotFound: true,
+		},
+		{
+			name: "Database error",
+			dbBuilder: func(t *testing.T) repository.UserDatabase {
+				t.Helper()
+
+				mockDB := NewMockUserDatabase(t)
+				mockDB.
+					On("GetUserByUsername", mock.Anything, "bob").
+					Return(nil, errors.New("connection refused")).
+					Once()
+
+				return mockDB
+			},
+			username:   "bob",
+			isNotFound: false,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewUserRepository(tc.dbBuilder(t))
+
+			_, err := repo.GetUserByUsername(context.Background(), tc.username)
+			require.Error(t, err)
+			assert.Equal(t, tc.isNotFound, errors.As(err, &repository.ErrNotFound{}))
+		})
+	}
+}
+
+func TestGetRefreshTokenWithError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		dbBuilder userDatabaseBuilder
+		id        string
+		err       string
+	}{
+		{
+			name: "Missing token",
+			dbBuilder: func(t *testing.T) repository.UserDatabase {
+				t.Helper()
+
+				mockDB := NewMockUserDatabase(t)
+				mockDB.
+					On("GetRefreshToken", mock.Anything, "abc").
+					Return(nil, fmt.Errorf("failed to get refresh token: %w", repository.NewErrNotFoundBy("token", "abc"))).
+					Once()
+
+				return mockDB
+			},
+			id:  "abc",
+			err: "failed to get refresh token: failed to get refresh token: no record with token=abc",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewUserRepository(tc.dbBuilder(t))
+
+			_, err := repo.GetRefreshToken(context.Background(), tc.id)
+			require.ErrorAs(t, err, &repository.ErrNotFound{})
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
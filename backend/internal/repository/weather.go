@@ -2,18 +2,33 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
 )
 
 //go:generate mockery --name Database --structname MockDatabase --filename mock_database_test.go --outpkg repository_test --output .
 type Database interface {
 	AddWeather(ctx context.Context, weather *models.Weather) (*models.Weather, error)
+	AddWeathers(ctx context.Context, weathers []*models.Weather) ([]int, error)
+	AddWeathersPartial(ctx context.Context, weathers []*models.Weather) ([]int, []error, error)
 	GetWeather(ctx context.Context, id int) (*models.Weather, error)
-	ListWeathers(ctx context.Context) ([]*models.Weather, error)
+	ListWeathers(ctx context.Context, filter models.WeatherFilter) ([]*models.Weather, error)
 	UpdateWeather(ctx context.Context, weather *models.Weather) (*models.Weather, error)
-	DeleteWeather(ctx context.Context, id int) (*models.Weather, error)
+	PatchWeather(
+		ctx context.Context,
+		id int,
+		version int,
+		patch *models.WeatherPatch,
+	) (*models.Weather, error)
+	DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error)
+	ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error)
+	ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error)
 }
 
 type WeatherRepository struct {
@@ -26,60 +41,223 @@ func NewWeatherRepository(db Database) *WeatherRepository {
 	}
 }
 
+// AddWeather stores ob and sets ob.LocationID to the location it was resolved
+// to.
 func (r *WeatherRepository) AddWeather(
 	ctx context.Context,
 	ob *models.Weather,
 ) (int, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.AddWeather")
+	defer span.End()
+
 	res, err := r.db.AddWeather(ctx, ob)
 	if err != nil {
-		return 0, fmt.Errorf("failed to add weather: %w", err)
+		return 0, tracing.Fail(span, fmt.Errorf("failed to add weather: %w", err))
 	}
 
+	ob.LocationID = res.LocationID
+
 	return res.ID, nil
 }
 
+// AddWeathers stores obs and, like AddWeather, sets the location id of each.
+func (r *WeatherRepository) AddWeathers(
+	ctx context.Context,
+	obs []*models.Weather,
+) ([]int, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.AddWeathers")
+	defer span.End()
+
+	ids, err := r.db.AddWeathers(ctx, obs)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add weathers: %w", err))
+	}
+
+	return ids, nil
+}
+
+// AddWeathersPartial stores what it can of obs. Each observation gets either
+// an id or the error it was rejected with; the stored ones get their location
+// id set.
+func (r *WeatherRepository) AddWeathersPartial(
+	ctx context.Context,
+	obs []*models.Weather,
+) ([]int, []error, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.AddWeathersPartial")
+	defer span.End()
+
+	ids, errs, err := r.db.AddWeathersPartial(ctx, obs)
+	if err != nil {
+		return nil, nil, tracing.Fail(span, fmt.Errorf("failed to add weathers: %w", err))
+	}
+
+	return ids, errs, nil
+}
+
 func (r *WeatherRepository) GetWeather(
 	ctx context.Context,
 	id int,
 ) (*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.GetWeather")
+	defer span.End()
+
 	res, err := r.db.GetWeather(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get weather: %w", err)
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather: %w", err))
 	}
 
 	return res, nil
 }
 
+// UpdateWeather replaces the observation if its stored version still equals
+// ob.Version (zero skips the check) and sets ob.Version to the new version.
 func (r *WeatherRepository) UpdateWeather(
 	ctx context.Context,
 	ob *models.Weather,
 ) error {
-	_, err := r.db.UpdateWeather(ctx, ob)
+	ctx, span := tracer.Start(ctx, "WeatherRepository.UpdateWeather")
+	defer span.End()
+
+	res, err := r.db.UpdateWeather(ctx, ob)
 	if err != nil {
-		return fmt.Errorf("failed to update weather: %w", err)
+		err = r.conditionalError(ctx, ob.ID, ob.Version, err)
+
+		return tracing.Fail(span, fmt.Errorf("failed to update weather: %w", err))
 	}
 
+	ob.Version = res.Version
+
 	return nil
 }
 
+func (r *WeatherRepository) PatchWeather(
+	ctx context.Context,
+	id int,
+	version int,
+	patch *models.WeatherPatch,
+) (*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.PatchWeather")
+	defer span.End()
+
+	res, err := r.db.PatchWeather(ctx, id, version, patch)
+	if err != nil {
+		err = r.conditionalError(ctx, id, version, err)
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to patch weather: %w", err))
+	}
+
+	return res, nil
+}
+
 func (r *WeatherRepository) DeleteWeather(
 	ctx context.Context,
 	id int,
+	version int,
 ) (*models.Weather, error) {
-	res, err := r.db.DeleteWeather(ctx, id)
+	ctx, span := tracer.Start(ctx, "WeatherRepository.DeleteWeather")
+	defer span.End()
+
+	res, err := r.db.DeleteWeather(ctx, id, version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete weather: %w", err)
+		err = r.conditionalError(ctx, id, version, err)
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to delete weather: %w", err))
 	}
 
 	return res, nil
 }
 
+// conditionalError explains why a write guarded by a version matched no row:
+// either the record does not exist or it has been modified in the meantime.
+func (r *WeatherRepository) conditionalError(
+	ctx context.Context,
+	id int,
+	version int,
+	err error,
+) error {
+	if version == 0 || !errors.As(err, &ErrNotFound{}) {
+		return err
+	}
+
+	if _, err := r.db.GetWeather(ctx, id); err != nil {
+		return err
+	}
+
+	slog.DebugContext(ctx, "weather version conflict", "id", id, "version", version)
+
+	return ErrVersionConflict
+}
+
 func (r *WeatherRepository) ListWeathers(
 	ctx context.Context,
+	filter models.WeatherFilter,
+) ([]*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.ListWeathers")
+	defer span.End()
+
+	res, err := r.db.ListWeathers(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *WeatherRepository) ListWeathersNear(
+	ctx context.Context,
+	filter models.NearFilter,
 ) ([]*models.Weather, error) {
-	res, err := r.db.ListWeathers(ctx)
+	ctx, span := tracer.Start(ctx, "WeatherRepository.ListWeathersNear")
+	defer span.End()
+
+	res, err := r.db.ListWeathersNear(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers near point: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *WeatherRepository) ListWeathersInBBox(
+	ctx context.Context,
+	filter models.BBoxFilter,
+) ([]*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.ListWeathersInBBox")
+	defer span.End()
+
+	res, err := r.db.ListWeathersInBBox(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers in bbox: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *WeatherRepository) LatestWeathers(
+	ctx context.Context,
+	filter models.LatestFilter,
+) ([]*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.LatestWeathers")
+	defer span.End()
+
+	res, err := r.db.LatestWeathers(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list latest weathers: %w", err))
+	}
+
+	return res, nil
+}
+
+func (r *WeatherRepository) WeatherStats(
+	ctx context.Context,
+	filter models.StatsFilter,
+) ([]*models.WeatherStats, error) {
+	ctx, span := tracer.Start(ctx, "WeatherRepository.WeatherStats")
+	defer span.End()
+
+	res, err := r.db.WeatherStats(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list weathers: %w", err)
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather stats: %w", err))
 	}
 
 	return res, nil
@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
@@ -388,6 +389,95 @@ func TestUpdateWeatherObservationWithError(t *testing.T) {
 	}
 }
 
+func TestUpdateWeatherObservationWithVersion(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		dbBuilder databaseBuilder
+		checkErr  func(t *testing.T, err error)
+	}{
+		{
+			name: "Version matches",
+			dbBuilder: func(t *testing.T) repository.Database {
+				t.Helper()
+
+				mockDB := NewMockDatabase(t)
+				mockDB.
+					On("UpdateWeather", mock.Anything, mock.Anything).
+					Return(&models.Weather{ID: 4, Version: 3}, nil).
+					Once()
+
+				return mockDB
+			},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "Stale version",
+			dbBuilder: func(t *testing.T) repository.Database {
+				t.Helper()
+
+				mockDB := NewMockDatabase(t)
+				mockDB.
+					On("UpdateWeather", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to update weather: %w", repository.NewErrNotFound(4))).
+					Once()
+				mockDB.
+					On("GetWeather", mock.Anything, 4).
+					Return(&models.Weather{ID: 4, Version: 5}, nil).
+					Once()
+
+				return mockDB
+			},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, repository.ErrVersionConflict)
+			},
+		},
+		{
+			name: "Missing record",
+			dbBuilder: func(t *testing.T) repository.Database {
+				t.Helper()
+
+				mockDB := NewMockDatabase(t)
+				mockDB.
+					On("UpdateWeather", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to update weather: %w", repository.NewErrNotFound(4))).
+					Once()
+				mockDB.
+					On("GetWeather", mock.Anything, 4).
+					Return(nil, fmt.Errorf("failed to get weather: %w", repository.NewErrNotFound(4))).
+					Once()
+
+				return mockDB
+			},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorAs(t, err, &repository.ErrNotFound{})
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := repository.NewWeatherRepository(tc.dbBuilder(t))
+
+			ob := &models.Weather{ID: 4, Version: 2}
+			err := repo.UpdateWeather(context.Background(), ob)
+			tc.checkErr(t, err)
+
+			if err == nil {
+				assert.Equal(t, 3, ob.Version)
+			}
+		})
+	}
+}
+
 func TestDeleteWeatherObservationWithoutError(t *testing.T) {
 	t.Parallel()
 
@@ -417,7 +507,7 @@ func TestDeleteWeatherObservationWithoutError(t *testing.T) {
 					}, nil).
 					Once()
 				mockService.
-					On("DeleteWeather", mock.Anything, 1).
+					On("DeleteWeather", mock.Anything, 1, 0).
 					Return(&models.Weather{
 						ID:            1,
 						Temperature:   -5.0,
@@ -464,7 +554,7 @@ func TestDeleteWeatherObservationWithoutError(t *testing.T) {
 					}, nil).
 					Once()
 				mockService.
-					On("DeleteWeather", mock.Anything, 2).
+					On("DeleteWeather", mock.Anything, 2, 0).
 					Return(&models.Weather{
 						ID:            2,
 						Temperature:   28.0,
@@ -505,7 +595,7 @@ func TestDeleteWeatherObservationWithoutError(t *testing.T) {
 
 			tc.ob.ID = id
 
-			ob, err := repo.DeleteWeather(tc.ctx, id)
+			ob, err := repo.DeleteWeather(tc.ctx, id, 0)
 			require.NoError(t, err)
 			assert.Equal(t, tc.ob, ob)
 
@@ -533,7 +623,7 @@ func TestDeleteWeatherObservationWithError(t *testing.T) {
 
 				mockService := NewMockDatabase(t)
 				mockService.
-					On("DeleteWeather", mock.Anything, 1).
+					On("DeleteWeather", mock.Anything, 1, 0).
 					Return(nil, repository.ErrNotFound{}).
 					Once()
 
@@ -549,7 +639,7 @@ func TestDeleteWeatherObservationWithError(t *testing.T) {
 			db := tc.dbBuilder(t)
 			repo := repository.NewWeatherRepository(db)
 
-			_, err := repo.DeleteWeather(tc.ctx, tc.id)
+			_, err := repo.DeleteWeather(tc.ctx, tc.id, 0)
 			require.ErrorAs(t, err, &repository.ErrNotFound{})
 		})
 	}
@@ -570,7 +660,7 @@ func TestListWeatherObservationsWithoutError(t *testing.T) {
 
 				mockService := NewMockDatabase(t)
 				mockService.
-					On("ListWeathers", mock.Anything).
+					On("ListWeathers", mock.Anything, mock.Anything).
 					Return([]*models.Weather{}, nil).
 					Once()
 
@@ -585,7 +675,7 @@ func TestListWeatherObservationsWithoutError(t *testing.T) {
 
 				mockService := NewMockDatabase(t)
 				mockService.
-					On("ListWeathers", mock.Anything).
+					On("ListWeathers", mock.Anything, mock.Anything).
 					Return([]*models.Weather{
 						{
 							ID:            1,
@@ -620,7 +710,7 @@ func TestListWeatherObservationsWithoutError(t *testing.T) {
 
 				mockService := NewMockDatabase(t)
 				mockService.
-					On("ListWeathers", mock.Anything).
+					On("ListWeathers", mock.Anything, mock.Anything).
 					Return([]*models.Weather{
 						{
 							ID:            1,
@@ -693,7 +783,7 @@ func TestListWeatherObservationsWithoutError(t *testing.T) {
 			db := tc.dbBuilder(t)
 			repo := repository.NewWeatherRepository(db)
 
-			observations, err := repo.ListWeathers(context.Background())
+			observations, err := repo.ListWeathers(context.Background(), models.WeatherFilter{})
 			require.NoError(t, err)
 			assert.Equal(t, tc.observations, observations)
 		})
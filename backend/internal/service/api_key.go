@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+const (
+	apiKeyScheme      = "wfk"
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	ErrAPIKeyRevoked = errors.New("api key is revoked")
+)
+
+// apiKeyPermissions lists the permissions that may be delegated to a station
+// key. Administrative permissions are never granted to machine credentials.
+var apiKeyPermissions = []models.Permission{
+	models.PermissionWeatherCreate,
+	models.PermissionWeatherUpdate,
+	models.PermissionWeatherDelete,
+}
+
+//go:generate mockery --name APIKeyRepo --structname MockAPIKeyRepo --filename mock_api_key_repo_test.go --outpkg service_test --output .
+type APIKeyRepo interface {
+	AddAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, error)
+	GetAPIKey(ctx context.Context, id int) (*models.APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int, prefix, keyHash string) (*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) (*models.APIKey, error)
+	TouchAPIKey(ctx context.Context, id int) error
+}
+
+type APIKeyService struct {
+	repo APIKeyRepo
+}
+
+func NewAPIKeyService(repo APIKeyRepo) *APIKeyService {
+	return &APIKeyService{repo: repo}
+}
+
+func (s *APIKeyService) CreateAPIKey(
+	ctx context.Context,
+	createdBy int,
+	name string,
+	cities []string,
+	permissions []models.Permission,
+) (*models.IssuedAPIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.CreateAPIKey")
+	defer span.End()
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+
+	cities, err := normalizeCities(cities)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAPIKeyPermissions(permissions); err != nil {
+		return nil, err
+	}
+
+	key, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		Name:        name,
+		Prefix:      prefix,
+		KeyHash:     hash,
+		Cities:      cities,
+		Permissions: permissions,
+	}
+
+	if createdBy != 0 {
+		apiKey.CreatedBy = &createdBy
+	}
+
+	res, err := s.repo.AddAPIKey(ctx, apiKey)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add api key: %w", err))
+	}
+
+	return &models.IssuedAPIKey{APIKey: *res, Key: key}, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.ListAPIKeys")
+	defer span.End()
+
+	keys, err := s.repo.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list api keys: %w", err))
+	}
+
+	return keys, nil
+}
+
+// RotateAPIKey replaces the secret of the key while keeping its id, scope and
+// attribution history. The previous secret stops working immediately.
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, id int) (*models.IssuedAPIKey, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.RotateAPIKey")
+	defer span.End()
+
+	current, err := s.repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get api key: %w", err))
+	}
+
+	if current.RevokedAt != nil {
+		return nil, fmt.Errorf("%w: id=%d", ErrAPIKeyRevoked, id)
+	}
+
+	key, prefix, hash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.RotateAPIKey(ctx, id, prefix, hash)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to rotate api key: %w", err))
+	}
+
+	return &models.IssuedAPIKey{APIKey: *res, Key: key}, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "APIKeyService.RevokeAPIKey")
+	defer span.End()
+
+	if _, err := s.repo.RevokeAPIKey(ctx, id); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to revoke api key: %w", err))
+	}
+
+	return nil
+}
+
+func (s *APIKeyService) AuthenticateAPIKey(
+	ctx context.Context,
+	key string,
+) (*models.Principal, error) {
+	ctx, span := tracer.Start(ctx, "APIKeyService.AuthenticateAPIKey")
+	defer span.End()
+
+	prefix, ok := parseAPIKeyPrefix(key)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return nil, ErrInvalidAPIKey
+		}
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get api key: %w", err))
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(apiKey.KeyHash)) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.repo.TouchAPIKey(ctx, apiKey.ID); err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to touch api key: %w", err))
+	}
+
+	return &models.Principal{
+		Username:    apiKey.Name,
+		Permissions: apiKey.Permissions,
+		APIKeyID:    apiKey.ID,
+		Cities:      apiKey.Cities,
+	}, nil
+}
+
+// generateAPIKey returns a key of the form wfk_<prefix>_<secret>. The prefix
+// is stored in plain text for lookup, the whole key only as a SHA-256 hash.
+func generateAPIKey() (key, prefix, hash string, err error) {
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = fmt.Sprintf("%s_%s_%s", apiKeyScheme, prefix, hex.EncodeToString(secret))
+
+	return key, prefix, hashAPIKey(key), nil
+}
+
+func parseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyScheme || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeCities(cities []string) ([]string, error) {
+	res := make([]string, 0, len(cities))
+	for _, city := range cities {
+		city = strings.TrimSpace(city)
+		if city == "" {
+			return nil, fmt.Errorf("%w: city must not be empty", ErrInvalidInput)
+		}
+
+		res = append(res, city)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: at least one city is required", ErrInvalidInput)
+	}
+
+	return res, nil
+}
+
+func checkAPIKeyPermissions(permissions []models.Permission) error {
+	if len(permissions) == 0 {
+		return fmt.Errorf("%w: at least one permission is required", ErrInvalidInput)
+	}
+
+	for _, permission := range permissions {
+		if !slices.Contains(apiKeyPermissions, permission) {
+			return fmt.Errorf("%w: permission %q cannot be granted to api keys", ErrInvalidInput, permission)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package service_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKeyWithoutError(t *testing.T) {
+	t.Parallel()
+
+	var stored *models.APIKey
+
+	repo := NewMockAPIKeyRepo(t)
+	repo.On("AddAPIKey", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, key *models.APIKey) (*models.APIKey, error) {
+			stored = key
+			res := *key
+			res.ID = 3
+
+			return &res, nil
+		}).
+		Once()
+
+	srv := service.NewAPIKeyService(repo)
+
+	issued, err := srv.CreateAPIKey(
+		context.Background(),
+		7,
+		" minsk-station ",
+		[]string{" Minsk "},
+		[]models.Permission{models.PermissionWeatherCreate},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, issued.ID)
+	assert.Equal(t, "minsk-station", stored.Name)
+	assert.Equal(t, []string{"Minsk"}, stored.Cities)
+	assert.Equal(t, 7, *stored.CreatedBy)
+	assert.True(t, strings.HasPrefix(issued.Key, "wfk_"+stored.Prefix+"_"))
+
+	sum := sha256.Sum256([]byte(issued.Key))
+	assert.Equal(t, hex.EncodeToString(sum[:]), stored.KeyHash)
+}
+
+func TestCreateAPIKeyWithError(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		keyName     string
+		cities      []string
+		permissions []models.Permission
+	}
+
+	tt := []TestCase{
+		{
+			name:        "missing name",
+			cities:      []string{"Minsk"},
+			permissions: []models.Permission{models.PermissionWeatherCreate},
+		},
+		{
+			name:        "missing cities",
+			keyName:     "station",
+			permissions: []models.Permission{models.PermissionWeatherCreate},
+		},
+		{
+			name:    "missing permissions",
+			keyName: "station",
+			cities:  []string{"Minsk"},
+		},
+		{
+			name:        "administrative permission",
+			keyName:     "station",
+			cities:      []string{"Minsk"},
+			permissions: []models.Permission{models.PermissionUsersManage},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewAPIKeyService(NewMockAPIKeyRepo(t))
+
+			_, err := srv.CreateAPIKey(context.Background(), 1, tc.keyName, tc.cities, tc.permissions)
+			require.ErrorIs(t, err, service.ErrInvalidInput)
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	const key = "wfk_0a1b2c_secret"
+
+	sum := sha256.Sum256([]byte(key))
+	hash := hex.EncodeToString(sum[:])
+	revokedAt := time.Now()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.APIKeyRepo
+		key         string
+		principal   *models.Principal
+		err         error
+	}
+
+	tt := []TestCase{
+		{
+			name: "valid key",
+			repoBuilder: func(t *testing.T) service.APIKeyRepo {
+				t.Helper()
+
+				repo := NewMockAPIKeyRepo(t)
+				repo.On("GetAPIKeyByPrefix", mock.Anything, "0a1b2c").Return(&models.APIKey{
+					ID:          3,
+					Name:        "minsk-station",
+					KeyHash:     hash,
+					Cities:      []string{"Minsk"},
+					Permissions: []models.Permission{models.PermissionWeatherCreate},
+				}, nil).Once()
+				repo.On("TouchAPIKey", mock.Anything, 3).Return(nil).Once()
+
+				return repo
+			},
+			key: key,
+			principal: &models.Principal{
+				Username:    "minsk-station",
+				Permissions: []models.Permission{models.PermissionWeatherCreate},
+				APIKeyID:    3,
+				Cities:      []string{"Minsk"},
+			},
+		},
+		{
+			name: "wrong secret",
+			repoBuilder: func(t *testing.T) service.APIKeyRepo {
+				t.Helper()
+
+				repo := NewMockAPIKeyRepo(t)
+				repo.On("GetAPIKeyByPrefix", mock.Anything, "0a1b2c").
+					Return(&models.APIKey{ID: 3, KeyHash: hash}, nil).
+					Once()
+
+				return repo
+			},
+			key: "wfk_0a1b2c_guess",
+			err: service.ErrInvalidAPIKey,
+		},
+		{
+			name: "revoked key",
+			repoBuilder: func(t *testing.T) service.APIKeyRepo {
+				t.Helper()
+
+				repo := NewMockAPIKeyRepo(t)
+				repo.On("GetAPIKeyByPrefix", mock.Anything, "0a1b2c").
+					Return(&models.APIKey{ID: 3, KeyHash: hash, RevokedAt: &revokedAt}, nil).
+					Once()
+
+				return repo
+			},
+			key: key,
+			err: service.ErrInvalidAPIKey,
+		},
+		{
+			name: "unknown prefix",
+			repoBuilder: func(t *testing.T) service.APIKeyRepo {
+				t.Helper()
+
+				repo := NewMockAPIKeyRepo(t)
+				repo.On("GetAPIKeyByPrefix", mock.Anything, "0a1b2c").
+					Return(nil, repository.NewErrNotFoundBy("prefix", "0a1b2c")).
+					Once()
+
+				return repo
+			},
+			key: key,
+			err: service.ErrInvalidAPIKey,
+		},
+		{
+			name: "malformed key",
+			repoBuilder: func(t *testing.T) service.APIKeyRepo {
+				t.Helper()
+
+				return NewMockAPIKeyRepo(t)
+			},
+			key: "not-a-key",
+			err: service.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewAPIKeyService(tc.repoBuilder(t))
+
+			principal, err := srv.AuthenticateAPIKey(context.Background(), tc.key)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.principal, principal)
+		})
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("active key", func(t *testing.T) {
+		t.Parallel()
+
+		repo := NewMockAPIKeyRepo(t)
+		repo.On("GetAPIKey", mock.Anything, 3).Return(&models.APIKey{ID: 3, Prefix: "old"}, nil).Once()
+		repo.On("RotateAPIKey", mock.Anything, 3, mock.Anything, mock.Anything).
+			Return(func(_ context.Context, id int, prefix, hash string) (*models.APIKey, error) {
+				return &models.APIKey{ID: id, Prefix: prefix, KeyHash: hash}, nil
+			}).
+			Once()
+
+		srv := service.NewAPIKeyService(repo)
+
+		issued, err := srv.RotateAPIKey(context.Background(), 3)
+		require.NoError(t, err)
+		assert.NotEqual(t, "old", issued.Prefix)
+		assert.True(t, strings.HasPrefix(issued.Key, "wfk_"+issued.Prefix+"_"))
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		t.Parallel()
+
+		revokedAt := time.Now()
+
+		repo := NewMockAPIKeyRepo(t)
+		repo.On("GetAPIKey", mock.Anything, 3).
+			Return(&models.APIKey{ID: 3, RevokedAt: &revokedAt}, nil).
+			Once()
+
+		srv := service.NewAPIKeyService(repo)
+
+		_, err := srv.RotateAPIKey(context.Background(), 3)
+		require.ErrorIs(t, err, service.ErrAPIKeyRevoked)
+	})
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidToken       = errors.New("invalid token")
+)
+
+type AuthConfig struct {
+	JWTSecret       string        `env:"JWT_SECRET"        env-required:"true"`
+	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL"  env-default:"15m"`
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" env-default:"720h"`
+	AdminUsername   string        `env:"ADMIN_USERNAME"`
+	AdminPassword   string        `env:"ADMIN_PASSWORD"`
+}
+
+type AuthService struct {
+	repo UserRepo
+	cfg  AuthConfig
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Username    string              `json:"username"`
+	Roles       []models.Role       `json:"roles,omitempty"`
+	Permissions []models.Permission `json:"permissions,omitempty"`
+	TokenType   string              `json:"typ"`
+}
+
+func NewAuthService(repo UserRepo, cfg AuthConfig) *AuthService {
+	return &AuthService{
+		repo: repo,
+		cfg:  cfg,
+	}
+}
+
+func (s *AuthService) Login(
+	ctx context.Context,
+	username, password string,
+) (*models.TokenPair, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	user, err := s.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return nil, ErrInvalidCredentials
+		}
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	pair, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to issue tokens: %w", err))
+	}
+
+	return pair, nil
+}
+
+// Refresh rotates the refresh token: the presented one is revoked and a new
+// pair is issued.
+func (s *AuthService) Refresh(
+	ctx context.Context,
+	refreshToken string,
+) (*models.TokenPair, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.Refresh")
+	defer span.End()
+
+	claims, err := s.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.activeRefreshToken(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUser(ctx, stored.UserID)
+	if err != nil {
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return nil, ErrInvalidToken
+		}
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	// the revoke decides which of concurrent refreshes gets the new pair
+	if err := s.repo.RevokeRefreshToken(ctx, stored.ID); err != nil {
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return nil, ErrInvalidToken
+		}
+
+		return nil, tracing.Fail(span, fmt.Errorf("failed to revoke refresh token: %w", err))
+	}
+
+	pair, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to issue tokens: %w", err))
+	}
+
+	return pair, nil
+}
+
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.Logout")
+	defer span.End()
+
+	claims, err := s.parse(refreshToken, refreshTokenType)
+	if err != nil {
+		return err
+	}
+
+	// a token that is already revoked or unknown leaves nothing to log out
+	err = s.repo.RevokeRefreshToken(ctx, claims.ID)
+	if err != nil && !errors.As(err, &repository.ErrNotFound{}) {
+		return tracing.Fail(span, fmt.Errorf("failed to revoke refresh token: %w", err))
+	}
+
+	return nil
+}
+
+func (s *AuthService) ParseAccessToken(token string) (*models.Principal, error) {
+	claims, err := s.parse(token, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &models.Principal{
+		UserID:      userID,
+		Username:    claims.Username,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+	}, nil
+}
+
+func (s *AuthService) activeRefreshToken(
+	ctx context.Context,
+	id string,
+) (*models.RefreshToken, error) {
+	stored, err := s.repo.GetRefreshToken(ctx, id)
+	if err != nil {
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return nil, ErrInvalidToken
+		}
+
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+
+	return stored, nil
+}
+
+func (s *AuthService) issueTokens(
+	ctx context.Context,
+	user *models.User,
+) (*models.TokenPair, error) {
+	now := time.Now()
+
+	permissions, err := s.repo.ListUserPermissions(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	access, err := s.sign(tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.AccessTokenTTL)),
+		},
+		Username:    user.Username,
+		Roles:       user.Roles,
+		Permissions: permissions,
+		TokenType:   accessTokenType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jti, err := newTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	// roles and permissions are re-read on refresh, so the refresh token
+	// only identifies the user
+	refresh, err := s.sign(tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.cfg.RefreshTokenTTL)),
+		},
+		Username:  user.Username,
+		TokenType: refreshTokenType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.AddRefreshToken(ctx, &models.RefreshToken{
+		ID:        jti,
+		UserID:    user.ID,
+		ExpiresAt: now.Add(s.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &models.TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.cfg.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) sign(claims tokenClaims) (string, error) {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).
+		SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return signed, nil
+}
+
+func (s *AuthService) parse(token, tokenType string) (*tokenClaims, error) {
+	var claims tokenClaims
+
+	_, err := jwt.ParseWithClaims(
+		token,
+		&claims,
+		func(*jwt.Token) (any, error) { return []byte(s.cfg.JWTSecret), nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.TokenType != tokenType {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testAuthConfig = service.AuthConfig{
+	JWTSecret:       "secret",
+	AccessTokenTTL:  time.Minute,
+	RefreshTokenTTL: time.Hour,
+}
+
+func testUser(t *testing.T) *models.User {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	return &models.User{
+		ID:           7,
+		Username:     "admin",
+		PasswordHash: string(hash),
+		Roles:        []models.Role{models.RoleAdmin},
+	}
+}
+
+var testPermissions = []models.Permission{
+	models.PermissionWeatherCreate,
+	models.PermissionUsersManage,
+}
+
+func TestLoginWithoutError(t *testing.T) {
+	t.Parallel()
+
+	user := testUser(t)
+
+	repo := NewMockUserRepo(t)
+	repo.On("GetUserByUsername", mock.Anything, "admin").Return(user, nil).Once()
+	repo.On("ListUserPermissions", mock.Anything, 7).Return(testPermissions, nil)
+	repo.On("AddRefreshToken", mock.Anything, mock.MatchedBy(func(tkn *models.RefreshToken) bool {
+		return tkn.UserID == 7 && tkn.ID != ""
+	})).Return(nil).Once()
+
+	srv := service.NewAuthService(repo, testAuthConfig)
+
+	pair, err := srv.Login(context.Background(), "admin", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", pair.TokenType)
+	assert.Equal(t, 60, pair.ExpiresIn)
+
+	principal, err := srv.ParseAccessToken(pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, &models.Principal{
+		UserID:      7,
+		Username:    "admin",
+		Roles:       []models.Role{models.RoleAdmin},
+		Permissions: testPermissions,
+	}, principal)
+
+	_, err = srv.ParseAccessToken(pair.RefreshToken)
+	require.ErrorIs(t, err, service.ErrInvalidToken)
+}
+
+func TestLoginWithError(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.UserRepo
+		password    string
+		err         error
+	}
+
+	tt := []TestCase{
+		{
+			name: "wrong password",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("GetUserByUsername", mock.Anything, "admin").Return(testUser(t), nil).Once()
+
+				return repo
+			},
+			password: "wrong",
+			err:      service.ErrInvalidCredentials,
+		},
+		{
+			name: "unknown user",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("GetUserByUsername", mock.Anything, "admin").
+					Return(nil, repository.NewErrNotFoundBy("username", "admin")).
+					Once()
+
+				return repo
+			},
+			password: "password",
+			err:      service.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewAuthService(tc.repoBuilder(t), testAuthConfig)
+
+			_, err := srv.Login(context.Background(), "admin", tc.password)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	t.Parallel()
+
+	user := testUser(t)
+
+	var stored *models.RefreshToken
+
+	repo := NewMockUserRepo(t)
+	repo.On("GetUserByUsername", mock.Anything, "admin").Return(user, nil).Once()
+	repo.On("ListUserPermissions", mock.Anything, 7).Return(testPermissions, nil)
+	repo.On("AddRefreshToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.RefreshToken) }).
+		Return(nil).
+		Twice()
+	repo.On("GetRefreshToken", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, id string) (*models.RefreshToken, error) {
+			if stored.ID != id {
+				return nil, repository.NewErrNotFoundBy("token", id)
+			}
+
+			return stored, nil
+		}).
+		Once()
+	repo.On("GetUser", mock.Anything, 7).Return(user, nil).Once()
+	repo.On("RevokeRefreshToken", mock.Anything, mock.Anything).Return(nil).Once()
+
+	srv := service.NewAuthService(repo, testAuthConfig)
+
+	pair, err := srv.Login(context.Background(), "admin", "password")
+	require.NoError(t, err)
+
+	oldID := stored.ID
+
+	refreshed, err := srv.Refresh(context.Background(), pair.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldID, stored.ID)
+	repo.AssertCalled(t, "RevokeRefreshToken", mock.Anything, oldID)
+
+	_, err = srv.ParseAccessToken(refreshed.AccessToken)
+	require.NoError(t, err)
+}
+
+func TestRefreshWithError(t *testing.T) {
+	t.Parallel()
+
+	user := testUser(t)
+	revokedAt := time.Now()
+
+	var stored *models.RefreshToken
+
+	repo := NewMockUserRepo(t)
+	repo.On("GetUserByUsername", mock.Anything, "admin").Return(user, nil).Once()
+	repo.On("ListUserPermissions", mock.Anything, 7).Return(testPermissions, nil)
+	repo.On("AddRefreshToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.RefreshToken) }).
+		Return(nil).
+		Once()
+	repo.On("GetRefreshToken", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, id string) (*models.RefreshToken, error) {
+			revoked := *stored
+			revoked.RevokedAt = &revokedAt
+
+			return &revoked, nil
+		}).
+		Once()
+
+	srv := service.NewAuthService(repo, testAuthConfig)
+
+	pair, err := srv.Login(context.Background(), "admin", "password")
+	require.NoError(t, err)
+
+	_, err = srv.Refresh(context.Background(), pair.RefreshToken)
+	require.ErrorIs(t, err, service.ErrInvalidToken)
+
+	_, err = srv.Refresh(context.Background(), pair.AccessToken)
+	require.ErrorIs(t, err, service.ErrInvalidToken)
+}
+
+func TestRefreshLosesRace(t *testing.T) {
+	t.Parallel()
+
+	user := testUser(t)
+
+	var stored *models.RefreshToken
+
+	repo := NewMockUserRepo(t)
+	repo.On("GetUserByUsername", mock.Anything, "admin").Return(user, nil).Once()
+	repo.On("ListUserPermissions", mock.Anything, 7).Return(testPermissions, nil).Once()
+	repo.On("AddRefreshToken", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*models.RefreshToken) }).
+		Return(nil).
+		Once()
+	repo.On("GetRefreshToken", mock.Anything, mock.Anything).
+		Return(func(context.Context, string) (*models.RefreshToken, error) { return stored, nil }).
+		Once()
+	repo.On("GetUser", mock.Anything, 7).Return(user, nil).Once()
+	// a concurrent refresh revoked the token after it was read
+	repo.On("RevokeRefreshToken", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, id string) error { return repository.NewErrNotFoundBy("token", id) }).
+		Once()
+
+	srv := service.NewAuthService(repo, testAuthConfig)
+
+	pair, err := srv.Login(context.Background(), "admin", "password")
+	require.NoError(t, err)
+
+	_, err = srv.Refresh(context.Background(), pair.RefreshToken)
+	require.ErrorIs(t, err, service.ErrInvalidToken)
+	repo.AssertNumberOfCalls(t, "AddRefreshToken", 1)
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	user := testUser(t)
+
+	repo := NewMockUserRepo(t)
+	repo.On("GetUserByUsername", mock.Anything, "admin").Return(user, nil).Once()
+	repo.On("ListUserPermissions", mock.Anything, 7).Return(testPermissions, nil)
+	repo.On("AddRefreshToken", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("RevokeRefreshToken", mock.Anything, mock.Anything).Return(nil).Once()
+	repo.On("RevokeRefreshToken", mock.Anything, mock.Anything).
+		Return(func(_ context.Context, id string) error { return repository.NewErrNotFoundBy("token", id) }).
+		Once()
+
+	srv := service.NewAuthService(repo, testAuthConfig)
+
+	pair, err := srv.Login(context.Background(), "admin", "password")
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Logout(context.Background(), pair.RefreshToken))
+	require.NoError(t, srv.Logout(context.Background(), pair.RefreshToken))
+	require.ErrorIs(t, srv.Logout(context.Background(), "garbage"), service.ErrInvalidToken)
+}
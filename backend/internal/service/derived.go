@@ -0,0 +1,45 @@
+package service
+
+import (
+	"math"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/meteo"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+// AddDerived fills in the derived metrics of every observation.
+func AddDerived(obs ...*models.Weather) {
+	for _, ob := range obs {
+		ob.Derived = Derive(ob)
+	}
+}
+
+// Derive computes the comfort and moisture indices of an observation, rounded
+// to two decimals.
+func Derive(ob *models.Weather) *models.DerivedMetrics {
+	derived := &models.DerivedMetrics{
+		ApparentTemperature: round2(meteo.ApparentTemperature(ob.Temperature, ob.Humidity, ob.WindSpeed)),
+		AbsoluteHumidity:    round2(meteo.AbsoluteHumidity(ob.Temperature, ob.Humidity)),
+		Beaufort:            meteo.Beaufort(ob.WindSpeed),
+	}
+
+	derived.HeatIndex = optional(meteo.HeatIndex(ob.Temperature, ob.Humidity))
+	derived.WindChill = optional(meteo.WindChill(ob.Temperature, ob.WindSpeed))
+	derived.DewPoint = optional(meteo.DewPoint(ob.Temperature, ob.Humidity))
+
+	return derived
+}
+
+func optional(value float64, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+
+	value = round2(value)
+
+	return &value
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
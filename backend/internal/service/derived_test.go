@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDerive(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(f float64) *float64 { return &f }
+
+	type TestCase struct {
+		name     string
+		ob       models.Weather
+		expected models.DerivedMetrics
+	}
+
+	tt := []TestCase{
+		{
+			name: "hot and humid",
+			ob:   models.Weather{Temperature: 30, Humidity: 70, WindSpeed: 2},
+			expected: models.DerivedMetrics{
+				HeatIndex:           ptr(35.04),
+				ApparentTemperature: 34.38,
+				DewPoint:            ptr(23.93),
+				AbsoluteHumidity:    21.18,
+				Beaufort:            2,
+			},
+		},
+		{
+			name: "cold and windy",
+			ob:   models.Weather{Temperature: -5, Humidity: 85, WindSpeed: 8},
+			expected: models.DerivedMetrics{
+				WindChill:           ptr(-12.85),
+				ApparentTemperature: -13.42,
+				DewPoint:            ptr(-7.13),
+				AbsoluteHumidity:    2.9,
+				Beaufort:            5,
+			},
+		},
+		{
+			name: "dry calm air",
+			ob:   models.Weather{Temperature: 15, Humidity: 0, WindSpeed: 0},
+			expected: models.DerivedMetrics{
+				ApparentTemperature: 11,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, &tc.expected, service.Derive(&tc.ob))
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/forecast"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name ForecastRepo --structname MockForecastRepo --filename mock_forecast_repo_test.go --outpkg service_test --output .
+type ForecastRepo interface {
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	GetLocationByName(ctx context.Context, name, country string) (*models.Location, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	SaveForecast(ctx context.Context, fc *models.Forecast) error
+	ForecastAccuracy(ctx context.Context, filter models.AccuracyFilter) ([]*models.ForecastAccuracy, error)
+}
+
+const (
+	DefaultHourlyHorizon = 24
+	MaxHourlyHorizon     = 7 * 24
+	DefaultDailyHorizon  = 7
+	MaxDailyHorizon      = 30
+	MaxForecastPoints    = 1000
+
+	// the models are fitted on this many periods before the forecast
+	hourlyHistory = 14 * 24
+	dailyHistory  = 365
+
+	// hourly observations follow the diurnal cycle
+	hourlySeason = 24
+)
+
+var ErrNotEnoughHistory = errors.New("not enough observations to forecast")
+
+type ForecastService struct {
+	repo ForecastRepo
+}
+
+func NewForecastService(repo ForecastRepo) *ForecastService {
+	return &ForecastService{repo: repo}
+}
+
+// Forecast predicts the mean temperature, humidity, pressure and wind speed of
+// the next req.Horizon hours or days from the observations of the preceding
+// weeks, and stores the run so that it can later be compared to reality.
+func (s *ForecastService) Forecast(
+	ctx context.Context,
+	req models.ForecastRequest,
+) (*models.Forecast, error) {
+	ctx, span := tracer.Start(ctx, "ForecastService.Forecast")
+	defer span.End()
+
+	var id int
+	if req.LocationID != nil {
+		id = *req.LocationID
+	}
+
+	loc, err := s.location(ctx, id, req.City, req.Country)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Granularity == "" {
+		req.Granularity = models.BucketHour
+	}
+
+	step, history, season := time.Hour, hourlyHistory, hourlySeason
+	if req.Granularity == models.BucketDay {
+		step, history, season = 24*time.Hour, dailyHistory, 1
+	}
+
+	if req.Horizon == 0 {
+		req.Horizon = DefaultHourlyHorizon
+		if req.Granularity == models.BucketDay {
+			req.Horizon = DefaultDailyHorizon
+		}
+	}
+
+	issuedAt := time.Now().UTC().Truncate(step)
+	from := issuedAt.Add(-time.Duration(history) * step)
+
+	// forecast periods are UTC periods whatever the location's time zone
+	utc := time.UTC.String()
+
+	stats, err := s.repo.WeatherStats(ctx, models.StatsFilter{
+		LocationID: &loc.ID,
+		From:       &from,
+		To:         &issuedAt,
+		Bucket:     req.Granularity,
+		TimeZone:   &utc,
+	})
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather stats: %w", err))
+	}
+
+	series, known := newMetricSeries(stats, from, step, history)
+	if known < forecast.MinPoints {
+		return nil, ErrNotEnoughHistory
+	}
+
+	fc := &models.Forecast{
+		LocationID:  loc.ID,
+		City:        loc.Name,
+		Country:     loc.Country,
+		Granularity: req.Granularity,
+		IssuedAt:    issuedAt,
+		Points:      make([]models.ForecastPoint, req.Horizon),
+	}
+
+	metrics := []struct {
+		values []float64
+		lo, hi float64
+		get    func(p *models.ForecastPoint) **models.Prediction
+	}{
+		{
+			values: series.temperature,
+			lo:     minTemperature,
+			hi:     maxTemperature,
+			get:    func(p *models.ForecastPoint) **models.Prediction { return &p.Temperature },
+		},
+		{
+			values: series.humidity,
+			lo:     minHumidity,
+			hi:     maxHumidity,
+			get:    func(p *models.ForecastPoint) **models.Prediction { return &p.Humidity },
+		},
+		{
+			values: series.pressure,
+			lo:     minPressure,
+			hi:     maxPressure,
+			get:    func(p *models.ForecastPoint) **models.Prediction { return &p.Pressure },
+		},
+		{
+			values: series.windSpeed,
+			lo:     minWindSpeed,
+			hi:     maxWindSpeed,
+			get:    func(p *models.ForecastPoint) **models.Prediction { return &p.WindSpeed },
+		},
+	}
+
+	for _, m := range metrics {
+		res, err := forecast.Predict(m.values, season, req.Horizon)
+		if err != nil {
+			return nil, tracing.Fail(span, fmt.Errorf("failed to predict: %w", err))
+		}
+
+		fc.Model = res.Model
+
+		for i, p := range res.Predictions {
+			lower, upper := clamp(p.Lower, m.lo, m.hi), clamp(p.Upper, m.lo, m.hi)
+			*m.get(&fc.Points[i]) = &models.Prediction{
+				Value: clamp(p.Value, m.lo, m.hi),
+				Lower: &lower,
+				Upper: &upper,
+			}
+		}
+	}
+
+	for i := range fc.Points {
+		fc.Points[i].Time = issuedAt.Add(time.Duration(i) * step)
+	}
+
+	if err := s.repo.SaveForecast(ctx, fc); err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to save forecast: %w", err))
+	}
+
+	return fc, nil
+}
+
+// UploadForecast validates and stores a third-party forecast run. The location
+// is given by fc.LocationID or by fc.City and fc.Country and must exist.
+func (s *ForecastService) UploadForecast(ctx context.Context, fc *models.Forecast) error {
+	ctx, span := tracer.Start(ctx, "ForecastService.UploadForecast")
+	defer span.End()
+
+	NormalizeForecast(fc)
+
+	if err := ValidateForecast(fc, time.Now()); err != nil {
+		return err
+	}
+
+	loc, err := s.location(ctx, fc.LocationID, fc.City, fc.Country)
+	if err != nil {
+		return err
+	}
+
+	fc.LocationID, fc.City, fc.Country = loc.ID, loc.Name, loc.Country
+
+	if err := s.repo.SaveForecast(ctx, fc); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to save forecast: %w", err))
+	}
+
+	return nil
+}
+
+// ForecastAccuracy compares stored forecasts with the observations made
+// afterwards, per model, location and horizon, and summarizes every model.
+func (s *ForecastService) ForecastAccuracy(
+	ctx context.Context,
+	filter models.AccuracyFilter,
+) (*models.AccuracyReport, error) {
+	ctx, span := tracer.Start(ctx, "ForecastService.ForecastAccuracy")
+	defer span.End()
+
+	if filter.Granularity == "" {
+		filter.Granularity = models.BucketHour
+	}
+
+	items, err := s.repo.ForecastAccuracy(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get forecast accuracy: %w", err))
+	}
+
+	report := &models.AccuracyReport{
+		Granularity: filter.Granularity,
+		Models:      []*models.ModelAccuracy{},
+		Items:       items,
+	}
+
+	// items are sorted by model
+	for _, item := range items {
+		if n := len(report.Models); n == 0 || report.Models[n-1].Model != item.Model {
+			report.Models = append(report.Models, &models.ModelAccuracy{Model: item.Model})
+		}
+
+		mergeErrors(&report.Models[len(report.Models)-1].VariableErrors, item.VariableErrors)
+	}
+
+	return report, nil
+}
+
+func (s *ForecastService) location(
+	ctx context.Context,
+	id int,
+	city string,
+	country string,
+) (*models.Location, error) {
+	var (
+		loc *models.Location
+		err error
+	)
+
+	if id != 0 {
+		loc, err = s.repo.GetLocation(ctx, id)
+	} else {
+		loc, err = s.repo.GetLocationByName(ctx, city, country)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+
+	return loc, nil
+}
+
+// mergeErrors adds src to dst, weighting both by their counts.
+func mergeErrors(dst *models.VariableErrors, src models.VariableErrors) {
+	mergeErrorStats(&dst.Temperature, src.Temperature)
+	mergeErrorStats(&dst.Humidity, src.Humidity)
+	mergeErrorStats(&dst.Pressure, src.Pressure)
+	mergeErrorStats(&dst.WindSpeed, src.WindSpeed)
+}
+
+func mergeErrorStats(dst **models.ErrorStats, src *models.ErrorStats) {
+	if src == nil {
+		return
+	}
+
+	if *dst == nil {
+		*dst = &models.ErrorStats{}
+	}
+
+	d := *dst
+	total := float64(d.Count + src.Count)
+	wd, ws := float64(d.Count)/total, float64(src.Count)/total
+
+	d.MAE = d.MAE*wd + src.MAE*ws
+	d.RMSE = math.Sqrt(d.RMSE*d.RMSE*wd + src.RMSE*src.RMSE*ws)
+	d.Bias = d.Bias*wd + src.Bias*ws
+	d.Count += src.Count
+}
+
+type metricSeries struct {
+	temperature []float64
+	humidity    []float64
+	pressure    []float64
+	windSpeed   []float64
+}
+
+// newMetricSeries lays the per-period means out on an even grid starting at
+// the first period with observations. Periods without observations are
+// interpolated. It also returns the number of periods with observations.
+func newMetricSeries(
+	stats []*models.WeatherStats,
+	from time.Time,
+	step time.Duration,
+	periods int,
+) (metricSeries, int) {
+	series := metricSeries{
+		temperature: nanSlice(periods),
+		humidity:    nanSlice(periods),
+		pressure:    nanSlice(periods),
+		windSpeed:   nanSlice(periods),
+	}
+
+	first, known := periods, 0
+
+	for _, st := range stats {
+		i := int(st.PeriodStart.Sub(from) / step)
+		if i < 0 || i >= periods {
+			continue
+		}
+
+		series.temperature[i] = st.Temperature.Mean
+		series.humidity[i] = st.Humidity.Mean
+		series.pressure[i] = st.Pressure.Mean
+		series.windSpeed[i] = st.WindSpeed.Mean
+		first = min(first, i)
+		known++
+	}
+
+	if known == 0 {
+		return metricSeries{}, 0
+	}
+
+	for _, values := range []*[]float64{
+		&series.temperature,
+		&series.humidity,
+		&series.pressure,
+		&series.windSpeed,
+	} {
+		*values = (*values)[first:]
+		forecast.FillGaps(*values)
+	}
+
+	return series, known
+}
+
+func nanSlice(n int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = math.NaN()
+	}
+
+	return values
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
@@ -0,0 +1,209 @@
+package service_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/forecast"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// hourlyStats returns the means of the given number of hours before the
+// current one, following a daily temperature cycle.
+func hourlyStats(hours int) []*models.WeatherStats {
+	issuedAt := time.Now().UTC().Truncate(time.Hour)
+
+	stats := make([]*models.WeatherStats, hours)
+	for i := range stats {
+		start := issuedAt.Add(-time.Duration(hours-i) * time.Hour)
+		stats[i] = &models.WeatherStats{
+			LocationID:  2,
+			PeriodStart: start,
+			Count:       1,
+			Temperature: models.MetricStats{Mean: 10 + 5*math.Sin(2*math.Pi*float64(start.Hour())/24)},
+			Humidity:    models.MetricStats{Mean: 99 + float64(i%2)},
+			Pressure:    models.MetricStats{Mean: 1013},
+			WindSpeed:   models.MetricStats{Mean: 3},
+		}
+	}
+
+	return stats
+}
+
+func TestForecast(t *testing.T) {
+	t.Parallel()
+
+	minsk := &models.Location{ID: 2, Name: "Minsk", Country: "Belarus", Timezone: "UTC"}
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.ForecastRepo
+		req         models.ForecastRequest
+		checkResult func(t *testing.T, fc *models.Forecast, err error)
+	}
+
+	tt := []TestCase{
+		{
+			name: "hourly forecast by name",
+			repoBuilder: func(t *testing.T) service.ForecastRepo {
+				t.Helper()
+
+				repo := NewMockForecastRepo(t)
+				repo.On("GetLocationByName", mock.Anything, "minsk", "belarus").Return(minsk, nil).Once()
+				repo.On("WeatherStats", mock.Anything, mock.MatchedBy(func(f models.StatsFilter) bool {
+					return f.LocationID != nil && *f.LocationID == 2 && f.Bucket == models.BucketHour &&
+						f.TimeZone != nil && *f.TimeZone == "UTC" &&
+						f.To.Sub(*f.From) == 14*24*time.Hour
+				})).Return(hourlyStats(72), nil).Once()
+				repo.On("SaveForecast", mock.Anything, mock.MatchedBy(func(fc *models.Forecast) bool {
+					return len(fc.Points) == service.DefaultHourlyHorizon
+				})).Return(nil).Once()
+
+				return repo
+			},
+			req: models.ForecastRequest{City: "minsk", Country: "belarus"},
+			checkResult: func(t *testing.T, fc *models.Forecast, err error) {
+				t.Helper()
+
+				require.NoError(t, err)
+				assert.Equal(t, "Minsk", fc.City)
+				assert.Equal(t, forecast.ModelHoltWinters, fc.Model)
+				assert.Equal(t, models.BucketHour, fc.Granularity)
+				assert.Equal(t, fc.IssuedAt, fc.Points[0].Time)
+				assert.Equal(t, fc.IssuedAt.Add(23*time.Hour), fc.Points[23].Time)
+
+				for _, p := range fc.Points {
+					expected := 10 + 5*math.Sin(2*math.Pi*float64(p.Time.Hour())/24)
+					assert.InDelta(t, expected, p.Temperature.Value, 1)
+					assert.LessOrEqual(t, *p.Humidity.Upper, 100.0)
+				}
+			},
+		},
+		{
+			name: "not enough history",
+			repoBuilder: func(t *testing.T) service.ForecastRepo {
+				t.Helper()
+
+				repo := NewMockForecastRepo(t)
+				repo.On("GetLocation", mock.Anything, 2).Return(minsk, nil).Once()
+				repo.On("WeatherStats", mock.Anything, mock.Anything).Return(hourlyStats(2), nil).Once()
+
+				return repo
+			},
+			req: models.ForecastRequest{LocationID: &minsk.ID},
+			checkResult: func(t *testing.T, _ *models.Forecast, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, service.ErrNotEnoughHistory)
+			},
+		},
+		{
+			name: "unknown location",
+			repoBuilder: func(t *testing.T) service.ForecastRepo {
+				t.Helper()
+
+				repo := NewMockForecastRepo(t)
+				repo.On("GetLocationByName", mock.Anything, "Atlantis", "Greece").
+					Return(nil, repository.NewErrNotFoundBy("name", "Atlantis, Greece")).
+					Once()
+
+				return repo
+			},
+			req: models.ForecastRequest{City: "Atlantis", Country: "Greece"},
+			checkResult: func(t *testing.T, _ *models.Forecast, err error) {
+				t.Helper()
+				require.ErrorAs(t, err, &repository.ErrNotFound{})
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewForecastService(tc.repoBuilder(t))
+
+			fc, err := srv.Forecast(context.Background(), tc.req)
+			tc.checkResult(t, fc, err)
+		})
+	}
+}
+
+func TestForecastAccuracy(t *testing.T) {
+	t.Parallel()
+
+	repo := NewMockForecastRepo(t)
+	repo.On("ForecastAccuracy", mock.Anything, models.AccuracyFilter{Granularity: models.BucketHour}).
+		Return([]*models.ForecastAccuracy{
+			{
+				Model:   "acme",
+				Horizon: 1,
+				VariableErrors: models.VariableErrors{
+					Temperature: &models.ErrorStats{Count: 1, MAE: 1, RMSE: 1, Bias: 1},
+				},
+			},
+			{
+				Model:   "acme",
+				Horizon: 2,
+				VariableErrors: models.VariableErrors{
+					Temperature: &models.ErrorStats{Count: 3, MAE: 3, RMSE: 3, Bias: -3},
+					WindSpeed:   &models.ErrorStats{Count: 2, MAE: 0.5, RMSE: 0.5, Bias: 0},
+				},
+			},
+			{Model: "holt", Horizon: 1},
+		}, nil).
+		Once()
+
+	report, err := service.NewForecastService(repo).ForecastAccuracy(context.Background(), models.AccuracyFilter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.BucketHour, report.Granularity)
+	assert.Len(t, report.Items, 3)
+	require.Len(t, report.Models, 2)
+
+	acme := report.Models[0]
+	assert.Equal(t, "acme", acme.Model)
+	require.NotNil(t, acme.Temperature)
+	assert.Equal(t, 4, acme.Temperature.Count)
+	assert.InDelta(t, 2.5, acme.Temperature.MAE, 1e-9)
+	assert.InDelta(t, math.Sqrt(7), acme.Temperature.RMSE, 1e-9)
+	assert.InDelta(t, -2, acme.Temperature.Bias, 1e-9)
+	assert.Equal(t, &models.ErrorStats{Count: 2, MAE: 0.5, RMSE: 0.5}, acme.WindSpeed)
+	assert.Nil(t, acme.Humidity)
+
+	assert.Equal(t, &models.ModelAccuracy{Model: "holt"}, report.Models[1])
+}
+
+func TestUploadForecast(t *testing.T) {
+	t.Parallel()
+
+	repo := NewMockForecastRepo(t)
+	repo.On("GetLocationByName", mock.Anything, "Minsk", "Belarus").
+		Return(&models.Location{ID: 2, Name: "Minsk", Country: "Belarus"}, nil).
+		Once()
+	repo.On("SaveForecast", mock.Anything, mock.MatchedBy(func(fc *models.Forecast) bool {
+		return fc.LocationID == 2 && fc.Model == "acme"
+	})).Return(nil).Once()
+
+	issuedAt := time.Now().UTC().Truncate(time.Hour)
+	fc := &models.Forecast{
+		City:        " Minsk ",
+		Country:     "Belarus",
+		Model:       "acme",
+		Granularity: models.BucketHour,
+		IssuedAt:    issuedAt,
+		Points: []models.ForecastPoint{
+			{Time: issuedAt.Add(time.Hour), Pressure: &models.Prediction{Value: 1010}},
+		},
+	}
+
+	require.NoError(t, service.NewForecastService(repo).UploadForecast(context.Background(), fc))
+	assert.Equal(t, 2, fc.LocationID)
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+// HealthChecker checks a dependency the service needs to serve requests. The
+// returned details are included in the readiness report either way.
+type HealthChecker interface {
+	Check(ctx context.Context) (map[string]any, error)
+}
+
+type HealthCheckerFunc func(ctx context.Context) (map[string]any, error)
+
+func (f HealthCheckerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return f(ctx)
+}
+
+type HealthConfig struct {
+	HealthCheckTimeout time.Duration `env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+}
+
+type HealthService struct {
+	cfg      HealthConfig
+	mu       sync.RWMutex
+	checkers map[string]HealthChecker
+	draining atomic.Bool
+}
+
+func NewHealthService(cfg HealthConfig) *HealthService {
+	return &HealthService{
+		cfg:      cfg,
+		checkers: make(map[string]HealthChecker),
+	}
+}
+
+// Register adds a dependency to the readiness checks, replacing any checker
+// registered under the same name.
+func (s *HealthService) Register(name string, checker HealthChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkers[name] = checker
+}
+
+// Drain makes the service report not ready from now on, so that load balancers
+// stop sending traffic while in-flight requests finish.
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+// Ready runs every checker concurrently, each bounded by the check timeout.
+// The service is ready when all of them pass and it is not draining.
+func (s *HealthService) Ready(ctx context.Context) *models.HealthReport {
+	s.mu.RLock()
+	checkers := maps.Clone(s.checkers)
+	s.mu.RUnlock()
+
+	report := &models.HealthReport{
+		Status: models.HealthOK,
+		Checks: make(map[string]models.CheckResult, len(checkers)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for name, checker := range checkers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			res := s.check(ctx, checker)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[name] = res
+			if res.Status != models.HealthOK {
+				report.Status = models.HealthFail
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if s.draining.Load() {
+		report.Status = models.HealthDraining
+	}
+
+	return report
+}
+
+func (s *HealthService) check(ctx context.Context, checker HealthChecker) models.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.HealthCheckTimeout)
+	defer cancel()
+
+	type outcome struct {
+		details map[string]any
+		err     error
+	}
+
+	// a checker ignoring its context must not hold the report up
+	done := make(chan outcome, 1)
+
+	go func() {
+		details, err := checker.Check(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var res outcome
+
+	select {
+	case res = <-done:
+	case <-ctx.Done():
+		res.err = fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+
+	if res.err != nil {
+		return models.CheckResult{
+			Status:  models.HealthFail,
+			Error:   res.err.Error(),
+			Details: res.details,
+		}
+	}
+
+	return models.CheckResult{Status: models.HealthOK, Details: res.details}
+}
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type SchemaVersioner interface {
+	Current(ctx context.Context) (version int, dirty bool, err error)
+	Latest() int
+}
+
+// PostgresChecker pings the database pool and reports the applied and the
+// latest known migration. A dirty schema fails the check; a pending migration
+// does not, since instances of the new version may be rolling out.
+func PostgresChecker(db Pinger, schema SchemaVersioner) HealthChecker {
+	return HealthCheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		if err := db.Ping(ctx); err != nil {
+			return nil, fmt.Errorf("failed to ping postgres: %w", err)
+		}
+
+		version, dirty, err := schema.Current(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details := map[string]any{
+			"migration_version": version,
+			"latest_migration":  schema.Latest(),
+			"dirty":             dirty,
+		}
+
+		if dirty {
+			return details, fmt.Errorf("schema is dirty at version %d", version)
+		}
+
+		return details, nil
+	})
+}
@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) Ping(context.Context) error {
+	return p.err
+}
+
+type fakeSchema struct {
+	version int
+	dirty   bool
+}
+
+func (s fakeSchema) Current(context.Context) (int, bool, error) {
+	return s.version, s.dirty, nil
+}
+
+func (s fakeSchema) Latest() int {
+	return 12
+}
+
+func TestHealthReady(t *testing.T) {
+	t.Parallel()
+
+	ok := service.HealthCheckerFunc(func(context.Context) (map[string]any, error) {
+		return nil, nil
+	})
+
+	hanging := service.HealthCheckerFunc(func(context.Context) (map[string]any, error) {
+		select {}
+	})
+
+	type testCase struct {
+		name     string
+		checkers map[string]service.HealthChecker
+		drain    bool
+		expected *models.HealthReport
+	}
+
+	tt := []testCase{
+		{
+			name: "All dependencies up",
+			checkers: map[string]service.HealthChecker{
+				"postgres": service.PostgresChecker(fakePinger{}, fakeSchema{version: 12}),
+				"cache":    ok,
+			},
+			expected: &models.HealthReport{
+				Status: models.HealthOK,
+				Checks: map[string]models.CheckResult{
+					"postgres": {
+						Status: models.HealthOK,
+						Details: map[string]any{
+							"migration_version": 12,
+							"latest_migration":  12,
+							"dirty":             false,
+						},
+					},
+					"cache": {Status: models.HealthOK},
+				},
+			},
+		},
+		{
+			name: "Postgres unreachable",
+			checkers: map[string]service.HealthChecker{
+				"postgres": service.PostgresChecker(fakePinger{err: errors.New("connection refused")}, fakeSchema{}),
+				"cache":    ok,
+			},
+			expected: &models.HealthReport{
+				Status: models.HealthFail,
+				Checks: map[string]models.CheckResult{
+					"postgres": {
+						Status: models.HealthFail,
+						Error:  "failed to ping postgres: connection refused",
+					},
+					"cache": {Status: models.HealthOK},
+				},
+			},
+		},
+		{
+			name: "Dirty schema",
+			checkers: map[string]service.HealthChecker{
+				"postgres": service.PostgresChecker(fakePinger{}, fakeSchema{version: 11, dirty: true}),
+			},
+			expected: &models.HealthReport{
+				Status: models.HealthFail,
+				Checks: map[string]models.CheckResult{
+					"postgres": {
+						Status: models.HealthFail,
+						Error:  "schema is dirty at version 11",
+						Details: map[string]any{
+							"migration_version": 11,
+							"latest_migration":  12,
+							"dirty":             true,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "Hanging checker times out",
+			checkers: map[string]service.HealthChecker{"slow": hanging},
+			expected: &models.HealthReport{
+				Status: models.HealthFail,
+				Checks: map[string]models.CheckResult{
+					"slow": {
+						Status: models.HealthFail,
+						Error:  "check timed out: context deadline exceeded",
+					},
+				},
+			},
+		},
+		{
+			name:     "Draining",
+			checkers: map[string]service.HealthChecker{"cache": ok},
+			drain:    true,
+			expected: &models.HealthReport{
+				Status: models.HealthDraining,
+				Checks: map[string]models.CheckResult{
+					"cache": {Status: models.HealthOK},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := service.NewHealthService(service.HealthConfig{HealthCheckTimeout: 50 * time.Millisecond})
+			for name, checker := range tc.checkers {
+				s.Register(name, checker)
+			}
+
+			if tc.drain {
+				s.Drain()
+			}
+
+			assert.Equal(t, tc.expected, s.Ready(context.Background()))
+		})
+	}
+}
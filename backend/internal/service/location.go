@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+)
+
+//go:generate mockery --name LocationRepo --structname MockLocationRepo --filename mock_location_repo_test.go --outpkg service_test --output .
+type LocationRepo interface {
+	AddLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	ListLocations(ctx context.Context) ([]*models.Location, error)
+	UpdateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id int) (*models.Location, error)
+}
+
+type LocationService struct {
+	repo LocationRepo
+}
+
+func NewLocationService(repo LocationRepo) *LocationService {
+	return &LocationService{repo: repo}
+}
+
+func (s *LocationService) CreateLocation(
+	ctx context.Context,
+	location *models.Location,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.CreateLocation")
+	defer span.End()
+
+	NormalizeLocation(location)
+
+	if err := ValidateLocation(location); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.AddLocation(ctx, location)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (s *LocationService) GetLocation(ctx context.Context, id int) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.GetLocation")
+	defer span.End()
+
+	res, err := s.repo.GetLocation(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (s *LocationService) ListLocations(ctx context.Context) ([]*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.ListLocations")
+	defer span.End()
+
+	res, err := s.repo.ListLocations(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list locations: %w", err))
+	}
+
+	return res, nil
+}
+
+// UpdateLocation replaces the location. Observations follow the change since
+// they reference the location by id.
+func (s *LocationService) UpdateLocation(
+	ctx context.Context,
+	location *models.Location,
+) (*models.Location, error) {
+	ctx, span := tracer.Start(ctx, "LocationService.UpdateLocation")
+	defer span.End()
+
+	NormalizeLocation(location)
+
+	if err := ValidateLocation(location); err != nil {
+		return nil, err
+	}
+
+	res, err := s.repo.UpdateLocation(ctx, location)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to update location: %w", err))
+	}
+
+	return res, nil
+}
+
+func (s *LocationService) DeleteLocation(ctx context.Context, id int) error {
+	ctx, span := tracer.Start(ctx, "LocationService.DeleteLocation")
+	defer span.End()
+
+	if _, err := s.repo.DeleteLocation(ctx, id); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to delete location: %w", err))
+	}
+
+	return nil
+}
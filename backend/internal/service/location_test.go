@@ -0,0 +1,74 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLocation(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.LocationRepo
+		location    *models.Location
+		checkErr    func(t *testing.T, err error)
+	}
+
+	tt := []TestCase{
+		{
+			name: "normalizes and stores",
+			repoBuilder: func(t *testing.T) service.LocationRepo {
+				t.Helper()
+
+				repo := NewMockLocationRepo(t)
+				repo.On("AddLocation", mock.Anything, &models.Location{
+					Name:     "Minsk",
+					Country:  "Belarus",
+					Timezone: "UTC",
+				}).Return(&models.Location{ID: 2, Name: "Minsk", Country: "Belarus", Timezone: "UTC"}, nil).Once()
+
+				return repo
+			},
+			location: &models.Location{Name: " Minsk", Country: "Belarus "},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "invalid location",
+			repoBuilder: func(t *testing.T) service.LocationRepo {
+				t.Helper()
+
+				return NewMockLocationRepo(t)
+			},
+			location: &models.Location{Name: "Minsk", Timezone: "Nowhere/City"},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Len(t, verr.Fields, 2)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewLocationService(tc.repoBuilder(t))
+
+			_, err := srv.CreateLocation(context.Background(), tc.location)
+			tc.checkErr(t, err)
+		})
+	}
+}
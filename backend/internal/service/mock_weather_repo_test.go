@@ -42,9 +42,78 @@ func (_m *MockWeatherRepo) AddWeather(ctx context.Context, ob *models.Weather) (
 	return r0, r1
 }
 
-// DeleteWeather provides a mock function with given fields: ctx, id
-func (_m *MockWeatherRepo) DeleteWeather(ctx context.Context, id int) (*models.Weather, error) {
-	ret := _m.Called(ctx, id)
+// AddWeathers provides a mock function with given fields: ctx, obs
+func (_m *MockWeatherRepo) AddWeathers(ctx context.Context, obs []*models.Weather) ([]int, error) {
+	ret := _m.Called(ctx, obs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWeathers")
+	}
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) ([]int, error)); ok {
+		return rf(ctx, obs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) []int); ok {
+		r0 = rf(ctx, obs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Weather) error); ok {
+		r1 = rf(ctx, obs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddWeathersPartial provides a mock function with given fields: ctx, obs
+func (_m *MockWeatherRepo) AddWeathersPartial(ctx context.Context, obs []*models.Weather) ([]int, []error, error) {
+	ret := _m.Called(ctx, obs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWeathersPartial")
+	}
+
+	var r0 []int
+	var r1 []error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) ([]int, []error, error)); ok {
+		return rf(ctx, obs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Weather) []int); ok {
+		r0 = rf(ctx, obs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.Weather) []error); ok {
+		r1 = rf(ctx, obs)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []*models.Weather) error); ok {
+		r2 = rf(ctx, obs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeleteWeather provides a mock function with given fields: ctx, id, version
+func (_m *MockWeatherRepo) DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteWeather")
@@ -52,19 +121,19 @@ func (_m *MockWeatherRepo) DeleteWeather(ctx context.Context, id int) (*models.W
 
 	var r0 *models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Weather, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Weather, error)); ok {
+		return rf(ctx, id, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Weather); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Weather); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -102,9 +171,39 @@ func (_m *MockWeatherRepo) GetWeather(ctx context.Context, id int) (*models.Weat
 	return r0, r1
 }
 
-// ListWeathers provides a mock function with given fields: ctx
-func (_m *MockWeatherRepo) ListWeathers(ctx context.Context) ([]*models.Weather, error) {
-	ret := _m.Called(ctx)
+// LatestWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherRepo) LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestWeathers")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.LatestFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherRepo) ListWeathers(ctx context.Context, filter models.WeatherFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWeathers")
@@ -112,19 +211,109 @@ func (_m *MockWeatherRepo) ListWeathers(ctx context.Context) ([]*models.Weather,
 
 	var r0 []*models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Weather, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.WeatherFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersInBBox provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherRepo) ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersInBBox")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.BBoxFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersNear provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherRepo) ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersNear")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Weather); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, models.NearFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchWeather provides a mock function with given fields: ctx, id, version, patch
+func (_m *MockWeatherRepo) PatchWeather(ctx context.Context, id int, version int, patch *models.WeatherPatch) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchWeather")
+	}
+
+	var r0 *models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) (*models.Weather, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) *models.Weather); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *models.WeatherPatch) error); ok {
+		r1 = rf(ctx, id, version, patch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,6 +339,36 @@ func (_m *MockWeatherRepo) UpdateWeather(ctx context.Context, ob *models.Weather
 	return r0
 }
 
+// WeatherStats provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherRepo) WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WeatherStats")
+	}
+
+	var r0 []*models.WeatherStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) ([]*models.WeatherStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) []*models.WeatherStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WeatherStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockWeatherRepo creates a new instance of MockWeatherRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockWeatherRepo(t interface {
@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer starts the spans of every service method.
+var tracer = otel.Tracer("github.com/LLIEPJIOK/weather-forecast/backend/internal/service")
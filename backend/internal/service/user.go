@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLength = 8
+
+var (
+	ErrUnknownRole  = errors.New("unknown role")
+	ErrUserExists   = errors.New("user already exists")
+	ErrInvalidInput = errors.New("invalid input")
+)
+
+//go:generate mockery --name UserRepo --structname MockUserRepo --filename mock_user_repo_test.go --outpkg service_test --output .
+type UserRepo interface {
+	AddUser(ctx context.Context, user *models.User) (int, error)
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	SetUserRoles(ctx context.Context, userID int, roles []models.Role) error
+	ListUserPermissions(ctx context.Context, userID int) ([]models.Permission, error)
+	ListRoles(ctx context.Context) ([]*models.RoleDefinition, error)
+	AddRefreshToken(ctx context.Context, token *models.RefreshToken) error
+	GetRefreshToken(ctx context.Context, id string) (*models.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id string) error
+}
+
+type UserService struct {
+	repo UserRepo
+}
+
+func NewUserService(repo UserRepo) *UserService {
+	return &UserService{repo: repo}
+}
+
+// EnsureUser creates the user if it does not exist yet. It is used to
+// bootstrap the first administrator from the config.
+func (s *UserService) EnsureUser(
+	ctx context.Context,
+	username, password string,
+	roles ...models.Role,
+) error {
+	ctx, span := tracer.Start(ctx, "UserService.EnsureUser")
+	defer span.End()
+
+	_, err := s.repo.GetUserByUsername(ctx, username)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.As(err, &repository.ErrNotFound{}) {
+		return tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	if _, err := s.addUser(ctx, username, password, roles); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *UserService) CreateUser(
+	ctx context.Context,
+	username, password string,
+	roles []models.Role,
+) (int, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
+	if username == "" || len(password) < minPasswordLength {
+		return 0, fmt.Errorf(
+			"%w: username is required and password must be at least %d characters",
+			ErrInvalidInput,
+			minPasswordLength,
+		)
+	}
+
+	if err := s.checkRoles(ctx, roles); err != nil {
+		return 0, err
+	}
+
+	_, err := s.repo.GetUserByUsername(ctx, username)
+	if err == nil {
+		return 0, fmt.Errorf("%w: %q", ErrUserExists, username)
+	}
+
+	if !errors.As(err, &repository.ErrNotFound{}) {
+		return 0, tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	return s.addUser(ctx, username, password, roles)
+}
+
+func (s *UserService) ListUsers(ctx context.Context) ([]*models.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUsers")
+	defer span.End()
+
+	users, err := s.repo.ListUsers(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list users: %w", err))
+	}
+
+	return users, nil
+}
+
+func (s *UserService) SetUserRoles(
+	ctx context.Context,
+	userID int,
+	roles []models.Role,
+) error {
+	ctx, span := tracer.Start(ctx, "UserService.SetUserRoles")
+	defer span.End()
+
+	if err := s.checkRoles(ctx, roles); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetUser(ctx, userID); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to get user: %w", err))
+	}
+
+	if err := s.repo.SetUserRoles(ctx, userID, roles); err != nil {
+		return tracing.Fail(span, fmt.Errorf("failed to set user roles: %w", err))
+	}
+
+	return nil
+}
+
+func (s *UserService) ListRoles(ctx context.Context) ([]*models.RoleDefinition, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListRoles")
+	defer span.End()
+
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list roles: %w", err))
+	}
+
+	return roles, nil
+}
+
+func (s *UserService) addUser(
+	ctx context.Context,
+	username, password string,
+	roles []models.Role,
+) (int, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	id, err := s.repo.AddUser(ctx, &models.User{
+		Username:     username,
+		PasswordHash: string(hash),
+		Roles:        roles,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add user: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *UserService) checkRoles(ctx context.Context, roles []models.Role) error {
+	known, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	names := make(map[models.Role]struct{}, len(known))
+	for _, role := range known {
+		names[role.Name] = struct{}{}
+	}
+
+	for _, role := range roles {
+		if _, ok := names[role]; !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownRole, role)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testRoles = []*models.RoleDefinition{
+	{Name: models.RoleAdmin},
+	{Name: models.RoleEditor},
+	{Name: models.RoleObserver},
+	{Name: models.RoleViewer},
+}
+
+func TestEnsureUser(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.UserRepo
+		err         bool
+	}
+
+	tt := []TestCase{
+		{
+			name: "creates missing user",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("GetUserByUsername", mock.Anything, "admin").
+					Return(nil, repository.NewErrNotFoundBy("username", "admin")).
+					Once()
+				repo.On("AddUser", mock.Anything, mock.MatchedBy(func(u *models.User) bool {
+					return u.Username == "admin" &&
+						assert.ObjectsAreEqual([]models.Role{models.RoleAdmin}, u.Roles) &&
+						bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("password")) == nil
+				})).Return(1, nil).Once()
+
+				return repo
+			},
+		},
+		{
+			name: "keeps existing user",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("GetUserByUsername", mock.Anything, "admin").Return(testUser(t), nil).Once()
+
+				return repo
+			},
+		},
+		{
+			name: "repo error",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("GetUserByUsername", mock.Anything, "admin").
+					Return(nil, errors.New("connection refused")).
+					Once()
+
+				return repo
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewUserService(tc.repoBuilder(t))
+
+			err := srv.EnsureUser(context.Background(), "admin", "password", models.RoleAdmin)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.UserRepo
+		username    string
+		password    string
+		roles       []models.Role
+		id          int
+		err         error
+	}
+
+	tt := []TestCase{
+		{
+			name: "primary",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "bob").
+					Return(nil, repository.NewErrNotFoundBy("username", "bob")).
+					Once()
+				repo.On("AddUser", mock.Anything, mock.Anything).Return(5, nil).Once()
+
+				return repo
+			},
+			username: "bob",
+			password: "password",
+			roles:    []models.Role{models.RoleObserver},
+			id:       5,
+		},
+		{
+			name: "short password",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				return NewMockUserRepo(t)
+			},
+			username: "bob",
+			password: "short",
+			err:      service.ErrInvalidInput,
+		},
+		{
+			name: "unknown role",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+
+				return repo
+			},
+			username: "bob",
+			password: "password",
+			roles:    []models.Role{"superuser"},
+			err:      service.ErrUnknownRole,
+		},
+		{
+			name: "existing user",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+				repo.On("GetUserByUsername", mock.Anything, "admin").Return(testUser(t), nil).Once()
+
+				return repo
+			},
+			username: "admin",
+			password: "password",
+			roles:    []models.Role{models.RoleAdmin},
+			err:      service.ErrUserExists,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewUserService(tc.repoBuilder(t))
+
+			id, err := srv.CreateUser(context.Background(), tc.username, tc.password, tc.roles)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestSetUserRoles(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.UserRepo
+		roles       []models.Role
+		checkErr    func(t *testing.T, err error)
+	}
+
+	tt := []TestCase{
+		{
+			name: "primary",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				roles := []models.Role{models.RoleEditor, models.RoleObserver}
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+				repo.On("GetUser", mock.Anything, 7).Return(testUser(t), nil).Once()
+				repo.On("SetUserRoles", mock.Anything, 7, roles).Return(nil).Once()
+
+				return repo
+			},
+			roles: []models.Role{models.RoleEditor, models.RoleObserver},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "missing user",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+				repo.On("GetUser", mock.Anything, 7).Return(nil, repository.NewErrNotFound(7)).Once()
+
+				return repo
+			},
+			roles: []models.Role{models.RoleViewer},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorAs(t, err, &repository.ErrNotFound{})
+			},
+		},
+		{
+			name: "unknown role",
+			repoBuilder: func(t *testing.T) service.UserRepo {
+				t.Helper()
+
+				repo := NewMockUserRepo(t)
+				repo.On("ListRoles", mock.Anything).Return(testRoles, nil).Once()
+
+				return repo
+			},
+			roles: []models.Role{"root"},
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, service.ErrUnknownRole)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewUserService(tc.repoBuilder(t))
+
+			tc.checkErr(t, srv.SetUserRoles(context.Background(), 7, tc.roles))
+		})
+	}
+}
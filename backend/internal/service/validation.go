@@ -0,0 +1,325 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/forecast"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+const (
+	minTemperature = -100.0
+	maxTemperature = 70.0
+	minHumidity    = 0.0
+	maxHumidity    = 100.0
+	minPressure    = 800.0
+	maxPressure    = 1100.0
+	minWindSpeed   = 0.0
+	maxWindSpeed   = 120.0
+	minLatitude    = -90.0
+	maxLatitude    = 90.0
+	minLongitude   = -180.0
+	maxLongitude   = 180.0
+	minElevation   = -500.0
+	maxElevation   = 9000.0
+
+	maxNameLength = 100
+
+	// maxClockSkew tolerates station clocks that run slightly ahead.
+	maxClockSkew = time.Hour
+)
+
+var minTimestamp = time.Date(1850, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = fmt.Sprintf("%s %s", f.Field, f.Message)
+	}
+
+	return "invalid weather: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) add(field, code, format string, args ...any) {
+	e.Fields = append(e.Fields, models.FieldError{
+		Field:   field,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// NormalizeWeather trims free-text fields and brings weather_status to its
+// canonical snake_case form, so "Partly cloudy" is stored as partly_cloudy.
+func NormalizeWeather(ob *models.Weather) {
+	ob.City = strings.TrimSpace(ob.City)
+	ob.Country = strings.TrimSpace(ob.Country)
+
+	status := strings.ToLower(strings.TrimSpace(ob.WeatherStatus))
+	ob.WeatherStatus = strings.Join(strings.FieldsFunc(status, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// ValidateWeather checks required fields, physical ranges and timestamp
+// sanity. All offending fields are reported at once.
+func ValidateWeather(ob *models.Weather, now time.Time) error {
+	verr := &ValidationError{}
+
+	switch {
+	case ob.Timestamp.IsZero():
+		verr.add("timestamp", models.CodeRequired, "is required")
+	case ob.Timestamp.Before(minTimestamp):
+		verr.add("timestamp", models.CodeOutOfRange, "must not be before %s", minTimestamp.Format(time.DateOnly))
+	case ob.Timestamp.After(now.Add(maxClockSkew)):
+		verr.add("timestamp", models.CodeInFuture, "must not be in the future")
+	}
+
+	// a bare location_id is enough; city and country otherwise go together
+	if ob.LocationID == 0 || ob.City != "" || ob.Country != "" {
+		checkName(verr, "city", ob.City)
+		checkName(verr, "country", ob.Country)
+	}
+
+	if ob.LocationID < 0 {
+		verr.add("location_id", models.CodeInvalidValue, "must be positive")
+	}
+
+	checkCoordinates(verr, ob.Latitude, ob.Longitude)
+
+	checkRange(verr, "temperature", ob.Temperature, minTemperature, maxTemperature)
+	checkRange(verr, "humidity", ob.Humidity, minHumidity, maxHumidity)
+	checkRange(verr, "pressure", ob.Pressure, minPressure, maxPressure)
+	checkRange(verr, "wind_speed", ob.WindSpeed, minWindSpeed, maxWindSpeed)
+
+	switch {
+	case ob.WeatherStatus == "":
+		verr.add("weather_status", models.CodeRequired, "is required")
+	case !slices.Contains(models.WeatherStatuses, ob.WeatherStatus):
+		verr.add(
+			"weather_status",
+			models.CodeInvalidValue,
+			"must be one of %s",
+			strings.Join(models.WeatherStatuses, ", "),
+		)
+	}
+
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+// withoutField drops the errors of field from a validation error, returning
+// nil if no other field failed.
+func withoutField(err error, field string) error {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return err
+	}
+
+	fields := slices.DeleteFunc(slices.Clone(verr.Fields), func(f models.FieldError) bool {
+		return f.Field == field
+	})
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: fields}
+}
+
+// NormalizeLocation trims free-text fields and defaults the timezone to UTC.
+func NormalizeLocation(loc *models.Location) {
+	loc.Name = strings.TrimSpace(loc.Name)
+	loc.Country = strings.TrimSpace(loc.Country)
+	loc.Timezone = strings.TrimSpace(loc.Timezone)
+
+	if loc.Timezone == "" {
+		loc.Timezone = "UTC"
+	}
+}
+
+// ValidateLocation checks required fields, coordinate ranges and that the
+// timezone is a known IANA name.
+func ValidateLocation(loc *models.Location) error {
+	verr := &ValidationError{}
+
+	checkName(verr, "name", loc.Name)
+	checkName(verr, "country", loc.Country)
+
+	checkCoordinates(verr, loc.Latitude, loc.Longitude)
+
+	if loc.Elevation != nil {
+		checkRange(verr, "elevation", *loc.Elevation, minElevation, maxElevation)
+	}
+
+	if _, err := time.LoadLocation(loc.Timezone); err != nil || loc.Timezone == "Local" {
+		verr.add("timezone", models.CodeInvalidValue, "must be an IANA time zone name")
+	}
+
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+// NormalizeForecast trims free-text fields and moves point times to UTC.
+func NormalizeForecast(fc *models.Forecast) {
+	fc.Model = strings.TrimSpace(fc.Model)
+	fc.City = strings.TrimSpace(fc.City)
+	fc.Country = strings.TrimSpace(fc.Country)
+	fc.IssuedAt = fc.IssuedAt.UTC()
+
+	for i := range fc.Points {
+		fc.Points[i].Time = fc.Points[i].Time.UTC()
+	}
+}
+
+// ValidateForecast checks an uploaded forecast run. Point times must start an
+// hour or a day (UTC) depending on the granularity, must not precede the
+// period the forecast was issued in and must be unique.
+func ValidateForecast(fc *models.Forecast, now time.Time) error {
+	verr := &ValidationError{}
+
+	checkName(verr, "model", fc.Model)
+
+	if fc.Model == forecast.ModelHolt || fc.Model == forecast.ModelHoltWinters {
+		verr.add("model", models.CodeInvalidValue, "is reserved for generated forecasts")
+	}
+
+	if fc.LocationID == 0 || fc.City != "" || fc.Country != "" {
+		checkName(verr, "city", fc.City)
+		checkName(verr, "country", fc.Country)
+	}
+
+	if fc.LocationID < 0 {
+		verr.add("location_id", models.CodeInvalidValue, "must be positive")
+	}
+
+	step := time.Duration(0)
+
+	switch fc.Granularity {
+	case "":
+		verr.add("granularity", models.CodeRequired, "is required")
+	case models.BucketHour:
+		step = time.Hour
+	case models.BucketDay:
+		step = 24 * time.Hour
+	default:
+		verr.add("granularity", models.CodeInvalidValue, "must be one of hour, day")
+	}
+
+	switch {
+	case fc.IssuedAt.IsZero():
+		verr.add("issued_at", models.CodeRequired, "is required")
+	case fc.IssuedAt.Before(minTimestamp):
+		verr.add("issued_at", models.CodeOutOfRange, "must not be before %s", minTimestamp.Format(time.DateOnly))
+	case fc.IssuedAt.After(now.Add(maxClockSkew)):
+		verr.add("issued_at", models.CodeInFuture, "must not be in the future")
+	}
+
+	switch {
+	case len(fc.Points) == 0:
+		verr.add("points", models.CodeRequired, "is required")
+	case len(fc.Points) > MaxForecastPoints:
+		verr.add("points", models.CodeTooLong, "must contain at most %d points", MaxForecastPoints)
+	}
+
+	seen := make(map[time.Time]bool, len(fc.Points))
+
+	for i, p := range fc.Points {
+		field := fmt.Sprintf("points[%d]", i)
+
+		switch {
+		case p.Time.IsZero():
+			verr.add(field+".time", models.CodeRequired, "is required")
+		case step != 0 && !p.Time.Equal(p.Time.Truncate(step)):
+			verr.add(field+".time", models.CodeInvalidValue, "must be the start of a period")
+		case step != 0 && p.Time.Before(fc.IssuedAt.Truncate(step)):
+			verr.add(field+".time", models.CodeOutOfRange, "must not be before issued_at")
+		case seen[p.Time]:
+			verr.add(field+".time", models.CodeInvalidValue, "duplicates an earlier point")
+		}
+
+		seen[p.Time] = true
+
+		if p.Temperature == nil && p.Humidity == nil && p.Pressure == nil && p.WindSpeed == nil {
+			verr.add(field, models.CodeRequired, "must predict at least one variable")
+		}
+
+		checkPrediction(verr, field+".temperature", p.Temperature, minTemperature, maxTemperature)
+		checkPrediction(verr, field+".humidity", p.Humidity, minHumidity, maxHumidity)
+		checkPrediction(verr, field+".pressure", p.Pressure, minPressure, maxPressure)
+		checkPrediction(verr, field+".wind_speed", p.WindSpeed, minWindSpeed, maxWindSpeed)
+	}
+
+	if len(verr.Fields) > 0 {
+		return verr
+	}
+
+	return nil
+}
+
+func checkPrediction(verr *ValidationError, field string, p *models.Prediction, lo, hi float64) {
+	if p == nil {
+		return
+	}
+
+	checkRange(verr, field+".value", p.Value, lo, hi)
+
+	if p.Lower != nil {
+		checkRange(verr, field+".lower", *p.Lower, lo, hi)
+	}
+
+	if p.Upper != nil {
+		checkRange(verr, field+".upper", *p.Upper, lo, hi)
+	}
+
+	if (p.Lower != nil && *p.Lower > p.Value) || (p.Upper != nil && *p.Upper < p.Value) {
+		verr.add(field, models.CodeInvalidValue, "must satisfy lower <= value <= upper")
+	}
+}
+
+func checkName(verr *ValidationError, field, value string) {
+	switch {
+	case value == "":
+		verr.add(field, models.CodeRequired, "is required")
+	case len([]rune(value)) > maxNameLength:
+		verr.add(field, models.CodeTooLong, "must be at most %d characters", maxNameLength)
+	}
+}
+
+// checkCoordinates accepts either both coordinates or none.
+func checkCoordinates(verr *ValidationError, latitude, longitude *float64) {
+	switch {
+	case latitude == nil && longitude != nil:
+		verr.add("latitude", models.CodeRequired, "is required when longitude is set")
+	case longitude == nil && latitude != nil:
+		verr.add("longitude", models.CodeRequired, "is required when latitude is set")
+	}
+
+	if latitude != nil {
+		checkRange(verr, "latitude", *latitude, minLatitude, maxLatitude)
+	}
+
+	if longitude != nil {
+		checkRange(verr, "longitude", *longitude, minLongitude, maxLongitude)
+	}
+}
+
+func checkRange(verr *ValidationError, field string, value, lo, hi float64) {
+	if math.IsNaN(value) || value < lo || value > hi {
+		verr.add(field, models.CodeOutOfRange, "must be between %g and %g", lo, hi)
+	}
+}
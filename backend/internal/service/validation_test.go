@@ -0,0 +1,359 @@
+package service_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWeather(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+
+	type TestCase struct {
+		name   string
+		modify func(ob *models.Weather)
+		fields map[string]string
+	}
+
+	tt := []TestCase{
+		{
+			name:   "valid",
+			modify: func(*models.Weather) {},
+		},
+		{
+			name: "empty observation",
+			modify: func(ob *models.Weather) {
+				*ob = models.Weather{}
+			},
+			fields: map[string]string{
+				"timestamp":      models.CodeRequired,
+				"city":           models.CodeRequired,
+				"country":        models.CodeRequired,
+				"pressure":       models.CodeOutOfRange,
+				"weather_status": models.CodeRequired,
+			},
+		},
+		{
+			name: "physical ranges",
+			modify: func(ob *models.Weather) {
+				ob.Temperature = 120
+				ob.Humidity = 500
+				ob.WindSpeed = -1
+				ob.Pressure = math.NaN()
+			},
+			fields: map[string]string{
+				"temperature": models.CodeOutOfRange,
+				"humidity":    models.CodeOutOfRange,
+				"wind_speed":  models.CodeOutOfRange,
+				"pressure":    models.CodeOutOfRange,
+			},
+		},
+		{
+			name: "future timestamp",
+			modify: func(ob *models.Weather) {
+				ob.Timestamp = now.Add(2 * time.Hour)
+			},
+			fields: map[string]string{"timestamp": models.CodeInFuture},
+		},
+		{
+			name: "ancient timestamp",
+			modify: func(ob *models.Weather) {
+				ob.Timestamp = time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC)
+			},
+			fields: map[string]string{"timestamp": models.CodeOutOfRange},
+		},
+		{
+			name: "location id instead of city",
+			modify: func(ob *models.Weather) {
+				ob.City = ""
+				ob.Country = ""
+				ob.LocationID = 3
+			},
+		},
+		{
+			name: "location id with partial city",
+			modify: func(ob *models.Weather) {
+				ob.Country = ""
+				ob.LocationID = 3
+			},
+			fields: map[string]string{"country": models.CodeRequired},
+		},
+		{
+			name: "latitude without longitude",
+			modify: func(ob *models.Weather) {
+				lat := 53.9
+				ob.Latitude = &lat
+			},
+			fields: map[string]string{"longitude": models.CodeRequired},
+		},
+		{
+			name: "coordinates out of range",
+			modify: func(ob *models.Weather) {
+				lat, lon := 95.0, -181.0
+				ob.Latitude, ob.Longitude = &lat, &lon
+			},
+			fields: map[string]string{
+				"latitude":  models.CodeOutOfRange,
+				"longitude": models.CodeOutOfRange,
+			},
+		},
+		{
+			name: "unknown status and long city",
+			modify: func(ob *models.Weather) {
+				ob.WeatherStatus = "meteor_shower"
+				ob.City = strings.Repeat("x", 101)
+			},
+			fields: map[string]string{
+				"weather_status": models.CodeInvalidValue,
+				"city":           models.CodeTooLong,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ob := validWeather()
+			tc.modify(ob)
+
+			err := service.ValidateWeather(ob, now)
+			if len(tc.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *service.ValidationError
+			require.ErrorAs(t, err, &verr)
+
+			fields := make(map[string]string, len(verr.Fields))
+			for _, f := range verr.Fields {
+				fields[f.Field] = f.Code
+			}
+
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestNormalizeWeather(t *testing.T) {
+	t.Parallel()
+
+	ob := &models.Weather{City: " Minsk ", Country: "Belarus\t", WeatherStatus: " Partly  Cloudy"}
+	service.NormalizeWeather(ob)
+
+	assert.Equal(t, "Minsk", ob.City)
+	assert.Equal(t, "Belarus", ob.Country)
+	assert.Equal(t, models.StatusPartlyCloudy, ob.WeatherStatus)
+}
+
+func TestValidateLocation(t *testing.T) {
+	t.Parallel()
+
+	ptr := func(f float64) *float64 { return &f }
+
+	type TestCase struct {
+		name     string
+		location models.Location
+		fields   map[string]string
+	}
+
+	tt := []TestCase{
+		{
+			name: "valid",
+			location: models.Location{
+				Name:      "Minsk",
+				Country:   "Belarus",
+				Latitude:  ptr(53.9),
+				Longitude: ptr(27.56),
+				Elevation: ptr(220),
+				Timezone:  "Europe/Minsk",
+			},
+		},
+		{
+			name:     "empty location",
+			location: models.Location{Timezone: "UTC"},
+			fields: map[string]string{
+				"name":    models.CodeRequired,
+				"country": models.CodeRequired,
+			},
+		},
+		{
+			name: "coordinates out of range",
+			location: models.Location{
+				Name:      "Minsk",
+				Country:   "Belarus",
+				Latitude:  ptr(91),
+				Longitude: ptr(-181),
+				Elevation: ptr(10000),
+				Timezone:  "UTC",
+			},
+			fields: map[string]string{
+				"latitude":  models.CodeOutOfRange,
+				"longitude": models.CodeOutOfRange,
+				"elevation": models.CodeOutOfRange,
+			},
+		},
+		{
+			name: "latitude without longitude and unknown timezone",
+			location: models.Location{
+				Name:     "Minsk",
+				Country:  "Belarus",
+				Latitude: ptr(53.9),
+				Timezone: "Mars/Olympus",
+			},
+			fields: map[string]string{
+				"longitude": models.CodeRequired,
+				"timezone":  models.CodeInvalidValue,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := service.ValidateLocation(&tc.location)
+			if len(tc.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *service.ValidationError
+			require.ErrorAs(t, err, &verr)
+
+			fields := make(map[string]string, len(verr.Fields))
+			for _, f := range verr.Fields {
+				fields[f.Field] = f.Code
+			}
+
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestNormalizeLocation(t *testing.T) {
+	t.Parallel()
+
+	loc := &models.Location{Name: " Minsk ", Country: "Belarus\t"}
+	service.NormalizeLocation(loc)
+
+	assert.Equal(t, "Minsk", loc.Name)
+	assert.Equal(t, "Belarus", loc.Country)
+	assert.Equal(t, "UTC", loc.Timezone)
+}
+
+func TestValidateForecast(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 1, 15, 0, 0, 0, time.UTC)
+	ptr := func(f float64) *float64 { return &f }
+
+	valid := func() *models.Forecast {
+		return &models.Forecast{
+			City:        "Minsk",
+			Country:     "Belarus",
+			Model:       "acme",
+			Granularity: models.BucketHour,
+			IssuedAt:    now.Add(-30 * time.Minute),
+			Points: []models.ForecastPoint{
+				{Time: now, Temperature: &models.Prediction{Value: 14, Lower: ptr(12), Upper: ptr(16)}},
+				{Time: now.Add(time.Hour), Humidity: &models.Prediction{Value: 70}},
+			},
+		}
+	}
+
+	type TestCase struct {
+		name   string
+		modify func(fc *models.Forecast)
+		fields map[string]string
+	}
+
+	tt := []TestCase{
+		{
+			name:   "valid",
+			modify: func(*models.Forecast) {},
+		},
+		{
+			name: "reserved model and missing run",
+			modify: func(fc *models.Forecast) {
+				fc.Model = "holt"
+				fc.Granularity = ""
+				fc.IssuedAt = time.Time{}
+				fc.Points = nil
+			},
+			fields: map[string]string{
+				"model":       models.CodeInvalidValue,
+				"granularity": models.CodeRequired,
+				"issued_at":   models.CodeRequired,
+				"points":      models.CodeRequired,
+			},
+		},
+		{
+			name: "location id instead of city",
+			modify: func(fc *models.Forecast) {
+				fc.City, fc.Country, fc.LocationID = "", "", 2
+			},
+		},
+		{
+			name: "invalid points",
+			modify: func(fc *models.Forecast) {
+				fc.Points[0].Time = now.Add(-2 * time.Hour)
+				fc.Points[0].Temperature.Lower = ptr(15)
+				fc.Points[1].Time = now.Add(90 * time.Minute)
+				fc.Points[1].Humidity = nil
+			},
+			fields: map[string]string{
+				"points[0].time":        models.CodeOutOfRange,
+				"points[0].temperature": models.CodeInvalidValue,
+				"points[1].time":        models.CodeInvalidValue,
+				"points[1]":             models.CodeRequired,
+			},
+		},
+		{
+			name: "duplicate point out of range",
+			modify: func(fc *models.Forecast) {
+				fc.Points[1].Time = now
+				fc.Points[1].Humidity.Value = 120
+			},
+			fields: map[string]string{
+				"points[1].time":           models.CodeInvalidValue,
+				"points[1].humidity.value": models.CodeOutOfRange,
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fc := valid()
+			tc.modify(fc)
+
+			err := service.ValidateForecast(fc, now)
+			if len(tc.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *service.ValidationError
+			require.ErrorAs(t, err, &verr)
+
+			fields := make(map[string]string, len(verr.Fields))
+			for _, f := range verr.Fields {
+				fields[f.Field] = f.Code
+			}
+
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
@@ -2,74 +2,300 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
 )
 
 //go:generate mockery --name WeatherRepo --structname MockWeatherRepo --filename mock_weather_repo_test.go --outpkg service_test --output .
 type WeatherRepo interface {
 	AddWeather(ctx context.Context, ob *models.Weather) (int, error)
+	AddWeathers(ctx context.Context, obs []*models.Weather) ([]int, error)
+	AddWeathersPartial(ctx context.Context, obs []*models.Weather) ([]int, []error, error)
 	GetWeather(ctx context.Context, id int) (*models.Weather, error)
 	UpdateWeather(ctx context.Context, ob *models.Weather) error
-	DeleteWeather(ctx context.Context, id int) (*models.Weather, error)
-	ListWeathers(ctx context.Context) ([]*models.Weather, error)
+	PatchWeather(
+		ctx context.Context,
+		id int,
+		version int,
+		patch *models.WeatherPatch,
+	) (*models.Weather, error)
+	DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error)
+	ListWeathers(ctx context.Context, filter models.WeatherFilter) ([]*models.Weather, error)
+	ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error)
+	ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error)
 }
 
+// IngestMetrics counts the observations stored per location. Locations are
+// counted by id, as resolved on write, and named by their stored city and
+// country rather than by the names clients send.
+type IngestMetrics interface {
+	ObservationsIngested(locationID int, city, country string, n int)
+}
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+	MaxBatchSize    = 10000
+	MaxRadiusKm     = 1000
+)
+
+var ErrBatchRejected = errors.New("batch rejected: some items are invalid")
+
 type WeatherService struct {
-	repo WeatherRepo
+	repo    WeatherRepo
+	metrics IngestMetrics
 }
 
 func NewWeatherService(repo WeatherRepo) *WeatherService {
 	return &WeatherService{repo: repo}
 }
 
+// SetMetrics makes the service count every observation it stores.
+func (s *WeatherService) SetMetrics(metrics IngestMetrics) {
+	s.metrics = metrics
+}
+
 func (s *WeatherService) AddWeather(
 	ctx context.Context,
 	ob *models.Weather,
 ) (int, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.AddWeather")
+	defer span.End()
+
+	NormalizeWeather(ob)
+
+	if err := ValidateWeather(ob, time.Now()); err != nil {
+		return 0, err
+	}
+
 	id, err := s.repo.AddWeather(ctx, ob)
 	if err != nil {
-		return 0, fmt.Errorf("failed to add weather: %w", err)
+		return 0, tracing.Fail(span, fmt.Errorf("failed to add weather: %w", err))
 	}
 
+	s.countIngested(ob)
+
 	return id, nil
 }
 
+// AddWeathers stores a batch of observations. Items that already carry an
+// error (e.g. from decoding) or fail validation are reported per index. In
+// atomic mode any failure rejects the whole batch with ErrBatchRejected; in
+// partial mode items the database rejects are reported per index as well.
+func (s *WeatherService) AddWeathers(
+	ctx context.Context,
+	items []*models.BatchItem,
+	mode models.BatchMode,
+) (*models.BatchResult, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.AddWeathers")
+	defer span.End()
+
+	result := &models.BatchResult{Items: make([]models.BatchItemResult, len(items))}
+
+	valid := make([]*models.Weather, 0, len(items))
+	positions := make([]int, 0, len(items))
+	now := time.Now()
+
+	for i, item := range items {
+		res := &result.Items[i]
+		res.Index = item.Index
+		res.Error = item.Error
+
+		if res.Error == "" {
+			NormalizeWeather(item.Weather)
+
+			var verr *ValidationError
+			if errors.As(ValidateWeather(item.Weather, now), &verr) {
+				res.Error = verr.Error()
+				res.Fields = verr.Fields
+			}
+		}
+
+		if res.Error != "" {
+			result.Failed++
+			continue
+		}
+
+		valid = append(valid, item.Weather)
+		positions = append(positions, i)
+	}
+
+	if result.Failed > 0 && mode != models.BatchPartial {
+		slog.InfoContext(ctx, "weather batch rejected", "mode", mode, "failed", result.Failed)
+
+		return result, ErrBatchRejected
+	}
+
+	if len(valid) == 0 {
+		return result, nil
+	}
+
+	var (
+		ids  []int
+		errs []error
+		err  error
+	)
+
+	if mode == models.BatchPartial {
+		ids, errs, err = s.repo.AddWeathersPartial(ctx, valid)
+	} else {
+		ids, err = s.repo.AddWeathers(ctx, valid)
+	}
+
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to add weathers: %w", err))
+	}
+
+	stored := make([]*models.Weather, 0, len(valid))
+
+	for i, id := range ids {
+		res := &result.Items[positions[i]]
+
+		if errs != nil && errs[i] != nil {
+			res.Error = errs[i].Error()
+			result.Failed++
+
+			continue
+		}
+
+		res.ID = id
+		stored = append(stored, valid[i])
+	}
+
+	result.Inserted = len(stored)
+
+	slog.InfoContext(ctx, "weather batch stored",
+		"mode", mode, "inserted", result.Inserted, "failed", result.Failed)
+
+	s.countIngested(stored...)
+
+	return result, nil
+}
+
 func (s *WeatherService) GetWeather(
 	ctx context.Context,
 	id int,
 ) (*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.GetWeather")
+	defer span.End()
+
 	ob, err := s.repo.GetWeather(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get weather: %w", err)
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather: %w", err))
 	}
 
 	return ob, nil
 }
 
+// UpdateWeather replaces the observation. A non-zero ob.Version must match the
+// stored one; on success ob.Version holds the new version.
 func (s *WeatherService) UpdateWeather(
 	ctx context.Context,
 	ob *models.Weather,
 ) error {
+	ctx, span := tracer.Start(ctx, "WeatherService.UpdateWeather")
+	defer span.End()
+
+	NormalizeWeather(ob)
+
+	if err := ValidateWeather(ob, time.Now()); err != nil {
+		return err
+	}
+
 	err := s.repo.UpdateWeather(ctx, ob)
 	if err != nil {
-		return fmt.Errorf("failed to update weather: %w", err)
+		return tracing.Fail(span, fmt.Errorf("failed to update weather: %w", err))
 	}
 
 	return nil
 }
 
+// PatchWeather applies a merge patch. The merged observation is validated as
+// a whole, but only the provided fields are written back. A non-zero version
+// must match the stored one.
+func (s *WeatherService) PatchWeather(
+	ctx context.Context,
+	id int,
+	version int,
+	patch *models.WeatherPatch,
+) (*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.PatchWeather")
+	defer span.End()
+
+	if nulls := patch.NullFields(); len(nulls) > 0 {
+		verr := &ValidationError{}
+		for _, field := range nulls {
+			verr.add(field, models.CodeRequired, "must not be null")
+		}
+
+		return nil, verr
+	}
+
+	current, err := s.repo.GetWeather(ctx, id)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather: %w", err))
+	}
+
+	if version != 0 && current.Version != version {
+		return nil, fmt.Errorf("failed to patch weather: %w", repository.ErrVersionConflict)
+	}
+
+	merged := *current
+	patch.Apply(&merged)
+	NormalizeWeather(&merged)
+
+	err = ValidateWeather(&merged, time.Now())
+	if !patch.WeatherStatus.Set {
+		// a status stored before the list of statuses existed is left alone
+		err = withoutField(err, "weather_status")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// write back the normalized form of the provided text fields; the location
+	// is resolved from city and country together, so either one brings the other
+	if patch.City.Set || patch.Country.Set {
+		patch.City = models.Optional[string]{Set: true, Value: merged.City}
+		patch.Country = models.Optional[string]{Set: true, Value: merged.Country}
+	}
+
+	patch.WeatherStatus.Value = merged.WeatherStatus
+
+	// coordinates are validated and stored as a pair as well
+	if patch.Latitude.Set || patch.Longitude.Set {
+		patch.Latitude = models.OptionalOf(merged.Latitude)
+		patch.Longitude = models.OptionalOf(merged.Longitude)
+	}
+
+	ob, err := s.repo.PatchWeather(ctx, id, version, patch)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to patch weather: %w", err))
+	}
+
+	return ob, nil
+}
+
 func (s *WeatherService) DeleteWeather(
 	ctx context.Context,
 	id int,
+	version int,
 ) (*models.Weather, error) {
-	ob, err := s.repo.DeleteWeather(ctx, id)
+	ctx, span := tracer.Start(ctx, "WeatherService.DeleteWeather")
+	defer span.End()
+
+	ob, err := s.repo.DeleteWeather(ctx, id, version)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"failed to delete weather: %w",
-			err,
-		)
+		return nil, tracing.Fail(span, fmt.Errorf("failed to delete weather: %w", err))
 	}
 
 	return ob, nil
@@ -77,11 +303,186 @@ func (s *WeatherService) DeleteWeather(
 
 func (s *WeatherService) ListWeathers(
 	ctx context.Context,
+	filter models.WeatherFilter,
+) (*models.WeatherPage, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.ListWeathers")
+	defer span.End()
+
+	if filter.SortBy == "" {
+		filter.SortBy = models.SortByTimestamp
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.SortDesc
+	}
+
+	limit := pageLimit(filter.Limit)
+
+	// one extra row tells whether there is a next page
+	filter.Limit = limit + 1
+
+	obList, err := s.repo.ListWeathers(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers: %w", err))
+	}
+
+	page := &models.WeatherPage{Items: obList}
+	if len(obList) > limit {
+		page.Items = obList[:limit]
+		page.Next = models.NewWeatherCursor(filter.SortBy, filter.Order, obList[limit-1])
+	}
+
+	return page, nil
+}
+
+// ListWeathersNear returns observations within filter.RadiusKm of a point,
+// nearest first, each carrying its distance. Observations without
+// coordinates are never matched.
+func (s *WeatherService) ListWeathersNear(
+	ctx context.Context,
+	filter models.NearFilter,
+) ([]*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.ListWeathersNear")
+	defer span.End()
+
+	filter.Limit = pageLimit(filter.Limit)
+
+	obList, err := s.repo.ListWeathersNear(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers near point: %w", err))
+	}
+
+	return obList, nil
+}
+
+// ListWeathersInBBox returns the newest observations inside a box.
+func (s *WeatherService) ListWeathersInBBox(
+	ctx context.Context,
+	filter models.BBoxFilter,
 ) ([]*models.Weather, error) {
-	obList, err := s.repo.ListWeathers(ctx)
+	ctx, span := tracer.Start(ctx, "WeatherService.ListWeathersInBBox")
+	defer span.End()
+
+	filter.Limit = pageLimit(filter.Limit)
+
+	obList, err := s.repo.ListWeathersInBBox(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list weathers: %w", err)
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list weathers in bbox: %w", err))
 	}
 
 	return obList, nil
 }
+
+// LatestWeathers returns the most recent observation of every location
+// matching the filter.
+func (s *WeatherService) LatestWeathers(
+	ctx context.Context,
+	filter models.LatestFilter,
+) ([]*models.Weather, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.LatestWeathers")
+	defer span.End()
+
+	obList, err := s.repo.LatestWeathers(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to list latest weathers: %w", err))
+	}
+
+	return obList, nil
+}
+
+// WeatherStats aggregates observations per location and period, daily by
+// default.
+func (s *WeatherService) WeatherStats(
+	ctx context.Context,
+	filter models.StatsFilter,
+) ([]*models.WeatherStats, error) {
+	ctx, span := tracer.Start(ctx, "WeatherService.WeatherStats")
+	defer span.End()
+
+	if filter.Bucket == "" {
+		filter.Bucket = models.BucketDay
+	}
+
+	stats, err := s.repo.WeatherStats(ctx, filter)
+	if err != nil {
+		return nil, tracing.Fail(span, fmt.Errorf("failed to get weather stats: %w", err))
+	}
+
+	return stats, nil
+}
+
+// ExportWeathers walks every observation matching the filter page by page and
+// passes it to fn, so callers can stream results without loading the whole
+// table. It defaults to chronological order.
+func (s *WeatherService) ExportWeathers(
+	ctx context.Context,
+	filter models.WeatherFilter,
+	fn func(ob *models.Weather) error,
+) error {
+	ctx, span := tracer.Start(ctx, "WeatherService.ExportWeathers")
+	defer span.End()
+
+	if filter.SortBy == "" {
+		filter.SortBy = models.SortByTimestamp
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.SortAsc
+	}
+
+	filter.Limit = MaxPageSize
+
+	for {
+		obList, err := s.repo.ListWeathers(ctx, filter)
+		if err != nil {
+			return tracing.Fail(span, fmt.Errorf("failed to list weathers: %w", err))
+		}
+
+		last := len(obList) < filter.Limit
+		if !last {
+			// fn may convert the observations in place, so the cursor is taken first
+			filter.After = models.NewWeatherCursor(filter.SortBy, filter.Order, obList[len(obList)-1])
+		}
+
+		for _, ob := range obList {
+			if err := fn(ob); err != nil {
+				return err
+			}
+		}
+
+		if last {
+			return nil
+		}
+	}
+}
+
+func (s *WeatherService) countIngested(obs ...*models.Weather) {
+	if s.metrics == nil {
+		return
+	}
+
+	type location struct {
+		id            int
+		city, country string
+	}
+
+	counts := make(map[location]int)
+	for _, ob := range obs {
+		counts[location{id: ob.LocationID, city: ob.City, country: ob.Country}]++
+	}
+
+	for loc, n := range counts {
+		s.metrics.ObservationsIngested(loc.id, loc.city, loc.country, n)
+	}
+}
+
+func pageLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultPageSize
+	case limit > MaxPageSize:
+		return MaxPageSize
+	}
+
+	return limit
+}
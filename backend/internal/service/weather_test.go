@@ -2,10 +2,14 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
 
 	"github.com/stretchr/testify/assert"
@@ -13,6 +17,20 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func validWeather() *models.Weather {
+	return &models.Weather{
+		Version:       1,
+		Timestamp:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		City:          "Minsk",
+		Country:       "Belarus",
+		Temperature:   12.5,
+		Humidity:      60,
+		Pressure:      1013,
+		WindSpeed:     3,
+		WeatherStatus: models.StatusClear,
+	}
+}
+
 func TestAddWeatherWithoutError(t *testing.T) {
 	t.Parallel()
 
@@ -45,7 +63,7 @@ func TestAddWeatherWithoutError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			id, err := srv.AddWeather(tc.ctx, &models.Weather{})
+			id, err := srv.AddWeather(tc.ctx, validWeather())
 			require.NoError(t, err)
 			assert.Equal(t, tc.id, id)
 		})
@@ -88,7 +106,7 @@ func TestAddWeatherWithError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			_, err := srv.AddWeather(tc.ctx, &models.Weather{})
+			_, err := srv.AddWeather(tc.ctx, validWeather())
 			require.EqualError(t, err, tc.err.Error())
 		})
 	}
@@ -223,7 +241,7 @@ func TestUpdateWeatherWithoutError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			err := srv.UpdateWeather(tc.ctx, &models.Weather{})
+			err := srv.UpdateWeather(tc.ctx, validWeather())
 			require.NoError(t, err)
 		})
 	}
@@ -265,7 +283,7 @@ func TestUpdateWeatherWithError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			err := srv.UpdateWeather(tc.ctx, &models.Weather{})
+			err := srv.UpdateWeather(tc.ctx, validWeather())
 			require.EqualError(t, err, tc.err.Error())
 		})
 	}
@@ -289,7 +307,7 @@ func TestDeleteWeatherWithoutError(t *testing.T) {
 				t.Helper()
 
 				repo := NewMockWeatherRepo(t)
-				repo.On("DeleteWeather", mock.Anything, 1).
+				repo.On("DeleteWeather", mock.Anything, 1, 2).
 					Return(&models.Weather{
 						Temperature:   30.0,
 						Humidity:      65.0,
@@ -321,7 +339,7 @@ func TestDeleteWeatherWithoutError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			ob, err := srv.DeleteWeather(tc.ctx, tc.id)
+			ob, err := srv.DeleteWeather(tc.ctx, tc.id, 2)
 			require.NoError(t, err)
 			assert.Equal(t, tc.ob, ob)
 		})
@@ -348,7 +366,7 @@ func TestDeleteWeatherWithError(t *testing.T) {
 				t.Helper()
 
 				repo := NewMockWeatherRepo(t)
-				repo.On("DeleteWeather", mock.Anything, 1).
+				repo.On("DeleteWeather", mock.Anything, 1, 2).
 					Return(&models.Weather{}, errDelete).
 					Once()
 
@@ -366,7 +384,7 @@ func TestDeleteWeatherWithError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			_, err := srv.DeleteWeather(tc.ctx, tc.id)
+			_, err := srv.DeleteWeather(tc.ctx, tc.id, 2)
 			require.EqualError(t, err, tc.err.Error())
 		})
 	}
@@ -379,75 +397,118 @@ func TestListWeathersWithoutError(t *testing.T) {
 		name        string
 		repoBuilder func(t *testing.T) service.WeatherRepo
 		ctx         context.Context
-		obList      []*models.Weather
+		filter      models.WeatherFilter
+		page        *models.WeatherPage
+	}
+
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	obList := []*models.Weather{
+		{
+			ID:            3,
+			Timestamp:     tm,
+			Temperature:   25.0,
+			Humidity:      60.0,
+			Pressure:      1013.0,
+			WeatherStatus: "Clear",
+			City:          "Berlin",
+			Country:       "Germany",
+		},
+		{
+			ID:            2,
+			Timestamp:     tm.Add(-time.Hour),
+			Temperature:   30.0,
+			Humidity:      65.0,
+			Pressure:      1015.0,
+			WeatherStatus: "Sunny",
+			City:          "Paris",
+			Country:       "France",
+		},
+		{
+			ID:            1,
+			Timestamp:     tm.Add(-2 * time.Hour),
+			Temperature:   28.0,
+			Humidity:      95.0,
+			Pressure:      1012.0,
+			WeatherStatus: "Rainy",
+			City:          "Singapore",
+			Country:       "Singapore",
+		},
 	}
 
 	tt := []TestCase{
 		{
-			name: "primary",
+			name: "defaults",
 			repoBuilder: func(t *testing.T) service.WeatherRepo {
 				t.Helper()
 
 				repo := NewMockWeatherRepo(t)
-				repo.On("ListWeathers", mock.Anything).
-					Return([]*models.Weather{
-						{
-							Temperature:   25.0,
-							Humidity:      60.0,
-							Pressure:      1013.0,
-							WeatherStatus: "Clear",
-							City:          "Berlin",
-							Country:       "Germany",
-						},
-						{
-							Temperature:   30.0,
-							Humidity:      65.0,
-							Pressure:      1015.0,
-							WeatherStatus: "Sunny",
-							City:          "Paris",
-							Country:       "France",
-						},
-						{
-							Temperature:   28.0,
-							Humidity:      95.0,
-							Pressure:      1012.0,
-							WeatherStatus: "Rainy",
-							City:          "Singapore",
-							Country:       "Singapore",
-						},
-					}, nil).
+				repo.On("ListWeathers", mock.Anything, models.WeatherFilter{
+					SortBy: models.SortByTimestamp,
+					Order:  models.SortDesc,
+					Limit:  service.DefaultPageSize + 1,
+				}).
+					Return(obList, nil).
+					Once()
+
+				return repo
+			},
+			ctx:    context.Background(),
+			filter: models.WeatherFilter{},
+			page:   &models.WeatherPage{Items: obList},
+		},
+		{
+			name: "has next page",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("ListWeathers", mock.Anything, models.WeatherFilter{
+					SortBy: models.SortByTemperature,
+					Order:  models.SortAsc,
+					Limit:  3,
+				}).
+					Return(obList, nil).
 					Once()
 
 				return repo
 			},
 			ctx: context.Background(),
-			obList: []*models.Weather{
-				{
-					Temperature:   25.0,
-					Humidity:      60.0,
-					Pressure:      1013.0,
-					WeatherStatus: "Clear",
-					City:          "Berlin",
-					Country:       "Germany",
-				},
-				{
-					Temperature:   30.0,
-					Humidity:      65.0,
-					Pressure:      1015.0,
-					WeatherStatus: "Sunny",
-					City:          "Paris",
-					Country:       "France",
-				},
-				{
-					Temperature:   28.0,
-					Humidity:      95.0,
-					Pressure:      1012.0,
-					WeatherStatus: "Rainy",
-					City:          "Singapore",
-					Country:       "Singapore",
+			filter: models.WeatherFilter{
+				SortBy: models.SortByTemperature,
+				Order:  models.SortAsc,
+				Limit:  2,
+			},
+			page: &models.WeatherPage{
+				Items: obList[:2],
+				Next: &models.WeatherCursor{
+					SortBy: models.SortByTemperature,
+					Order:  models.SortAsc,
+					ID:     2,
+					Value:  30.0,
 				},
 			},
 		},
+		{
+			name: "limit is capped",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("ListWeathers", mock.Anything, models.WeatherFilter{
+					SortBy: models.SortByTimestamp,
+					Order:  models.SortDesc,
+					Limit:  service.MaxPageSize + 1,
+				}).
+					Return(obList, nil).
+					Once()
+
+				return repo
+			},
+			ctx:    context.Background(),
+			filter: models.WeatherFilter{Limit: 100000},
+			page:   &models.WeatherPage{Items: obList},
+		},
 	}
 
 	for _, tc := range tt {
@@ -456,9 +517,9 @@ func TestListWeathersWithoutError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			obList, err := srv.ListWeathers(tc.ctx)
+			page, err := srv.ListWeathers(tc.ctx, tc.filter)
 			require.NoError(t, err)
-			assert.Equal(t, tc.obList, obList)
+			assert.Equal(t, tc.page, page)
 		})
 	}
 }
@@ -482,7 +543,7 @@ func TestListWeathersWithError(t *testing.T) {
 				t.Helper()
 
 				repo := NewMockWeatherRepo(t)
-				repo.On("ListWeathers", mock.Anything).Return(nil, errList).Once()
+				repo.On("ListWeathers", mock.Anything, mock.Anything).Return(nil, errList).Once()
 
 				return repo
 			},
@@ -497,8 +558,501 @@ func TestListWeathersWithError(t *testing.T) {
 
 			srv := service.NewWeatherService(tc.repoBuilder(t))
 
-			_, err := srv.ListWeathers(tc.ctx)
+			_, err := srv.ListWeathers(tc.ctx, models.WeatherFilter{})
 			require.EqualError(t, err, tc.err.Error())
 		})
 	}
 }
+
+func TestAddWeathers(t *testing.T) {
+	t.Parallel()
+
+	weatherIn := func(city string) *models.Weather {
+		ob := validWeather()
+		ob.City = city
+
+		return ob
+	}
+
+	newItems := func() []*models.BatchItem {
+		return []*models.BatchItem{
+			{Index: 0, Weather: weatherIn("Minsk")},
+			{Index: 1, Weather: weatherIn("")},
+			{Index: 2, Weather: &models.Weather{}, Error: "invalid JSON"},
+			{Index: 3, Weather: weatherIn("Brest")},
+		}
+	}
+
+	cityRequired := []models.FieldError{{Field: "city", Code: models.CodeRequired, Message: "is required"}}
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.WeatherRepo
+		items       []*models.BatchItem
+		mode        models.BatchMode
+		result      *models.BatchResult
+		err         error
+	}
+
+	tt := []TestCase{
+		{
+			name: "atomic rejects invalid batch",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				return NewMockWeatherRepo(t)
+			},
+			items: newItems(),
+			mode:  models.BatchAtomic,
+			result: &models.BatchResult{
+				Failed: 2,
+				Items: []models.BatchItemResult{
+					{Index: 0},
+					{Index: 1, Error: "invalid weather: city is required", Fields: cityRequired},
+					{Index: 2, Error: "invalid JSON"},
+					{Index: 3},
+				},
+			},
+			err: service.ErrBatchRejected,
+		},
+		{
+			name: "partial stores valid items",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("AddWeathersPartial", mock.Anything, mock.MatchedBy(func(obs []*models.Weather) bool {
+					return len(obs) == 2 && obs[0].City == "Minsk" && obs[1].City == "Brest"
+				})).Return([]int{10, 11}, make([]error, 2), nil).Once()
+
+				return repo
+			},
+			items: newItems(),
+			mode:  models.BatchPartial,
+			result: &models.BatchResult{
+				Inserted: 2,
+				Failed:   2,
+				Items: []models.BatchItemResult{
+					{Index: 0, ID: 10},
+					{Index: 1, Error: "invalid weather: city is required", Fields: cityRequired},
+					{Index: 2, Error: "invalid JSON"},
+					{Index: 3, ID: 11},
+				},
+			},
+		},
+		{
+			name: "partial reports items the database rejects",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("AddWeathersPartial", mock.Anything, mock.Anything).
+					Return([]int{0, 11}, []error{repository.ErrConflict, nil}, nil).
+					Once()
+
+				return repo
+			},
+			items: newItems(),
+			mode:  models.BatchPartial,
+			result: &models.BatchResult{
+				Inserted: 1,
+				Failed:   3,
+				Items: []models.BatchItemResult{
+					{Index: 0, Error: repository.ErrConflict.Error()},
+					{Index: 1, Error: "invalid weather: city is required", Fields: cityRequired},
+					{Index: 2, Error: "invalid JSON"},
+					{Index: 3, ID: 11},
+				},
+			},
+		},
+		{
+			name: "atomic stores valid batch",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("AddWeathers", mock.Anything, mock.Anything).Return([]int{5}, nil).Once()
+
+				return repo
+			},
+			items: []*models.BatchItem{
+				{Index: 0, Weather: weatherIn("Minsk")},
+			},
+			mode: models.BatchAtomic,
+			result: &models.BatchResult{
+				Inserted: 1,
+				Items:    []models.BatchItemResult{{Index: 0, ID: 5}},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := service.NewWeatherService(tc.repoBuilder(t))
+
+			result, err := srv.AddWeathers(context.Background(), tc.items, tc.mode)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.result, result)
+		})
+	}
+}
+
+type fakeIngestMetrics map[string]int
+
+func (m fakeIngestMetrics) ObservationsIngested(locationID int, city, country string, n int) {
+	m[fmt.Sprintf("%d %s, %s", locationID, city, country)] += n
+}
+
+func TestIngestMetrics(t *testing.T) {
+	t.Parallel()
+
+	// the repository resolves city names case-insensitively and writes back
+	// the stored ones
+	locations := map[string]models.Location{
+		"minsk": {ID: 3, Name: "Minsk", Country: "Belarus"},
+		"brest": {ID: 7, Name: "Brest", Country: "Belarus"},
+	}
+	resolve := func(obs ...*models.Weather) {
+		for _, ob := range obs {
+			loc := locations[strings.ToLower(ob.City)]
+			ob.LocationID, ob.City, ob.Country = loc.ID, loc.Name, loc.Country
+		}
+	}
+
+	weatherIn := func(city string) *models.Weather {
+		ob := validWeather()
+		ob.City = city
+
+		return ob
+	}
+
+	repo := NewMockWeatherRepo(t)
+	repo.On("AddWeather", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { resolve(args.Get(1).(*models.Weather)) }).
+		Return(1, nil).
+		Once()
+	repo.On("AddWeathers", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { resolve(args.Get(1).([]*models.Weather)...) }).
+		Return([]int{2, 3, 4}, nil).
+		Once()
+
+	metrics := fakeIngestMetrics{}
+
+	srv := service.NewWeatherService(repo)
+	srv.SetMetrics(metrics)
+
+	_, err := srv.AddWeather(context.Background(), weatherIn("Minsk"))
+	require.NoError(t, err)
+
+	_, err = srv.AddWeathers(context.Background(), []*models.BatchItem{
+		{Index: 0, Weather: weatherIn("minsk")},
+		{Index: 1, Weather: weatherIn("Brest")},
+		{Index: 2, Weather: weatherIn("MINSK")},
+	}, models.BatchAtomic)
+	require.NoError(t, err)
+
+	assert.Equal(t, fakeIngestMetrics{"3 Minsk, Belarus": 3, "7 Brest, Belarus": 1}, metrics)
+}
+
+func TestExportWeathers(t *testing.T) {
+	t.Parallel()
+
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	firstPage := make([]*models.Weather, service.MaxPageSize)
+	for i := range firstPage {
+		firstPage[i] = &models.Weather{ID: i + 1, Timestamp: tm.Add(time.Duration(i) * time.Minute)}
+	}
+
+	last := *firstPage[len(firstPage)-1]
+
+	repo := NewMockWeatherRepo(t)
+	repo.On("ListWeathers", mock.Anything, mock.MatchedBy(func(f models.WeatherFilter) bool {
+		return f.After == nil && f.Limit == service.MaxPageSize &&
+			f.SortBy == models.SortByTimestamp && f.Order == models.SortAsc
+	})).Return(firstPage, nil).Once()
+	repo.On("ListWeathers", mock.Anything, mock.MatchedBy(func(f models.WeatherFilter) bool {
+		return f.After != nil && f.After.ID == last.ID && f.After.Timestamp.Equal(last.Timestamp)
+	})).Return([]*models.Weather{{ID: 9999}}, nil).Once()
+
+	srv := service.NewWeatherService(repo)
+
+	var ids []int
+
+	err := srv.ExportWeathers(context.Background(), models.WeatherFilter{}, func(ob *models.Weather) error {
+		ids = append(ids, ob.ID)
+
+		// the callback may change the observation; the cursor must not follow
+		ob.Timestamp = time.Time{}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, ids, service.MaxPageSize+1)
+	assert.Equal(t, 9999, ids[len(ids)-1])
+}
+
+func TestListWeathersNear(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name          string
+		limit         int
+		expectedLimit int
+	}
+
+	tt := []TestCase{
+		{name: "default limit", limit: 0, expectedLimit: service.DefaultPageSize},
+		{name: "capped limit", limit: 10000, expectedLimit: service.MaxPageSize},
+		{name: "explicit limit", limit: 7, expectedLimit: 7},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := NewMockWeatherRepo(t)
+			repo.On("ListWeathersNear", mock.Anything, models.NearFilter{
+				Latitude:  53.9,
+				Longitude: 27.56,
+				RadiusKm:  10,
+				Limit:     tc.expectedLimit,
+			}).Return([]*models.Weather{{ID: 1}}, nil).Once()
+
+			srv := service.NewWeatherService(repo)
+
+			obList, err := srv.ListWeathersNear(context.Background(), models.NearFilter{
+				Latitude:  53.9,
+				Longitude: 27.56,
+				RadiusKm:  10,
+				Limit:     tc.limit,
+			})
+			require.NoError(t, err)
+			assert.Len(t, obList, 1)
+		})
+	}
+}
+
+func TestWeatherStatsDefaultsToDailyBuckets(t *testing.T) {
+	t.Parallel()
+
+	repo := NewMockWeatherRepo(t)
+	repo.On("WeatherStats", mock.Anything, models.StatsFilter{Bucket: models.BucketDay}).
+		Return([]*models.WeatherStats{{LocationID: 1, Count: 3}}, nil).
+		Once()
+
+	srv := service.NewWeatherService(repo)
+
+	stats, err := srv.WeatherStats(context.Background(), models.StatsFilter{})
+	require.NoError(t, err)
+	assert.Len(t, stats, 1)
+}
+
+func TestPatchWeather(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		repoBuilder func(t *testing.T) service.WeatherRepo
+		patch       string
+		checkErr    func(t *testing.T, err error)
+	}
+
+	tt := []TestCase{
+		{
+			name: "merges and normalizes",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(validWeather(), nil).Once()
+				repo.On("PatchWeather", mock.Anything, 4, 1, mock.MatchedBy(func(p *models.WeatherPatch) bool {
+					return p.Temperature.Set && p.Temperature.Value == 0 &&
+						p.City.Set && p.City.Value == "Brest" &&
+						p.Country.Set && p.Country.Value == "Belarus" &&
+						!p.Humidity.Set
+				})).Return(validWeather(), nil).Once()
+
+				return repo
+			},
+			patch: `{"temperature": 0, "city": "  Brest "}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "null member",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				return NewMockWeatherRepo(t)
+			},
+			patch: `{"humidity": null}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "humidity", verr.Fields[0].Field)
+				assert.Equal(t, models.CodeRequired, verr.Fields[0].Code)
+			},
+		},
+		{
+			name: "moves by location_id",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(validWeather(), nil).Once()
+				repo.On("PatchWeather", mock.Anything, 4, 1, mock.MatchedBy(func(p *models.WeatherPatch) bool {
+					return p.LocationID.Set && p.LocationID.Value == 7 && !p.City.Set && !p.Country.Set
+				})).Return(validWeather(), nil).Once()
+
+				return repo
+			},
+			patch: `{"location_id": 7}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "null location_id",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				return NewMockWeatherRepo(t)
+			},
+			patch: `{"location_id": null}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "location_id", verr.Fields[0].Field)
+				assert.Equal(t, models.CodeRequired, verr.Fields[0].Code)
+			},
+		},
+		{
+			name: "negative location_id",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(validWeather(), nil).Once()
+
+				return repo
+			},
+			patch: `{"location_id": -1}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "location_id", verr.Fields[0].Field)
+			},
+		},
+		{
+			name: "merged result is invalid",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(validWeather(), nil).Once()
+
+				return repo
+			},
+			patch: `{"humidity": 140}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "humidity", verr.Fields[0].Field)
+			},
+		},
+		{
+			name: "keeps a legacy status",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				stored := validWeather()
+				stored.WeatherStatus = "light_breeze"
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(stored, nil).Once()
+				repo.On("PatchWeather", mock.Anything, 4, 1, mock.MatchedBy(func(p *models.WeatherPatch) bool {
+					return p.Humidity.Set && !p.WeatherStatus.Set
+				})).Return(stored, nil).Once()
+
+				return repo
+			},
+			patch: `{"humidity": 40}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "unknown status",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(validWeather(), nil).Once()
+
+				return repo
+			},
+			patch: `{"weather_status": "light breeze"}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+
+				var verr *service.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, "weather_status", verr.Fields[0].Field)
+				assert.Equal(t, models.CodeInvalidValue, verr.Fields[0].Code)
+			},
+		},
+		{
+			name: "stale version",
+			repoBuilder: func(t *testing.T) service.WeatherRepo {
+				t.Helper()
+
+				stored := validWeather()
+				stored.Version = 2
+
+				repo := NewMockWeatherRepo(t)
+				repo.On("GetWeather", mock.Anything, 4).Return(stored, nil).Once()
+
+				return repo
+			},
+			patch: `{"humidity": 40}`,
+			checkErr: func(t *testing.T, err error) {
+				t.Helper()
+				require.ErrorIs(t, err, repository.ErrVersionConflict)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var patch models.WeatherPatch
+			require.NoError(t, json.Unmarshal([]byte(tc.patch), &patch))
+
+			srv := service.NewWeatherService(tc.repoBuilder(t))
+
+			_, err := srv.PatchWeather(context.Background(), 4, 1, &patch)
+			tc.checkErr(t, err)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Package tracing sets up OpenTelemetry tracing: the global tracer provider
+// with the configured exporter, W3C trace-context propagation and the echo
+// middleware that starts a server span per request.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Exporter string
+
+const (
+	ExporterNone   Exporter = "none"
+	ExporterStdout Exporter = "stdout"
+	ExporterOTLP   Exporter = "otlp"
+)
+
+var ErrUnsupportedExporter = errors.New("unsupported trace exporter")
+
+const tracerName = "github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+
+// TracingConfig selects the exporter. OTLP exports over HTTP to OTLPEndpoint,
+// or to the endpoint of the standard OTEL_EXPORTER_OTLP_* variables if empty.
+type TracingConfig struct {
+	TraceExporter    Exporter `env:"TRACE_EXPORTER"     env-default:"none"`
+	OTLPEndpoint     string   `env:"OTLP_ENDPOINT"`
+	TraceSampleRatio float64  `env:"TRACE_SAMPLE_RATIO" env-default:"1"`
+	ServiceName      string   `env:"OTEL_SERVICE_NAME"  env-default:"weather-forecast"`
+}
+
+// Setup installs the global propagator and tracer provider. The returned
+// function flushes the spans still buffered and has to be called on exit.
+// With ExporterNone spans are still propagated but never recorded.
+func Setup(ctx context.Context, cfg TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch cfg.TraceExporter {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+		}
+
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedExporter, cfg.TraceExporter)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", cfg.TraceExporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TraceSampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+var (
+	memoryOnce     sync.Once
+	memoryExporter *tracetest.InMemoryExporter
+)
+
+// InMemory installs a tracer provider that keeps every span in memory as soon
+// as it ends, and returns its exporter emptied. Tracers bind to the first
+// global provider for good, so the provider is installed once per process;
+// tests using it must not run in parallel with each other.
+func InMemory() *tracetest.InMemoryExporter {
+	memoryOnce.Do(func() {
+		memoryExporter = tracetest.NewInMemoryExporter()
+
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(memoryExporter)))
+	})
+
+	memoryExporter.Reset()
+
+	return memoryExporter
+}
+
+// expected is implemented by errors that are a normal outcome of an operation
+// rather than a failure, such as a lookup that finds nothing.
+type expected interface {
+	Expected() bool
+}
+
+// Fail marks the span as failed with err and returns err. Expected errors are
+// returned as they are and leave the span alone.
+func Fail(span trace.Span, err error) error {
+	var exp expected
+	if errors.As(err, &exp) && exp.Expected() {
+		return err
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	return err
+}
+
+// StatusFunc resolves the status code the response is sent with once the
+// handler returned err.
+type StatusFunc func(c echo.Context, err error) int
+
+// Middleware starts a server span for every request, continuing the trace of
+// the caller if the request carries a traceparent header, and puts it into
+// the request context. The handler error is recorded on the span and returned
+// as is; only server errors fail the span.
+func Middleware(status StatusFunc) echo.MiddlewareFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := c.Path()
+
+			ctx, span := tracer.Start(ctx, req.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			code := status(c, err)
+			span.SetAttributes(semconv.HTTPResponseStatusCode(code))
+
+			if code >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(code))
+			}
+
+			return err
+		}
+	}
+}
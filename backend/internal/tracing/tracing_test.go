@@ -0,0 +1,157 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeDatabase implements the listing only; any other call panics.
+type fakeDatabase struct {
+	repository.Database
+	err error
+}
+
+func (db fakeDatabase) ListWeathers(context.Context, models.WeatherFilter) ([]*models.Weather, error) {
+	return nil, db.err
+}
+
+func serve(t *testing.T, db repository.Database, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	e.HTTPErrorHandler = apierror.Handler
+	e.Use(tracing.Middleware(apierror.Status))
+	e.GET("/weathers", weather.ListWeathersHandler(
+		service.NewWeatherService(repository.NewWeatherRepository(db)),
+	))
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func spanByName(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+
+	for _, span := range spans {
+		if span.Name == name {
+			return span
+		}
+	}
+
+	require.Failf(t, "span not found", "no span named %q", name)
+
+	return tracetest.SpanStub{}
+}
+
+// The tests below install the global tracer provider and do not run in
+// parallel.
+
+func TestMiddlewareSpansAcrossLayers(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	req := httptest.NewRequest(http.MethodGet, "/weathers?limit=5", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+
+	rec := serve(t, fakeDatabase{}, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+
+	server := spanByName(t, spans, "GET /weathers")
+	svc := spanByName(t, spans, "WeatherService.ListWeathers")
+	repo := spanByName(t, spans, "WeatherRepository.ListWeathers")
+
+	assert.Equal(t, traceID, server.SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+	assert.Contains(t, server.Attributes, semconv.HTTPRoute("/weathers"))
+	assert.Contains(t, server.Attributes, semconv.HTTPResponseStatusCode(http.StatusOK))
+
+	assert.Equal(t, server.SpanContext.SpanID(), svc.Parent.SpanID())
+	assert.Equal(t, svc.SpanContext.SpanID(), repo.Parent.SpanID())
+}
+
+func TestMiddlewareRecordsFailures(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	req := httptest.NewRequest(http.MethodGet, "/weathers", nil)
+
+	rec := serve(t, fakeDatabase{err: errors.New("connection reset")}, req)
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	spans := exporter.GetSpans()
+
+	server := spanByName(t, spans, "GET /weathers")
+	assert.Equal(t, codes.Error, server.Status.Code)
+	assert.Contains(t, server.Attributes, semconv.HTTPResponseStatusCode(http.StatusInternalServerError))
+	require.Len(t, server.Events, 1)
+	assert.Equal(t, "exception", server.Events[0].Name)
+
+	repo := spanByName(t, spans, "WeatherRepository.ListWeathers")
+	assert.Equal(t, codes.Error, repo.Status.Code)
+	assert.Equal(t, "failed to list weathers: connection reset", repo.Status.Description)
+}
+
+func TestMiddlewareClientErrors(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	req := httptest.NewRequest(http.MethodGet, "/weathers?limit=-1", nil)
+
+	rec := serve(t, fakeDatabase{}, req)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	server := spanByName(t, exporter.GetSpans(), "GET /weathers")
+	assert.Equal(t, codes.Unset, server.Status.Code)
+	assert.Contains(t, server.Attributes, semconv.HTTPResponseStatusCode(http.StatusBadRequest))
+}
+
+func TestFailSkipsNotFound(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	_, span := otel.Tracer("test").Start(context.Background(), "lookup")
+	err := tracing.Fail(span, fmt.Errorf("failed to get user: %w", repository.NewErrNotFound(1)))
+	span.End()
+
+	assert.ErrorAs(t, err, &repository.ErrNotFound{})
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Empty(t, spans[0].Events)
+}
+
+func TestSetup(t *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), tracing.TracingConfig{
+		TraceExporter: tracing.ExporterNone,
+	})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = tracing.Setup(context.Background(), tracing.TracingConfig{TraceExporter: "zipkin"})
+	assert.ErrorIs(t, err, tracing.ErrUnsupportedExporter)
+}
@@ -2,9 +2,23 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
 
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/logging"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apikey"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/forecast"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/health"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/location"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/user"
 	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
 
 	"github.com/labstack/echo/v4"
@@ -12,32 +26,248 @@ import (
 
 type WeatherService interface {
 	AddWeather(ctx context.Context, ob *models.Weather) (int, error)
+	AddWeathers(
+		ctx context.Context,
+		items []*models.BatchItem,
+		mode models.BatchMode,
+	) (*models.BatchResult, error)
 	GetWeather(ctx context.Context, id int) (*models.Weather, error)
 	UpdateWeather(ctx context.Context, ob *models.Weather) error
-	DeleteWeather(ctx context.Context, id int) (*models.Weather, error)
-	ListWeathers(ctx context.Context) ([]*models.Weather, error)
+	PatchWeather(
+		ctx context.Context,
+		id int,
+		version int,
+		patch *models.WeatherPatch,
+	) (*models.Weather, error)
+	DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error)
+	ListWeathers(ctx context.Context, filter models.WeatherFilter) (*models.WeatherPage, error)
+	ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error)
+	ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error)
+	ExportWeathers(
+		ctx context.Context,
+		filter models.WeatherFilter,
+		fn func(ob *models.Weather) error,
+	) error
+}
+
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (*models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	ParseAccessToken(token string) (*models.Principal, error)
+}
+
+type UserService interface {
+	CreateUser(ctx context.Context, username, password string, roles []models.Role) (int, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	SetUserRoles(ctx context.Context, userID int, roles []models.Role) error
+	ListRoles(ctx context.Context) ([]*models.RoleDefinition, error)
+}
+
+type APIKeyService interface {
+	CreateAPIKey(
+		ctx context.Context,
+		createdBy int,
+		name string,
+		cities []string,
+		permissions []models.Permission,
+	) (*models.IssuedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int) (*models.IssuedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.Principal, error)
+}
+
+type LocationService interface {
+	CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	ListLocations(ctx context.Context) ([]*models.Location, error)
+	UpdateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id int) error
+}
+
+type ForecastService interface {
+	Forecast(ctx context.Context, req models.ForecastRequest) (*models.Forecast, error)
+	UploadForecast(ctx context.Context, fc *models.Forecast) error
+	ForecastAccuracy(ctx context.Context, filter models.AccuracyFilter) (*models.AccuracyReport, error)
+}
+
+type HealthService interface {
+	Ready(ctx context.Context) *models.HealthReport
+	Drain()
+}
+
+type Metrics interface {
+	Middleware() echo.MiddlewareFunc
+	Handler() http.Handler
+}
+
+type ServerConfig struct {
+	RESTServerPort    int           `env:"REST_SERVER_PORT"    env-default:"8080"`
+	ReadHeaderTimeout time.Duration `env:"READ_HEADER_TIMEOUT" env-default:"5s"`
+	ReadTimeout       time.Duration `env:"READ_TIMEOUT"        env-default:"30s"`
+	WriteTimeout      time.Duration `env:"WRITE_TIMEOUT"       env-default:"2m"`
+	// ExportWriteTimeout replaces WriteTimeout for the streamed CSV export,
+	// which may take far longer to write than any other response.
+	ExportWriteTimeout time.Duration `env:"EXPORT_WRITE_TIMEOUT" env-default:"30m"`
+	IdleTimeout        time.Duration `env:"IDLE_TIMEOUT"         env-default:"2m"`
+	// DrainDelay is how long the server keeps serving after failing readiness,
+	// so that load balancers stop routing to it before it stops listening.
+	DrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" env-default:"5s"`
+	// DrainTimeout bounds draining the in-flight requests; the rest of the
+	// teardown has to fit in it as well.
+	DrainTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"15s"`
+	// MetricsServerPort serves /metrics on a listener of its own, so that it
+	// can be kept out of reach of API clients.
+	MetricsServerPort int `env:"METRICS_SERVER_PORT" env-default:"9090"`
 }
 
 type Server struct {
-	restServer  *echo.Echo
-	restAddress string
+	restServer    *echo.Echo
+	restAddress   string
+	metricsServer *http.Server
+	drainDelay    time.Duration
+	drainTimeout  time.Duration
+	health        HealthService
 }
 
-func New(ctx context.Context, restPort int, weatherService WeatherService) *Server {
+func New(
+	ctx context.Context,
+	cfg ServerConfig,
+	weatherService WeatherService,
+	authService AuthService,
+	userService UserService,
+	apiKeyService APIKeyService,
+	locationService LocationService,
+	forecastService ForecastService,
+	healthService HealthService,
+	metrics Metrics,
+	logger *slog.Logger,
+) *Server {
 	httpSever := echo.New()
-	weather.RegisterWeatherRoutes(ctx, httpSever, weatherService)
+	httpSever.HideBanner = true
+	httpSever.HidePort = true
+	httpSever.HTTPErrorHandler = apierror.Handler
+	httpSever.Server.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+	httpSever.Server.ReadTimeout = cfg.ReadTimeout
+	httpSever.Server.WriteTimeout = cfg.WriteTimeout
+	httpSever.Server.IdleTimeout = cfg.IdleTimeout
+	httpSever.Use(logging.Middleware(logger), tracing.Middleware(apierror.Status), metrics.Middleware())
+	httpSever.Use(writeTimeout(cfg.ExportWriteTimeout, "/weathers/export"))
+
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("GET /metrics", metrics.Handler())
+
+	authenticate := auth.Middleware(authService, apiKeyService)
+
+	auth.RegisterAuthRoutes(ctx, httpSever, authService)
+	user.RegisterUserRoutes(ctx, httpSever, userService, authenticate)
+	apikey.RegisterAPIKeyRoutes(ctx, httpSever, apiKeyService, authenticate)
+	location.RegisterLocationRoutes(ctx, httpSever, locationService, authenticate)
+	weather.RegisterWeatherRoutes(ctx, httpSever, weatherService, authenticate)
+	forecast.RegisterForecastRoutes(ctx, httpSever, forecastService, authenticate)
+	health.RegisterHealthRoutes(ctx, httpSever, healthService)
 
 	return &Server{
 		restServer:  httpSever,
-		restAddress: fmt.Sprintf(":%d", restPort),
+		restAddress: fmt.Sprintf(":%d", cfg.RESTServerPort),
+		metricsServer: &http.Server{
+			Addr:              fmt.Sprintf(":%d", cfg.MetricsServerPort),
+			Handler:           metricsMux,
+			ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		},
+		drainDelay:   cfg.DrainDelay,
+		drainTimeout: cfg.DrainTimeout,
+		health:       healthService,
 	}
 }
 
+// writeTimeout moves the write deadline of requests to the given routes to
+// timeout from the start of the request.
+func writeTimeout(timeout time.Duration, routes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !slices.Contains(routes, c.Path()) {
+				return next(c)
+			}
+
+			rc := http.NewResponseController(c.Response())
+
+			err := rc.SetWriteDeadline(time.Now().Add(timeout))
+			if err != nil && !errors.Is(err, http.ErrNotSupported) {
+				return fmt.Errorf("failed to set write deadline: %w", err)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Start serves the API and the metrics until either server fails or both are
+// shut down; a shutdown is not an error.
 func (s *Server) Start() error {
-	s.restServer.Logger.Infof("starting rest server at address=%q", s.restAddress)
+	errCh := make(chan error, 2)
+
+	go func() {
+		slog.Info("starting metrics server", "address", s.metricsServer.Addr)
+		errCh <- serveError("metrics", s.metricsServer.ListenAndServe())
+	}()
+
+	go func() {
+		slog.Info("starting rest server", "address", s.restAddress)
+		errCh <- serveError("rest", s.restServer.Start(s.restAddress))
+	}()
+
+	err := <-errCh
+	if err != nil {
+		// a server that fails takes the other one down with it
+		_ = s.restServer.Close()
+		_ = s.metricsServer.Close()
+	}
+
+	return errors.Join(err, <-errCh)
+}
+
+// serveError drops the error a server returns once it is shut down.
+func serveError(name string, err error) error {
+	if err == nil || errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return fmt.Errorf("failed to start %s server: %w", name, err)
+}
+
+// Shutdown fails readiness and keeps serving for the drain delay. Then it
+// stops accepting connections, waits for in-flight requests to finish for at
+// most the drain timeout and closes the remaining connections. The metrics
+// server stops last.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Drain()
+
+	delay := time.NewTimer(s.drainDelay)
+	defer delay.Stop()
+
+	select {
+	case <-delay.C:
+	case <-ctx.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.drainTimeout)
+	defer cancel()
+
+	if err := s.restServer.Shutdown(ctx); err != nil {
+		_ = s.restServer.Close()
+		_ = s.metricsServer.Close()
+
+		return fmt.Errorf("failed to drain rest server: %w", err)
+	}
 
-	if err := s.restServer.Start(s.restAddress); err != nil {
-		return fmt.Errorf("failed to start rest server: %w", err)
+	// the metrics stay available until the requests are drained
+	if err := s.metricsServer.Shutdown(ctx); err != nil {
+		_ = s.metricsServer.Close()
+		return fmt.Errorf("failed to stop metrics server: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,137 @@
+// Package units converts between the canonical metric units the service
+// stores (°C, hPa, m/s, km) and the unit systems clients may ask for.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type System string
+
+const (
+	// Metric is the canonical system: °C, hPa, m/s and km.
+	Metric System = "metric"
+	// Imperial uses °F, inHg, mph and mi.
+	Imperial System = "imperial"
+	// SI uses K, Pa, m/s and m.
+	SI System = "si"
+)
+
+var Systems = []System{Metric, Imperial, SI}
+
+const (
+	kelvinOffset   = 273.15
+	hPaPerInHg     = 33.8638866667
+	metersPerMile  = 1609.344
+	metersPerKm    = 1000
+	secondsPerHour = 3600
+	// precision drops the float noise conversions leave behind
+	precision = 1e6
+)
+
+var ErrUnsupported = errors.New("unsupported units")
+
+// Parse returns the system named by value, ignoring case.
+func Parse(value string) (System, error) {
+	switch s := System(strings.ToLower(strings.TrimSpace(value))); s {
+	case Metric, Imperial, SI:
+		return s, nil
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnsupported, value)
+	}
+}
+
+// Temperature converts a temperature in °C to s.
+func (s System) Temperature(celsius float64) float64 {
+	switch s {
+	case Imperial:
+		return round(celsius*9/5 + 32)
+	case SI:
+		return round(celsius + kelvinOffset)
+	default:
+		return celsius
+	}
+}
+
+// TemperatureDelta converts a temperature difference, such as a standard
+// deviation, in °C to s.
+func (s System) TemperatureDelta(celsius float64) float64 {
+	if s == Imperial {
+		return round(celsius * 9 / 5)
+	}
+
+	return celsius
+}
+
+// Pressure converts a pressure in hPa to s.
+func (s System) Pressure(hPa float64) float64 {
+	switch s {
+	case Imperial:
+		return round(hPa / hPaPerInHg)
+	case SI:
+		return round(hPa * 100)
+	default:
+		return hPa
+	}
+}
+
+// WindSpeed converts a speed in m/s to s.
+func (s System) WindSpeed(mps float64) float64 {
+	if s == Imperial {
+		return round(mps * secondsPerHour / metersPerMile)
+	}
+
+	return mps
+}
+
+// Distance converts a distance in km to s.
+func (s System) Distance(km float64) float64 {
+	switch s {
+	case Imperial:
+		return round(km * metersPerKm / metersPerMile)
+	case SI:
+		return round(km * metersPerKm)
+	default:
+		return km
+	}
+}
+
+// MetricTemperature converts a temperature given in s to °C.
+func (s System) MetricTemperature(value float64) float64 {
+	switch s {
+	case Imperial:
+		return round((value - 32) * 5 / 9)
+	case SI:
+		return round(value - kelvinOffset)
+	default:
+		return value
+	}
+}
+
+// MetricPressure converts a pressure given in s to hPa.
+func (s System) MetricPressure(value float64) float64 {
+	switch s {
+	case Imperial:
+		return round(value * hPaPerInHg)
+	case SI:
+		return round(value / 100)
+	default:
+		return value
+	}
+}
+
+// MetricWindSpeed converts a speed given in s to m/s.
+func (s System) MetricWindSpeed(value float64) float64 {
+	if s == Imperial {
+		return round(value * metersPerMile / secondsPerHour)
+	}
+
+	return value
+}
+
+func round(value float64) float64 {
+	return math.Round(value*precision) / precision
+}
@@ -0,0 +1,64 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	s, err := units.Parse(" Imperial ")
+	require.NoError(t, err)
+	assert.Equal(t, units.Imperial, s)
+
+	_, err = units.Parse("nautical")
+	require.ErrorIs(t, err, units.ErrUnsupported)
+}
+
+func TestConversions(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		system      units.System
+		temperature float64
+		delta       float64
+		pressure    float64
+		windSpeed   float64
+		distance    float64
+	}
+
+	// 25°C, a 2°C spread, 1013.25 hPa, 10 m/s and 5 km
+	tt := []TestCase{
+		{system: units.Metric, temperature: 25, delta: 2, pressure: 1013.25, windSpeed: 10, distance: 5},
+		{
+			system:      units.Imperial,
+			temperature: 77,
+			delta:       3.6,
+			pressure:    29.921255,
+			windSpeed:   22.369363,
+			distance:    3.106856,
+		},
+		{system: units.SI, temperature: 298.15, delta: 2, pressure: 101325, windSpeed: 10, distance: 5000},
+	}
+
+	for _, tc := range tt {
+		t.Run(string(tc.system), func(t *testing.T) {
+			t.Parallel()
+
+			assert.InDelta(t, tc.temperature, tc.system.Temperature(25), 1e-6)
+			assert.InDelta(t, tc.delta, tc.system.TemperatureDelta(2), 1e-6)
+			assert.InDelta(t, tc.pressure, tc.system.Pressure(1013.25), 1e-6)
+			assert.InDelta(t, tc.windSpeed, tc.system.WindSpeed(10), 1e-6)
+			assert.InDelta(t, tc.distance, tc.system.Distance(5), 1e-6)
+
+			assert.InDelta(t, 25, tc.system.MetricTemperature(tc.temperature), 1e-6)
+			assert.InDelta(t, 1013.25, tc.system.MetricPressure(tc.pressure), 1e-3)
+			assert.InDelta(t, 10, tc.system.MetricWindSpeed(tc.windSpeed), 1e-6)
+		})
+	}
+}
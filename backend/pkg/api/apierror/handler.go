@@ -0,0 +1,94 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+const msgUnavailable = "The server is temporarily unavailable, please try again later"
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+type EchoValidationError struct {
+	Msg    string              `json:"message"`
+	Errors []models.FieldError `json:"errors"`
+}
+
+// Handler is the echo HTTPErrorHandler. Handlers return service and
+// repository errors as is and Handler maps their kind to a status code.
+func Handler(err error, c echo.Context) {
+	status, body := resolve(err)
+
+	// a streamed response may fail halfway, when the status is already sent
+	if c.Response().Committed {
+		return
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSONPretty(status, body, "\t")
+	}
+
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), "failed to write error response", "error", err)
+	}
+}
+
+// Status returns the status code of a request that failed with err, the one
+// Handler responds with or the one already sent. Middlewares use it to learn
+// the status before the error reaches Handler.
+func Status(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+
+	status, _ := resolve(err)
+
+	return status
+}
+
+func resolve(err error) (int, any) {
+	var (
+		httpErr  *echo.HTTPError
+		verr     *service.ValidationError
+		notFound repository.ErrNotFound
+	)
+
+	switch {
+	case errors.As(err, &httpErr):
+		msg, ok := httpErr.Message.(string)
+		if !ok {
+			msg = fmt.Sprint(httpErr.Message)
+		}
+
+		return httpErr.Code, EchoMessage{Msg: msg}
+	case errors.As(err, &verr):
+		return http.StatusUnprocessableEntity, EchoValidationError{
+			Msg:    "validation failed",
+			Errors: verr.Fields,
+		}
+	case errors.As(err, &notFound):
+		return http.StatusNotFound, EchoMessage{Msg: notFound.Error()}
+	case errors.Is(err, repository.ErrVersionConflict):
+		return http.StatusPreconditionFailed, EchoMessage{Msg: "record has been modified"}
+	case errors.Is(err, repository.ErrConflict):
+		return http.StatusConflict, EchoMessage{Msg: repository.ErrConflict.Error()}
+	case errors.Is(err, repository.ErrInvalid):
+		return http.StatusUnprocessableEntity, EchoMessage{Msg: repository.ErrInvalid.Error()}
+	case errors.Is(err, repository.ErrUnavailable):
+		return http.StatusServiceUnavailable, EchoMessage{Msg: msgUnavailable}
+	}
+
+	return http.StatusInternalServerError, EchoMessage{Msg: msgUnavailable}
+}
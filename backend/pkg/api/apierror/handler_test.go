@@ -0,0 +1,121 @@
+package apierror_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		err                error
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:               "Not found",
+			err:                fmt.Errorf("failed to get weather: %w", repository.NewErrNotFound(7)),
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "no record with id=7"}`,
+		},
+		{
+			name: "Validation",
+			err: &service.ValidationError{Fields: []models.FieldError{
+				{Field: "city", Code: models.CodeRequired, Message: "must not be empty"},
+			}},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: `{
+				"message": "validation failed",
+				"errors": [{"field": "city", "code": "required", "message": "must not be empty"}]
+			}`,
+		},
+		{
+			name:               "Version conflict",
+			err:                fmt.Errorf("failed to update weather: %w", repository.ErrVersionConflict),
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedResponse:   `{"message": "record has been modified"}`,
+		},
+		{
+			name:               "Conflict",
+			err:                fmt.Errorf("failed to add user: %w: duplicate key", repository.ErrConflict),
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"message": "record conflicts with existing data"}`,
+		},
+		{
+			name:               "Invalid",
+			err:                fmt.Errorf("failed to add weather: %w: out of range", repository.ErrInvalid),
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"message": "record violates a data constraint"}`,
+		},
+		{
+			name:               "Unavailable",
+			err:                fmt.Errorf("failed to list weathers: %w: bad conn", repository.ErrUnavailable),
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse: `{
+				"message": "The server is temporarily unavailable, please try again later"
+			}`,
+		},
+		{
+			name:               "Echo error",
+			err:                echo.ErrMethodNotAllowed,
+			expectedStatusCode: http.StatusMethodNotAllowed,
+			expectedResponse:   `{"message": "Method Not Allowed"}`,
+		},
+		{
+			name:               "Unknown error",
+			err:                errors.New("boom"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse: `{
+				"message": "The server is temporarily unavailable, please try again later"
+			}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weather/7", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			apierror.Handler(tc.err, c)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestHandlerCommittedResponse(t *testing.T) {
+	t.Parallel()
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/weathers/export", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	c.Response().WriteHeader(http.StatusOK)
+	_, _ = c.Response().Write([]byte("timestamp,city\n"))
+
+	apierror.Handler(errors.New("connection reset"), c)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "timestamp,city\n", rec.Body.String())
+}
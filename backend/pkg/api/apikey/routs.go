@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string              `json:"name"`
+	Cities      []string            `json:"cities"`
+	Permissions []models.Permission `json:"permissions"`
+}
+
+//go:generate mockery --name APIKeyService --structname MockAPIKeyService --filename mock_api_key_service_test.go --outpkg apikey_test --output .
+type APIKeyService interface {
+	CreateAPIKey(
+		ctx context.Context,
+		createdBy int,
+		name string,
+		cities []string,
+		permissions []models.Permission,
+	) (*models.IssuedAPIKey, error)
+	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int) (*models.IssuedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+}
+
+func RegisterAPIKeyRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	apiKeyService APIKeyService,
+	authenticate echo.MiddlewareFunc,
+) {
+	manage := []models.Permission{models.PermissionAPIKeysManage}
+
+	auth.RegisterRoutes(server, authenticate,
+		auth.Route{
+			Method:      http.MethodGet,
+			Path:        "/api-keys",
+			Handler:     ListAPIKeysHandler(apiKeyService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/api-keys",
+			Handler:     CreateAPIKeyHandler(apiKeyService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/api-keys/:id/rotate",
+			Handler:     RotateAPIKeyHandler(apiKeyService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodDelete,
+			Path:        "/api-keys/:id",
+			Handler:     RevokeAPIKeyHandler(apiKeyService),
+			Permissions: manage,
+		},
+	)
+}
+
+func ListAPIKeysHandler(apiKeyService APIKeyService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		keys, err := apiKeyService.ListAPIKeys(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, keys, "\t")
+	}
+}
+
+func CreateAPIKeyHandler(apiKeyService APIKeyService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req CreateAPIKeyRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		var createdBy int
+		if principal, ok := auth.PrincipalFromContext(c.Request().Context()); ok {
+			createdBy = principal.UserID
+		}
+
+		key, err := apiKeyService.CreateAPIKey(
+			c.Request().Context(),
+			createdBy,
+			req.Name,
+			req.Cities,
+			req.Permissions,
+		)
+		if err != nil {
+			return apiKeyError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusCreated, key, "\t")
+	}
+}
+
+func RotateAPIKeyHandler(apiKeyService APIKeyService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		key, err := apiKeyService.RotateAPIKey(c.Request().Context(), id)
+		if err != nil {
+			return apiKeyError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, key, "\t")
+	}
+}
+
+func RevokeAPIKeyHandler(apiKeyService APIKeyService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		if err := apiKeyService.RevokeAPIKey(c.Request().Context(), id); err != nil {
+			return apiKeyError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, EchoMessage{Msg: "successfully revoked"}, "\t")
+	}
+}
+
+func apiKeyError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput):
+		return c.JSONPretty(http.StatusBadRequest, EchoMessage{Msg: err.Error()}, "\t")
+	case errors.Is(err, service.ErrAPIKeyRevoked):
+		return c.JSONPretty(http.StatusConflict, EchoMessage{Msg: err.Error()}, "\t")
+	case errors.As(err, &repository.ErrNotFound{}):
+		return c.JSONPretty(http.StatusNotFound, EchoMessage{Msg: "api key not found"}, "\t")
+	}
+
+	return err
+}
+
+func parseID(c echo.Context) (int, error) {
+	strID := c.Param("id")
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse id=%q: %w", strID, err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,202 @@
+package apikey_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apikey"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceBuilder func(t *testing.T) apikey.APIKeyService
+
+func TestCreateAPIKeyHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	createdBy := 1
+
+	tt := []testCase{
+		{
+			name:      "Valid key",
+			inputBody: `{"name": "minsk", "cities": ["Minsk"], "permissions": ["weather:create"]}`,
+			serviceBuilder: func(t *testing.T) apikey.APIKeyService {
+				t.Helper()
+
+				mockService := NewMockAPIKeyService(t)
+				mockService.
+					On(
+						"CreateAPIKey",
+						mock.Anything,
+						1,
+						"minsk",
+						[]string{"Minsk"},
+						[]models.Permission{models.PermissionWeatherCreate},
+					).
+					Return(&models.IssuedAPIKey{
+						APIKey: models.APIKey{
+							ID:          2,
+							Name:        "minsk",
+							Prefix:      "0a1b2c",
+							KeyHash:     "hash",
+							Cities:      []string{"Minsk"},
+							Permissions: []models.Permission{models.PermissionWeatherCreate},
+							CreatedBy:   &createdBy,
+							CreatedAt:   createdAt,
+						},
+						Key: "wfk_0a1b2c_secret",
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: `{
+				"id": 2,
+				"name": "minsk",
+				"prefix": "0a1b2c",
+				"cities": ["Minsk"],
+				"permissions": ["weather:create"],
+				"created_by": 1,
+				"created_at": "2024-05-01T12:00:00Z",
+				"key": "wfk_0a1b2c_secret"
+			}`,
+		},
+		{
+			name:      "Invalid permission",
+			inputBody: `{"name": "minsk", "cities": ["Minsk"], "permissions": ["users:manage"]}`,
+			serviceBuilder: func(t *testing.T) apikey.APIKeyService {
+				t.Helper()
+
+				mockService := NewMockAPIKeyService(t)
+				mockService.
+					On(
+						"CreateAPIKey",
+						mock.Anything,
+						1,
+						"minsk",
+						[]string{"Minsk"},
+						[]models.Permission{models.PermissionUsersManage},
+					).
+					Return(nil, service.ErrInvalidInput).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/api-keys",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), &models.Principal{UserID: 1}))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := apikey.CreateAPIKeyHandler(tc.serviceBuilder(t))
+
+			err := handler(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRotateAPIKeyHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		id                 string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name: "Revoked key",
+			id:   "2",
+			serviceBuilder: func(t *testing.T) apikey.APIKeyService {
+				t.Helper()
+
+				mockService := NewMockAPIKeyService(t)
+				mockService.
+					On("RotateAPIKey", mock.Anything, 2).
+					Return(nil, service.ErrAPIKeyRevoked).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"message": "api key is revoked"}`,
+		},
+		{
+			name: "Missing key",
+			id:   "9",
+			serviceBuilder: func(t *testing.T) apikey.APIKeyService {
+				t.Helper()
+
+				mockService := NewMockAPIKeyService(t)
+				mockService.
+					On("RotateAPIKey", mock.Anything, 9).
+					Return(nil, repository.NewErrNotFound(9)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "api key not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodPost, "/api-keys/"+tc.id+"/rotate", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+			handler := apikey.RotateAPIKeyHandler(tc.serviceBuilder(t))
+
+			err := handler(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
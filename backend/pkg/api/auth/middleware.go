@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type principalKey struct{}
+
+//go:generate mockery --name TokenParser --structname MockTokenParser --filename mock_token_parser_test.go --outpkg auth_test --output .
+type TokenParser interface {
+	ParseAccessToken(token string) (*models.Principal, error)
+}
+
+//go:generate mockery --name APIKeyAuthenticator --structname MockAPIKeyAuthenticator --filename mock_api_key_authenticator_test.go --outpkg auth_test --output .
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.Principal, error)
+}
+
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*models.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*models.Principal)
+	return principal, ok
+}
+
+// Middleware validates either a bearer access token or a station API key and
+// stores the principal in the request context.
+func Middleware(parser TokenParser, keys APIKeyAuthenticator) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+
+			if key, ok := strings.CutPrefix(header, "ApiKey "); ok {
+				return authenticateAPIKey(c, next, keys, key)
+			}
+
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "missing bearer token"},
+					"\t",
+				)
+			}
+
+			principal, err := parser.ParseAccessToken(token)
+			if err != nil {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "invalid access token"},
+					"\t",
+				)
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(WithPrincipal(req.Context(), principal)))
+
+			return next(c)
+		}
+	}
+}
+
+func authenticateAPIKey(
+	c echo.Context,
+	next echo.HandlerFunc,
+	keys APIKeyAuthenticator,
+	key string,
+) error {
+	req := c.Request()
+
+	principal, err := keys.AuthenticateAPIKey(req.Context(), key)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			return c.JSONPretty(
+				http.StatusUnauthorized,
+				EchoMessage{Msg: "invalid api key"},
+				"\t",
+			)
+		}
+
+		return err
+	}
+
+	c.SetRequest(req.WithContext(WithPrincipal(req.Context(), principal)))
+
+	return next(c)
+}
+
+func RequirePermissions(permissions ...models.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, ok := PrincipalFromContext(c.Request().Context())
+			if !ok {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "authentication required"},
+					"\t",
+				)
+			}
+
+			for _, permission := range permissions {
+				if !principal.HasPermission(permission) {
+					return c.JSONPretty(
+						http.StatusForbidden,
+						EchoMessage{Msg: fmt.Sprintf("missing permission %q", permission)},
+						"\t",
+					)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		header             string
+		parserBuilder      func(t *testing.T) auth.TokenParser
+		keysBuilder        func(t *testing.T) auth.APIKeyAuthenticator
+		permissions        []models.Permission
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:   "Admin token",
+			header: "Bearer good",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				parser := NewMockTokenParser(t)
+				parser.
+					On("ParseAccessToken", "good").
+					Return(&models.Principal{
+						UserID:      1,
+						Username:    "admin",
+						Roles:       []models.Role{models.RoleAdmin},
+						Permissions: []models.Permission{models.PermissionWeatherCreate},
+					}, nil).
+					Once()
+
+				return parser
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"user_id": 1,
+				"username": "admin",
+				"roles": ["admin"],
+				"permissions": ["weather:create"]
+			}`,
+		},
+		{
+			name:   "Missing token",
+			header: "",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				return NewMockTokenParser(t)
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "missing bearer token"}`,
+		},
+		{
+			name:   "Invalid token",
+			header: "Bearer bad",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				parser := NewMockTokenParser(t)
+				parser.
+					On("ParseAccessToken", "bad").
+					Return(nil, service.ErrInvalidToken).
+					Once()
+
+				return parser
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "invalid access token"}`,
+		},
+		{
+			name:   "Missing permission",
+			header: "Bearer viewer",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				parser := NewMockTokenParser(t)
+				parser.
+					On("ParseAccessToken", "viewer").
+					Return(&models.Principal{
+						UserID:   2,
+						Username: "bob",
+						Roles:    []models.Role{models.RoleViewer},
+					}, nil).
+					Once()
+
+				return parser
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"message": "missing permission \"weather:create\""}`,
+		},
+		{
+			name:   "Station api key",
+			header: "ApiKey wfk_abc_secret",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				return NewMockTokenParser(t)
+			},
+			keysBuilder: func(t *testing.T) auth.APIKeyAuthenticator {
+				t.Helper()
+
+				keys := NewMockAPIKeyAuthenticator(t)
+				keys.
+					On("AuthenticateAPIKey", mock.Anything, "wfk_abc_secret").
+					Return(&models.Principal{
+						Username:    "minsk-station",
+						Permissions: []models.Permission{models.PermissionWeatherCreate},
+						APIKeyID:    3,
+						Cities:      []string{"Minsk"},
+					}, nil).
+					Once()
+
+				return keys
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"user_id": 0,
+				"username": "minsk-station",
+				"roles": null,
+				"permissions": ["weather:create"],
+				"api_key_id": 3,
+				"cities": ["Minsk"]
+			}`,
+		},
+		{
+			name:   "Invalid api key",
+			header: "ApiKey wfk_abc_wrong",
+			parserBuilder: func(t *testing.T) auth.TokenParser {
+				t.Helper()
+
+				return NewMockTokenParser(t)
+			},
+			keysBuilder: func(t *testing.T) auth.APIKeyAuthenticator {
+				t.Helper()
+
+				keys := NewMockAPIKeyAuthenticator(t)
+				keys.
+					On("AuthenticateAPIKey", mock.Anything, "wfk_abc_wrong").
+					Return(nil, service.ErrInvalidAPIKey).
+					Once()
+
+				return keys
+			},
+			permissions:        []models.Permission{models.PermissionWeatherCreate},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "invalid api key"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodPost, "/weather", nil)
+			if tc.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var keys auth.APIKeyAuthenticator = NewMockAPIKeyAuthenticator(t)
+			if tc.keysBuilder != nil {
+				keys = tc.keysBuilder(t)
+			}
+
+			handler := auth.Middleware(tc.parserBuilder(t), keys)(
+				auth.RequirePermissions(tc.permissions...)(func(c echo.Context) error {
+					principal, ok := auth.PrincipalFromContext(c.Request().Context())
+					require.True(t, ok)
+
+					return c.JSON(http.StatusOK, principal)
+				}),
+			)
+
+			err := handler(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
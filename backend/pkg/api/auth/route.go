@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Route declares an endpoint together with the permissions it requires.
+// Routes without permissions are public.
+type Route struct {
+	Method      string
+	Path        string
+	Handler     echo.HandlerFunc
+	Permissions []models.Permission
+}
+
+func RegisterRoutes(server *echo.Echo, authenticate echo.MiddlewareFunc, routes ...Route) {
+	for _, route := range routes {
+		var middlewares []echo.MiddlewareFunc
+		if len(route.Permissions) > 0 {
+			middlewares = append(middlewares, authenticate, RequirePermissions(route.Permissions...))
+		}
+
+		server.Add(route.Method, route.Path, route.Handler, middlewares...)
+	}
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+//go:generate mockery --name AuthService --structname MockAuthService --filename mock_auth_service_test.go --outpkg auth_test --output .
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (*models.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+func RegisterAuthRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	authService AuthService,
+) {
+	server.POST("/auth/login", LoginHandler(authService))
+	server.POST("/auth/refresh", RefreshHandler(authService))
+	server.POST("/auth/logout", LogoutHandler(authService))
+}
+
+func LoginHandler(authService AuthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req LoginRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		pair, err := authService.Login(c.Request().Context(), req.Username, req.Password)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidCredentials) {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "invalid username or password"},
+					"\t",
+				)
+			}
+
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, pair, "\t")
+	}
+}
+
+func RefreshHandler(authService AuthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req RefreshRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		pair, err := authService.Refresh(c.Request().Context(), req.RefreshToken)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidToken) {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "invalid refresh token"},
+					"\t",
+				)
+			}
+
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, pair, "\t")
+	}
+}
+
+func LogoutHandler(authService AuthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req RefreshRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		err := authService.Logout(c.Request().Context(), req.RefreshToken)
+		if err != nil {
+			if errors.Is(err, service.ErrInvalidToken) {
+				return c.JSONPretty(
+					http.StatusUnauthorized,
+					EchoMessage{Msg: "invalid refresh token"},
+					"\t",
+				)
+			}
+
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, EchoMessage{Msg: "successfully logged out"}, "\t")
+	}
+}
@@ -0,0 +1,276 @@
+package auth_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceBuilder func(t *testing.T) auth.AuthService
+
+func TestLoginHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "Valid credentials",
+			inputBody: `{"username": "admin", "password": "admin"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Login", mock.Anything, "admin", "admin").
+					Return(&models.TokenPair{
+						AccessToken:  "access",
+						RefreshToken: "refresh",
+						TokenType:    "Bearer",
+						ExpiresIn:    900,
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"access_token": "access",
+				"refresh_token": "refresh",
+				"token_type": "Bearer",
+				"expires_in": 900
+			}`,
+		},
+		{
+			name:      "Invalid credentials",
+			inputBody: `{"username": "admin", "password": "wrong"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Login", mock.Anything, "admin", "wrong").
+					Return(nil, service.ErrInvalidCredentials).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "invalid username or password"}`,
+		},
+		{
+			name:      "Service error",
+			inputBody: `{"username": "admin", "password": "admin"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Login", mock.Anything, "admin", "admin").
+					Return(nil, errors.New("database error")).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"message": "The server is temporarily unavailable, please try again later"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/login",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := auth.LoginHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestRefreshHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "Valid refresh token",
+			inputBody: `{"refresh_token": "refresh"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Refresh", mock.Anything, "refresh").
+					Return(&models.TokenPair{
+						AccessToken:  "access2",
+						RefreshToken: "refresh2",
+						TokenType:    "Bearer",
+						ExpiresIn:    900,
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"access_token": "access2",
+				"refresh_token": "refresh2",
+				"token_type": "Bearer",
+				"expires_in": 900
+			}`,
+		},
+		{
+			name:      "Revoked refresh token",
+			inputBody: `{"refresh_token": "refresh"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Refresh", mock.Anything, "refresh").
+					Return(nil, service.ErrInvalidToken).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "invalid refresh token"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/refresh",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := auth.RefreshHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestLogoutHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "Valid refresh token",
+			inputBody: `{"refresh_token": "refresh"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Logout", mock.Anything, "refresh").
+					Return(nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message": "successfully logged out"}`,
+		},
+		{
+			name:      "Invalid refresh token",
+			inputBody: `{"refresh_token": "garbage"}`,
+			serviceBuilder: func(t *testing.T) auth.AuthService {
+				t.Helper()
+
+				mockService := NewMockAuthService(t)
+				mockService.
+					On("Logout", mock.Anything, "garbage").
+					Return(service.ErrInvalidToken).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"message": "invalid refresh token"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/auth/logout",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := auth.LogoutHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+//go:generate mockery --name ForecastService --structname MockForecastService --filename mock_forecast_service_test.go --outpkg forecast_test --output .
+type ForecastService interface {
+	Forecast(ctx context.Context, req models.ForecastRequest) (*models.Forecast, error)
+	UploadForecast(ctx context.Context, fc *models.Forecast) error
+	ForecastAccuracy(ctx context.Context, filter models.AccuracyFilter) (*models.AccuracyReport, error)
+}
+
+func RegisterForecastRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	forecastService ForecastService,
+	authenticate echo.MiddlewareFunc,
+) {
+	auth.RegisterRoutes(server, authenticate,
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/forecast",
+			Handler: GetForecastHandler(forecastService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/forecast/accuracy",
+			Handler: ForecastAccuracyHandler(forecastService),
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/forecasts",
+			Handler:     UploadForecastHandler(forecastService),
+			Permissions: []models.Permission{models.PermissionForecastsUpload},
+		},
+	)
+}
+
+// GetForecastHandler forecasts the location given by location_id or by city
+// and country. granularity is hour (default) or day, horizon the number of
+// periods to predict.
+func GetForecastHandler(forecastService ForecastService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req, err := parseForecastRequest(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		fc, err := forecastService.Forecast(c.Request().Context(), req)
+
+		switch {
+		case err == nil:
+		case errors.As(err, &repository.ErrNotFound{}):
+			return c.JSONPretty(http.StatusNotFound, EchoMessage{Msg: "location not found"}, "\t")
+		case errors.Is(err, service.ErrNotEnoughHistory):
+			return c.JSONPretty(http.StatusUnprocessableEntity, EchoMessage{Msg: err.Error()}, "\t")
+		default:
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, fc, "\t")
+	}
+}
+
+// UploadForecastHandler stores a third-party forecast run. Uploading a run
+// with the same location, model, granularity and issued_at again replaces
+// the stored points.
+func UploadForecastHandler(forecastService ForecastService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var fc models.Forecast
+
+		if err := c.Bind(&fc); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		err := forecastService.UploadForecast(c.Request().Context(), &fc)
+		if errors.As(err, &repository.ErrNotFound{}) {
+			return c.JSONPretty(http.StatusNotFound, EchoMessage{Msg: "location not found"}, "\t")
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusCreated, fc, "\t")
+	}
+}
+
+// ForecastAccuracyHandler reports the errors of the stored forecasts whose
+// target time lies within [from, to).
+func ForecastAccuracyHandler(forecastService ForecastService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := parseAccuracyFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		report, err := forecastService.ForecastAccuracy(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, report, "\t")
+	}
+}
+
+func parseAccuracyFilter(c echo.Context) (models.AccuracyFilter, error) {
+	filter := models.AccuracyFilter{
+		Model:       queryString(c, "model"),
+		City:        queryString(c, "city"),
+		Country:     queryString(c, "country"),
+		Granularity: models.BucketHour,
+	}
+
+	if value := c.QueryParam("location_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, fmt.Errorf("failed to parse location_id=%q: %w", value, err)
+		}
+
+		filter.LocationID = &id
+	}
+
+	for _, bound := range []struct {
+		name string
+		dst  **time.Time
+	}{
+		{name: "from", dst: &filter.From},
+		{name: "to", dst: &filter.To},
+	} {
+		value := c.QueryParam(bound.name)
+		if value == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("failed to parse %s=%q: %w", bound.name, value, err)
+		}
+
+		*bound.dst = &t
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+
+	switch granularity := c.QueryParam("granularity"); granularity {
+	case "", string(models.BucketHour):
+	case string(models.BucketDay):
+		filter.Granularity = models.BucketDay
+	default:
+		return filter, fmt.Errorf("unsupported granularity %q", granularity)
+	}
+
+	return filter, nil
+}
+
+func queryString(c echo.Context, name string) *string {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func parseForecastRequest(c echo.Context) (models.ForecastRequest, error) {
+	req := models.ForecastRequest{
+		City:        c.QueryParam("city"),
+		Country:     c.QueryParam("country"),
+		Granularity: models.BucketHour,
+	}
+
+	if value := c.QueryParam("location_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return req, fmt.Errorf("failed to parse location_id=%q: %w", value, err)
+		}
+
+		req.LocationID = &id
+	} else if req.City == "" || req.Country == "" {
+		return req, fmt.Errorf("either location_id or city and country are required")
+	}
+
+	maxHorizon := service.MaxHourlyHorizon
+
+	switch granularity := c.QueryParam("granularity"); granularity {
+	case "", string(models.BucketHour):
+	case string(models.BucketDay):
+		req.Granularity = models.BucketDay
+		maxHorizon = service.MaxDailyHorizon
+	default:
+		return req, fmt.Errorf("unsupported granularity %q", granularity)
+	}
+
+	if value := c.QueryParam("horizon"); value != "" {
+		horizon, err := strconv.Atoi(value)
+		if err != nil || horizon <= 0 || horizon > maxHorizon {
+			return req, fmt.Errorf("horizon must be between 1 and %d", maxHorizon)
+		}
+
+		req.Horizon = horizon
+	}
+
+	return req, nil
+}
@@ -0,0 +1,388 @@
+package forecast_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/forecast"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceBuilder func(t *testing.T) forecast.ForecastService
+
+func prediction(value, lower, upper float64) *models.Prediction {
+	return &models.Prediction{Value: value, Lower: &lower, Upper: &upper}
+}
+
+func TestGetForecastHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	issuedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []testCase{
+		{
+			name:  "Daily forecast",
+			query: "?city=Minsk&country=Belarus&granularity=day&horizon=1",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("Forecast", mock.Anything, models.ForecastRequest{
+						City:        "Minsk",
+						Country:     "Belarus",
+						Granularity: models.BucketDay,
+						Horizon:     1,
+					}).
+					Return(&models.Forecast{
+						LocationID:  2,
+						City:        "Minsk",
+						Country:     "Belarus",
+						Model:       "holt",
+						Granularity: models.BucketDay,
+						IssuedAt:    issuedAt,
+						Points: []models.ForecastPoint{
+							{
+								Time:        issuedAt,
+								Temperature: prediction(12, 9, 15),
+								Humidity:    prediction(60, 50, 70),
+								Pressure:    prediction(1013, 1008, 1018),
+								WindSpeed:   prediction(4, 1, 7),
+							},
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"location_id": 2,
+				"city": "Minsk",
+				"country": "Belarus",
+				"model": "holt",
+				"granularity": "day",
+				"issued_at": "2024-05-01T00:00:00Z",
+				"points": [{
+					"time": "2024-05-01T00:00:00Z",
+					"temperature": {"value": 12, "lower": 9, "upper": 15},
+					"humidity": {"value": 60, "lower": 50, "upper": 70},
+					"pressure": {"value": 1013, "lower": 1008, "upper": 1018},
+					"wind_speed": {"value": 4, "lower": 1, "upper": 7}
+				}]
+			}`,
+		},
+		{
+			name:  "Missing country",
+			query: "?city=Minsk",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				return NewMockForecastService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: either location_id or city and country are required"}`,
+		},
+		{
+			name:  "Horizon too long",
+			query: "?location_id=2&granularity=day&horizon=31",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				return NewMockForecastService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: horizon must be between 1 and 30"}`,
+		},
+		{
+			name:  "Unknown location",
+			query: "?location_id=9",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				id := 9
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("Forecast", mock.Anything, models.ForecastRequest{
+						LocationID:  &id,
+						Granularity: models.BucketHour,
+					}).
+					Return(nil, repository.NewErrNotFound(9)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "location not found"}`,
+		},
+		{
+			name:  "Not enough history",
+			query: "?location_id=2",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("Forecast", mock.Anything, mock.Anything).
+					Return(nil, service.ErrNotEnoughHistory).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"message": "not enough observations to forecast"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/forecast"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := forecast.GetForecastHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestUploadForecastHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name: "Valid upload",
+			inputBody: `{
+				"city": "Minsk",
+				"country": "Belarus",
+				"model": "acme",
+				"granularity": "hour",
+				"issued_at": "2024-05-01T06:30:00Z",
+				"points": [{"time": "2024-05-01T07:00:00Z", "temperature": {"value": 14}}]
+			}`,
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("UploadForecast", mock.Anything, mock.MatchedBy(func(fc *models.Forecast) bool {
+						return fc.Model == "acme" && len(fc.Points) == 1 &&
+							fc.Points[0].Temperature.Value == 14 && fc.Points[0].Humidity == nil
+					})).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*models.Forecast).LocationID = 2
+					}).
+					Return(nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: `{
+				"location_id": 2,
+				"city": "Minsk",
+				"country": "Belarus",
+				"model": "acme",
+				"granularity": "hour",
+				"issued_at": "2024-05-01T06:30:00Z",
+				"points": [{"time": "2024-05-01T07:00:00Z", "temperature": {"value": 14}}]
+			}`,
+		},
+		{
+			name:      "Unknown location",
+			inputBody: `{"location_id": 9, "model": "acme"}`,
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("UploadForecast", mock.Anything, mock.Anything).
+					Return(repository.NewErrNotFound(9)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "location not found"}`,
+		},
+		{
+			name:      "Invalid forecast",
+			inputBody: `{"location_id": 2, "model": "holt"}`,
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("UploadForecast", mock.Anything, mock.Anything).
+					Return(&service.ValidationError{Fields: []models.FieldError{
+						{Field: "model", Code: models.CodeInvalidValue, Message: "is reserved for generated forecasts"},
+					}}).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: `{
+				"message": "validation failed",
+				"errors": [{"field": "model", "code": "invalid_value", "message": "is reserved for generated forecasts"}]
+			}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodPost, "/forecasts", strings.NewReader(tc.inputBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := forecast.UploadForecastHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestForecastAccuracyHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []testCase{
+		{
+			name:  "Daily accuracy of a model",
+			query: "?model=acme&granularity=day&from=2024-05-01T00:00:00Z",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				model := "acme"
+				errs := models.VariableErrors{
+					Temperature: &models.ErrorStats{Count: 3, MAE: 1.5, RMSE: 2, Bias: -0.5},
+				}
+
+				mockService := NewMockForecastService(t)
+				mockService.
+					On("ForecastAccuracy", mock.Anything, models.AccuracyFilter{
+						Model:       &model,
+						From:        &from,
+						Granularity: models.BucketDay,
+					}).
+					Return(&models.AccuracyReport{
+						Granularity: models.BucketDay,
+						Models:      []*models.ModelAccuracy{{Model: "acme", VariableErrors: errs}},
+						Items: []*models.ForecastAccuracy{
+							{
+								Model:          "acme",
+								LocationID:     2,
+								City:           "Minsk",
+								Country:        "Belarus",
+								Horizon:        1,
+								VariableErrors: errs,
+							},
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"granularity": "day",
+				"models": [
+					{"model": "acme", "temperature": {"count": 3, "mae": 1.5, "rmse": 2, "bias": -0.5}}
+				],
+				"items": [{
+					"model": "acme",
+					"location_id": 2,
+					"city": "Minsk",
+					"country": "Belarus",
+					"horizon": 1,
+					"temperature": {"count": 3, "mae": 1.5, "rmse": 2, "bias": -0.5}
+				}]
+			}`,
+		},
+		{
+			name:  "Unsupported granularity",
+			query: "?granularity=week",
+			serviceBuilder: func(t *testing.T) forecast.ForecastService {
+				t.Helper()
+
+				return NewMockForecastService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported granularity \"week\""}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/forecast/accuracy"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := forecast.ForecastAccuracyHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
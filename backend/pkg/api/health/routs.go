@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoStatus struct {
+	Status models.HealthStatus `json:"status"`
+}
+
+//go:generate mockery --name HealthService --structname MockHealthService --filename mock_health_service_test.go --outpkg health_test --output .
+type HealthService interface {
+	Ready(ctx context.Context) *models.HealthReport
+}
+
+// RegisterHealthRoutes adds the probes. They are public, so load balancers
+// and orchestrators can reach them without credentials.
+func RegisterHealthRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	healthService HealthService,
+) {
+	server.GET("/healthz", HealthHandler())
+	server.GET("/readyz", ReadyHandler(healthService))
+}
+
+// HealthHandler reports that the process is alive and serving; it checks no
+// dependencies.
+func HealthHandler() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSONPretty(http.StatusOK, EchoStatus{Status: models.HealthOK}, "\t")
+	}
+}
+
+// ReadyHandler reports every dependency check. It responds 503 unless all of
+// them pass and the server is not shutting down.
+func ReadyHandler(healthService HealthService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		report := healthService.Ready(c.Request().Context())
+
+		code := http.StatusOK
+		if report.Status != models.HealthOK {
+			code = http.StatusServiceUnavailable
+		}
+
+		return c.JSONPretty(code, report, "\t")
+	}
+}
@@ -0,0 +1,101 @@
+package health_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, health.HealthHandler()(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, rec.Body.String())
+}
+
+func TestReadyHandler(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		report             *models.HealthReport
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name: "Ready",
+			report: &models.HealthReport{
+				Status: models.HealthOK,
+				Checks: map[string]models.CheckResult{
+					"postgres": {
+						Status:  models.HealthOK,
+						Details: map[string]any{"migration_version": 12},
+					},
+				},
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"status": "ok",
+				"checks": {"postgres": {"status": "ok", "details": {"migration_version": 12}}}
+			}`,
+		},
+		{
+			name: "Dependency down",
+			report: &models.HealthReport{
+				Status: models.HealthFail,
+				Checks: map[string]models.CheckResult{
+					"postgres": {Status: models.HealthFail, Error: "failed to ping postgres: connection refused"},
+				},
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse: `{
+				"status": "fail",
+				"checks": {"postgres": {"status": "fail", "error": "failed to ping postgres: connection refused"}}
+			}`,
+		},
+		{
+			name: "Shutting down",
+			report: &models.HealthReport{
+				Status: models.HealthDraining,
+				Checks: map[string]models.CheckResult{},
+			},
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"status": "draining", "checks": {}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockService := NewMockHealthService(t)
+			mockService.On("Ready", mock.Anything).Return(tc.report).Once()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, health.ReadyHandler(mockService)(c))
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
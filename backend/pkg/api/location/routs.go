@@ -0,0 +1,208 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+//go:generate mockery --name LocationService --structname MockLocationService --filename mock_location_service_test.go --outpkg location_test --output .
+type LocationService interface {
+	CreateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	GetLocation(ctx context.Context, id int) (*models.Location, error)
+	ListLocations(ctx context.Context) ([]*models.Location, error)
+	UpdateLocation(ctx context.Context, location *models.Location) (*models.Location, error)
+	DeleteLocation(ctx context.Context, id int) error
+}
+
+func RegisterLocationRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	locationService LocationService,
+	authenticate echo.MiddlewareFunc,
+) {
+	manage := []models.Permission{models.PermissionLocationsManage}
+
+	auth.RegisterRoutes(server, authenticate,
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/locations",
+			Handler: ListLocationsHandler(locationService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/locations/:id",
+			Handler: GetLocationHandler(locationService),
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/locations",
+			Handler:     CreateLocationHandler(locationService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodPut,
+			Path:        "/locations/:id",
+			Handler:     UpdateLocationHandler(locationService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodDelete,
+			Path:        "/locations/:id",
+			Handler:     DeleteLocationHandler(locationService),
+			Permissions: manage,
+		},
+	)
+}
+
+func ListLocationsHandler(locationService LocationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		locations, err := locationService.ListLocations(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, locations, "\t")
+	}
+}
+
+func GetLocationHandler(locationService LocationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		location, err := locationService.GetLocation(c.Request().Context(), id)
+		if err != nil {
+			return locationError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, location, "\t")
+	}
+}
+
+func CreateLocationHandler(locationService LocationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var location models.Location
+
+		if err := c.Bind(&location); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		location.ID = 0
+
+		res, err := locationService.CreateLocation(c.Request().Context(), &location)
+		if err != nil {
+			return locationError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusCreated, res, "\t")
+	}
+}
+
+func UpdateLocationHandler(locationService LocationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var location models.Location
+
+		if err := c.Bind(&location); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		location.ID = id
+
+		res, err := locationService.UpdateLocation(c.Request().Context(), &location)
+		if err != nil {
+			return locationError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, res, "\t")
+	}
+}
+
+func DeleteLocationHandler(locationService LocationService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		err = locationService.DeleteLocation(c.Request().Context(), id)
+		if errors.Is(err, repository.ErrConflict) {
+			return c.JSONPretty(
+				http.StatusConflict,
+				EchoMessage{Msg: "location still has observations"},
+				"\t",
+			)
+		}
+
+		if err != nil {
+			return locationError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, EchoMessage{Msg: "successfully deleted"}, "\t")
+	}
+}
+
+func locationError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		return c.JSONPretty(
+			http.StatusConflict,
+			EchoMessage{Msg: "location with this name and country already exists"},
+			"\t",
+		)
+	case errors.As(err, &repository.ErrNotFound{}):
+		return c.JSONPretty(http.StatusNotFound, EchoMessage{Msg: "location not found"}, "\t")
+	}
+
+	return err
+}
+
+func parseID(c echo.Context) (int, error) {
+	strID := c.Param("id")
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse id=%q: %w", strID, err)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,235 @@
+package location_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/location"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceBuilder func(t *testing.T) location.LocationService
+
+func TestCreateLocationHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	latitude, longitude := 53.9, 27.56
+
+	tt := []testCase{
+		{
+			name:      "Valid location",
+			inputBody: `{"name": "Minsk", "country": "Belarus", "latitude": 53.9, "longitude": 27.56, "timezone": "Europe/Minsk"}`,
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.
+					On("CreateLocation", mock.Anything, &models.Location{
+						Name:      "Minsk",
+						Country:   "Belarus",
+						Latitude:  &latitude,
+						Longitude: &longitude,
+						Timezone:  "Europe/Minsk",
+					}).
+					Return(&models.Location{
+						ID:        1,
+						Name:      "Minsk",
+						Country:   "Belarus",
+						Latitude:  &latitude,
+						Longitude: &longitude,
+						Timezone:  "Europe/Minsk",
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedResponse: `{
+				"id": 1,
+				"name": "Minsk",
+				"country": "Belarus",
+				"latitude": 53.9,
+				"longitude": 27.56,
+				"timezone": "Europe/Minsk"
+			}`,
+		},
+		{
+			name:      "Invalid location",
+			inputBody: `{"name": "", "country": "Belarus"}`,
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.
+					On("CreateLocation", mock.Anything, mock.Anything).
+					Return(nil, &service.ValidationError{Fields: []models.FieldError{
+						{Field: "name", Code: models.CodeRequired, Message: "is required"},
+					}}).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: `{
+				"message": "validation failed",
+				"errors": [{"field": "name", "code": "required", "message": "is required"}]
+			}`,
+		},
+		{
+			name:      "Duplicate location",
+			inputBody: `{"name": "minsk", "country": "belarus"}`,
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.
+					On("CreateLocation", mock.Anything, mock.Anything).
+					Return(nil, fmt.Errorf("failed to add location: %w: duplicate key", repository.ErrConflict)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"message": "location with this name and country already exists"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/locations",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := location.CreateLocationHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestDeleteLocationHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		id                 string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name: "Unused location",
+			id:   "3",
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.On("DeleteLocation", mock.Anything, 3).Return(nil).Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message": "successfully deleted"}`,
+		},
+		{
+			name: "Location in use",
+			id:   "1",
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.
+					On("DeleteLocation", mock.Anything, 1).
+					Return(fmt.Errorf("failed to delete location: %w: foreign key violation", repository.ErrConflict)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"message": "location still has observations"}`,
+		},
+		{
+			name: "Missing location",
+			id:   "9",
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				mockService := NewMockLocationService(t)
+				mockService.
+					On("DeleteLocation", mock.Anything, 9).
+					Return(repository.NewErrNotFound(9)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "location not found"}`,
+		},
+		{
+			name: "Invalid ID",
+			id:   "abc",
+			serviceBuilder: func(t *testing.T) location.LocationService {
+				t.Helper()
+
+				return NewMockLocationService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "parseID: failed to parse id=\"abc\": strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodDelete, "/locations/"+tc.id, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+			handler := location.DeleteLocationHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
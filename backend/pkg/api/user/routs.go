@@ -0,0 +1,179 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoMessage struct {
+	Msg string `json:"message"`
+}
+
+type EchoID struct {
+	ID int `json:"id"`
+}
+
+type CreateUserRequest struct {
+	Username string        `json:"username"`
+	Password string        `json:"password"`
+	Roles    []models.Role `json:"roles"`
+}
+
+type SetRolesRequest struct {
+	Roles []models.Role `json:"roles"`
+}
+
+//go:generate mockery --name UserService --structname MockUserService --filename mock_user_service_test.go --outpkg user_test --output .
+type UserService interface {
+	CreateUser(ctx context.Context, username, password string, roles []models.Role) (int, error)
+	ListUsers(ctx context.Context) ([]*models.User, error)
+	SetUserRoles(ctx context.Context, userID int, roles []models.Role) error
+	ListRoles(ctx context.Context) ([]*models.RoleDefinition, error)
+}
+
+func RegisterUserRoutes(
+	ctx context.Context,
+	server *echo.Echo,
+	userService UserService,
+	authenticate echo.MiddlewareFunc,
+) {
+	manage := []models.Permission{models.PermissionUsersManage}
+
+	auth.RegisterRoutes(server, authenticate,
+		auth.Route{
+			Method:      http.MethodGet,
+			Path:        "/users",
+			Handler:     ListUsersHandler(userService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/users",
+			Handler:     CreateUserHandler(userService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodPut,
+			Path:        "/users/:id/roles",
+			Handler:     SetUserRolesHandler(userService),
+			Permissions: manage,
+		},
+		auth.Route{
+			Method:      http.MethodGet,
+			Path:        "/roles",
+			Handler:     ListRolesHandler(userService),
+			Permissions: manage,
+		},
+	)
+}
+
+func ListUsersHandler(userService UserService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		users, err := userService.ListUsers(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, users, "\t")
+	}
+}
+
+func CreateUserHandler(userService UserService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req CreateUserRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		id, err := userService.CreateUser(
+			c.Request().Context(),
+			req.Username,
+			req.Password,
+			req.Roles,
+		)
+		if err != nil {
+			return userError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, EchoID{ID: id}, "\t")
+	}
+}
+
+func SetUserRolesHandler(userService UserService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var req SetRolesRequest
+
+		if err := c.Bind(&req); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		if err := userService.SetUserRoles(c.Request().Context(), id, req.Roles); err != nil {
+			return userError(c, err)
+		}
+
+		return c.JSONPretty(http.StatusOK, EchoMessage{Msg: "successfully updated"}, "\t")
+	}
+}
+
+func ListRolesHandler(userService UserService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roles, err := userService.ListRoles(c.Request().Context())
+		if err != nil {
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, roles, "\t")
+	}
+}
+
+func userError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidInput), errors.Is(err, service.ErrUnknownRole):
+		return c.JSONPretty(http.StatusBadRequest, EchoMessage{Msg: err.Error()}, "\t")
+	case errors.Is(err, service.ErrUserExists):
+		return c.JSONPretty(http.StatusConflict, EchoMessage{Msg: err.Error()}, "\t")
+	case errors.As(err, &repository.ErrNotFound{}):
+		return c.JSONPretty(http.StatusNotFound, EchoMessage{Msg: "user not found"}, "\t")
+	}
+
+	return err
+}
+
+func parseID(c echo.Context) (int, error) {
+	strID := c.Param("id")
+
+	id, err := strconv.Atoi(strID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse id=%q: %w", strID, err)
+	}
+
+	return id, nil
+}
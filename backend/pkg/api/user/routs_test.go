@@ -0,0 +1,198 @@
+package user_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/user"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceBuilder func(t *testing.T) user.UserService
+
+func TestCreateUserHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "Valid user",
+			inputBody: `{"username": "bob", "password": "password", "roles": ["observer"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				mockService := NewMockUserService(t)
+				mockService.
+					On("CreateUser", mock.Anything, "bob", "password", []models.Role{models.RoleObserver}).
+					Return(4, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"id": 4}`,
+		},
+		{
+			name:      "Unknown role",
+			inputBody: `{"username": "bob", "password": "password", "roles": ["root"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				mockService := NewMockUserService(t)
+				mockService.
+					On("CreateUser", mock.Anything, "bob", "password", []models.Role{"root"}).
+					Return(0, service.ErrUnknownRole).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "unknown role"}`,
+		},
+		{
+			name:      "Existing user",
+			inputBody: `{"username": "admin", "password": "password", "roles": ["admin"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				mockService := NewMockUserService(t)
+				mockService.
+					On("CreateUser", mock.Anything, "admin", "password", []models.Role{models.RoleAdmin}).
+					Return(0, service.ErrUserExists).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"message": "user already exists"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/users",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := user.CreateUserHandler(tc.serviceBuilder(t))
+
+			err := handler(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestSetUserRolesHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		id                 string
+		inputBody          string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "Valid roles",
+			id:        "2",
+			inputBody: `{"roles": ["editor"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				mockService := NewMockUserService(t)
+				mockService.
+					On("SetUserRoles", mock.Anything, 2, []models.Role{models.RoleEditor}).
+					Return(nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"message": "successfully updated"}`,
+		},
+		{
+			name:      "Missing user",
+			id:        "9",
+			inputBody: `{"roles": ["editor"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				mockService := NewMockUserService(t)
+				mockService.
+					On("SetUserRoles", mock.Anything, 9, []models.Role{models.RoleEditor}).
+					Return(repository.NewErrNotFound(9)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "user not found"}`,
+		},
+		{
+			name:      "Invalid ID",
+			id:        "abc",
+			inputBody: `{"roles": ["editor"]}`,
+			serviceBuilder: func(t *testing.T) user.UserService {
+				t.Helper()
+
+				return NewMockUserService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "parseID: failed to parse id=\"abc\": strconv.Atoi: parsing \"abc\": invalid syntax"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPut,
+				"/users/"+tc.id+"/roles",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tc.id)
+			handler := user.SetUserRolesHandler(tc.serviceBuilder(t))
+
+			err := handler(c)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
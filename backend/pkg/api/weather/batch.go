@@ -0,0 +1,182 @@
+package weather
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+const mimeApplicationNDJSON = "application/x-ndjson"
+
+// maxNDJSONLine bounds a single NDJSON record.
+const maxNDJSONLine = 1 << 20
+
+var errBatchTooLarge = fmt.Errorf("batch exceeds %d items", service.MaxBatchSize)
+
+func AddWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		mode, err := parseBatchMode(c.QueryParam("mode"))
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		var items []*models.BatchItem
+
+		req := c.Request()
+		if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), mimeApplicationNDJSON) {
+			items, err = decodeNDJSONBatch(req.Body)
+		} else {
+			items, err = decodeJSONBatch(req.Body)
+		}
+
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		system, err := requestUnits(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		batchToMetric(system, items)
+
+		ctx := req.Context()
+		attributeItems(ctx, items)
+
+		result, err := weatherService.AddWeathers(ctx, items, mode)
+		if err != nil {
+			if errors.Is(err, service.ErrBatchRejected) {
+				return c.JSONPretty(http.StatusUnprocessableEntity, result, "\t")
+			}
+
+			return err
+		}
+
+		return c.JSONPretty(http.StatusOK, result, "\t")
+	}
+}
+
+// attributeItems ties every decoded item to the submitting API key and marks
+// items outside of the key's city scope as failed.
+func attributeItems(ctx context.Context, items []*models.BatchItem) {
+	principal, _ := auth.PrincipalFromContext(ctx)
+
+	for _, item := range items {
+		if item.Error != "" {
+			continue
+		}
+
+		item.Weather.APIKeyID = nil
+
+		if principal == nil || principal.APIKeyID == 0 {
+			continue
+		}
+
+		if !principal.CanAccessCity(item.Weather.City) {
+			item.Error = fmt.Sprintf("%s %q", errCityNotAllowed, item.Weather.City)
+			continue
+		}
+
+		item.Weather.APIKeyID = &principal.APIKeyID
+	}
+}
+
+func parseBatchMode(value string) (models.BatchMode, error) {
+	switch mode := models.BatchMode(value); mode {
+	case "":
+		return models.BatchAtomic, nil
+	case models.BatchAtomic, models.BatchPartial:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unsupported mode %q", value)
+	}
+}
+
+// decodeJSONBatch streams a JSON array. A syntax error makes the rest of the
+// array unreadable, so it fails the whole request.
+func decodeJSONBatch(r io.Reader) ([]*models.BatchItem, error) {
+	dec := json.NewDecoder(r)
+
+	tkn, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err)
+	}
+
+	if delim, ok := tkn.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("batch must be a JSON array")
+	}
+
+	var items []*models.BatchItem
+
+	for dec.More() {
+		if len(items) == service.MaxBatchSize {
+			return nil, errBatchTooLarge
+		}
+
+		var ob models.Weather
+		if err := dec.Decode(&ob); err != nil {
+			return nil, fmt.Errorf("failed to decode item %d: %w", len(items), err)
+		}
+
+		items = append(items, &models.BatchItem{Index: len(items), Weather: &ob})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err)
+	}
+
+	return items, nil
+}
+
+// decodeNDJSONBatch reads one observation per line. Malformed lines are
+// reported as item errors instead of failing the request.
+func decodeNDJSONBatch(r io.Reader) ([]*models.BatchItem, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLine)
+
+	var items []*models.BatchItem
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		if len(items) == service.MaxBatchSize {
+			return nil, errBatchTooLarge
+		}
+
+		item := &models.BatchItem{Index: len(items), Weather: &models.Weather{}}
+		if err := json.Unmarshal(line, item.Weather); err != nil {
+			item.Error = fmt.Sprintf("invalid JSON: %s", err)
+		}
+
+		items = append(items, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read batch: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,184 @@
+package weather_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		contentType        string
+		inputBody          string
+		principal          *models.Principal
+		repoBuilder        serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:        "JSON array",
+			contentType: echo.MIMEApplicationJSON,
+			inputBody:   `[{"city": "Minsk"}, {"city": "Brest"}]`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.MatchedBy(func(items []*models.BatchItem) bool {
+						return len(items) == 2 && items[1].Index == 1 && items[1].Weather.City == "Brest"
+					}), models.BatchAtomic).
+					Return(&models.BatchResult{
+						Inserted: 2,
+						Items:    []models.BatchItemResult{{Index: 0, ID: 1}, {Index: 1, ID: 2}},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"inserted": 2, "failed": 0, "items": [{"index": 0, "id": 1}, {"index": 1, "id": 2}]}`,
+		},
+		{
+			name:        "NDJSON with malformed line",
+			query:       "?mode=partial",
+			contentType: "application/x-ndjson",
+			inputBody:   "{\"city\": \"Minsk\"}\n\n{oops\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.MatchedBy(func(items []*models.BatchItem) bool {
+						return len(items) == 2 && items[0].Error == "" && items[1].Error != ""
+					}), models.BatchPartial).
+					Return(&models.BatchResult{
+						Inserted: 1,
+						Failed:   1,
+						Items:    []models.BatchItemResult{{Index: 0, ID: 1}, {Index: 1, Error: "invalid JSON"}},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"inserted": 1,
+				"failed": 1,
+				"items": [{"index": 0, "id": 1}, {"index": 1, "error": "invalid JSON"}]
+			}`,
+		},
+		{
+			name:        "Rejected atomic batch",
+			contentType: echo.MIMEApplicationJSON,
+			inputBody:   `[{"city": ""}]`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.Anything, models.BatchAtomic).
+					Return(&models.BatchResult{
+						Failed: 1,
+						Items:  []models.BatchItemResult{{Index: 0, Error: "city is required"}},
+					}, service.ErrBatchRejected).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"inserted": 0, "failed": 1, "items": [{"index": 0, "error": "city is required"}]}`,
+		},
+		{
+			name:        "City outside api key scope",
+			contentType: echo.MIMEApplicationJSON,
+			inputBody:   `[{"city": "Minsk"}, {"city": "Berlin"}]`,
+			principal:   stationPrincipal,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.MatchedBy(func(items []*models.BatchItem) bool {
+						return *items[0].Weather.APIKeyID == 3 &&
+							items[1].Error == `api key is not allowed to write observations for city "Berlin"`
+					}), models.BatchAtomic).
+					Return(&models.BatchResult{}, service.ErrBatchRejected).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"inserted": 0, "failed": 0, "items": null}`,
+		},
+		{
+			name:        "Not an array",
+			contentType: echo.MIMEApplicationJSON,
+			inputBody:   `{"city": "Minsk"}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input: batch must be a JSON array"}`,
+		},
+		{
+			name:        "Unknown mode",
+			query:       "?mode=best-effort",
+			contentType: echo.MIMEApplicationJSON,
+			inputBody:   `[]`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported mode \"best-effort\""}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/weathers/batch"+tc.query,
+				strings.NewReader(tc.inputBody),
+			)
+			if tc.principal != nil {
+				req = req.WithContext(auth.WithPrincipal(req.Context(), tc.principal))
+			}
+
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.AddWeathersHandler(tc.repoBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
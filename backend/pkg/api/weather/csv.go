@@ -0,0 +1,425 @@
+package weather
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	formatCSV = "csv"
+
+	// csvFlushRows controls how often exported rows are flushed to the client.
+	csvFlushRows = 500
+)
+
+var csvColumns = []string{
+	"id",
+	"timestamp",
+	"city",
+	"country",
+	"temperature",
+	"humidity",
+	"pressure",
+	"wind_speed",
+	"weather_status",
+	"latitude",
+	"longitude",
+}
+
+var requiredCSVColumns = []string{
+	"timestamp",
+	"city",
+	"country",
+	"temperature",
+	"humidity",
+	"pressure",
+	"wind_speed",
+	"weather_status",
+}
+
+// csvTimeLayouts are accepted on import; spreadsheets often drop the zone.
+var csvTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+type EchoLineError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+type EchoImportResult struct {
+	Inserted int             `json:"inserted"`
+	Failed   int             `json:"failed"`
+	Errors   []EchoLineError `json:"errors"`
+}
+
+func ExportWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if format := c.QueryParam("format"); format != "" && format != formatCSV {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: unsupported format %q", format)},
+				"\t",
+			)
+		}
+
+		filter, err := parseWeatherFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		filterToMetric(format.units, &filter)
+
+		res := c.Response()
+		w := csv.NewWriter(res)
+		rows := 0
+
+		// The response is committed lazily so that a failure before the first
+		// row can still be reported with a proper status code.
+		start := func() error {
+			res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+			res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="weathers.csv"`)
+			res.WriteHeader(http.StatusOK)
+
+			return w.Write(csvColumns)
+		}
+
+		err = weatherService.ExportWeathers(c.Request().Context(), filter, func(ob *models.Weather) error {
+			if !res.Committed {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+
+			format.weathers(ob)
+
+			if err := w.Write(weatherToCSV(ob)); err != nil {
+				return err
+			}
+
+			rows++
+			if rows%csvFlushRows == 0 {
+				w.Flush()
+				res.Flush()
+			}
+
+			return w.Error()
+		})
+		if err != nil {
+			// once headers are sent the client sees a truncated file
+			return err
+		}
+
+		if !res.Committed {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+
+		w.Flush()
+
+		return nil
+	}
+}
+
+func ImportWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		mode, err := parseBatchMode(c.QueryParam("mode"))
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		body, err := importSource(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+		defer body.Close()
+
+		items, err := decodeCSVBatch(body)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		system, err := requestUnits(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		batchToMetric(system, items)
+
+		ctx := c.Request().Context()
+		attributeItems(ctx, items)
+
+		result, err := weatherService.AddWeathers(ctx, items, mode)
+		if err != nil && !errors.Is(err, service.ErrBatchRejected) {
+			return err
+		}
+
+		status := http.StatusOK
+		if err != nil {
+			status = http.StatusUnprocessableEntity
+		}
+
+		return c.JSONPretty(status, importResult(result), "\t")
+	}
+}
+
+// importSource accepts either a multipart upload in the "file" field or a raw
+// CSV request body.
+func importSource(c echo.Context) (io.ReadCloser, error) {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	if !strings.HasPrefix(contentType, echo.MIMEMultipartForm) {
+		return c.Request().Body, nil
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+
+	return file, nil
+}
+
+// decodeCSVBatch maps the header row to weather fields and converts every
+// following record into a batch item indexed by its line number. Malformed
+// rows become item errors; a malformed header fails the whole import.
+func decodeCSVBatch(r io.Reader) ([]*models.BatchItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns, err := parseCSVHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*models.BatchItem
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		// a record that failed to parse has no field positions to ask for
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				return nil, fmt.Errorf("line %d: %w", perr.Line, err)
+			}
+
+			return nil, fmt.Errorf("failed to read record: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(items) == service.MaxBatchSize {
+			return nil, fmt.Errorf("import exceeds %d rows", service.MaxBatchSize)
+		}
+
+		item := &models.BatchItem{Index: line, Weather: &models.Weather{}}
+
+		if err != nil {
+			item.Error = fmt.Sprintf("expected %d fields, got %d", len(columns), len(record))
+		} else if err := csvToWeather(columns, record, item.Weather); err != nil {
+			item.Error = err.Error()
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func parseCSVHeader(header []string) ([]string, error) {
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+
+	for i, h := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+
+		if !slices.Contains(csvColumns, name) {
+			return nil, fmt.Errorf("unknown column %q", h)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q", h)
+		}
+
+		seen[name] = true
+		columns[i] = name
+	}
+
+	for _, name := range requiredCSVColumns {
+		if !seen[name] {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	return columns, nil
+}
+
+func csvToWeather(columns, record []string, ob *models.Weather) error {
+	for i, column := range columns {
+		value := strings.TrimSpace(record[i])
+
+		var err error
+
+		switch column {
+		case "id":
+			// ids are assigned by the database, exported files may carry them
+		case "timestamp":
+			ob.Timestamp, err = parseCSVTime(value)
+		case "city":
+			ob.City = value
+		case "country":
+			ob.Country = value
+		case "temperature":
+			ob.Temperature, err = parseCSVFloat(value)
+		case "humidity":
+			ob.Humidity, err = parseCSVFloat(value)
+		case "pressure":
+			ob.Pressure, err = parseCSVFloat(value)
+		case "wind_speed":
+			ob.WindSpeed, err = parseCSVFloat(value)
+		case "weather_status":
+			ob.WeatherStatus = value
+		case "latitude":
+			ob.Latitude, err = parseCSVOptionalFloat(value)
+		case "longitude":
+			ob.Longitude, err = parseCSVOptionalFloat(value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+
+	return nil
+}
+
+func parseCSVTime(value string) (time.Time, error) {
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+func parseCSVFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, errors.New("is required")
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+
+	return f, nil
+}
+
+func parseCSVOptionalFloat(value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := parseCSVFloat(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func weatherToCSV(ob *models.Weather) []string {
+	return []string{
+		strconv.Itoa(ob.ID),
+		ob.Timestamp.Format(time.RFC3339),
+		ob.City,
+		ob.Country,
+		strconv.FormatFloat(ob.Temperature, 'f', -1, 64),
+		strconv.FormatFloat(ob.Humidity, 'f', -1, 64),
+		strconv.FormatFloat(ob.Pressure, 'f', -1, 64),
+		strconv.FormatFloat(ob.WindSpeed, 'f', -1, 64),
+		ob.WeatherStatus,
+		formatCSVOptionalFloat(ob.Latitude),
+		formatCSVOptionalFloat(ob.Longitude),
+	}
+}
+
+func formatCSVOptionalFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func importResult(result *models.BatchResult) EchoImportResult {
+	res := EchoImportResult{
+		Inserted: result.Inserted,
+		Failed:   result.Failed,
+		Errors:   []EchoLineError{},
+	}
+
+	for _, item := range result.Items {
+		if item.Error != "" {
+			res.Errors = append(res.Errors, EchoLineError{Line: item.Index, Error: item.Error})
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,335 @@
+package weather_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name                string
+		query               string
+		repoBuilder         serviceBuilder
+		expectedStatusCode  int
+		expectedContentType string
+		expectedResponse    string
+	}
+
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lat, lon := 53.9, 27.5667
+
+	tt := []testCase{
+		{
+			name:  "Streams rows",
+			query: "?format=csv&city=Minsk",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ExportWeathers", mock.Anything, mock.MatchedBy(func(f models.WeatherFilter) bool {
+						return f.City != nil && *f.City == "Minsk"
+					}), mock.Anything).
+					Return(func(_ context.Context, _ models.WeatherFilter, fn func(*models.Weather) error) error {
+						return fn(&models.Weather{
+							ID:            1,
+							Timestamp:     tm,
+							City:          "Minsk",
+							Country:       "Belarus",
+							Temperature:   -2.5,
+							Humidity:      80,
+							Pressure:      1013,
+							WindSpeed:     4,
+							WeatherStatus: "Snow, light",
+							Latitude:      &lat,
+							Longitude:     &lon,
+						})
+					}).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponse: "id,timestamp,city,country,temperature,humidity,pressure,wind_speed,weather_status,latitude,longitude\n" +
+				"1,2024-05-01T12:00:00Z,Minsk,Belarus,-2.5,80,1013,4,\"Snow, light\",53.9,27.5667\n",
+		},
+		{
+			name: "Empty result",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ExportWeathers", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode:  http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedResponse:    "id,timestamp,city,country,temperature,humidity,pressure,wind_speed,weather_status,latitude,longitude\n",
+		},
+		{
+			name: "Service error before first row",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ExportWeathers", mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("database error")).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode:  http.StatusInternalServerError,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedResponse:    "{\n\t\"message\": \"The server is temporarily unavailable, please try again later\"\n}\n",
+		},
+		{
+			name:  "Unsupported format",
+			query: "?format=xlsx",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode:  http.StatusBadRequest,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedResponse:    "{\n\t\"message\": \"invalid query: unsupported format \\\"xlsx\\\"\"\n}\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weathers/export"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.ExportWeathersHandler(tc.repoBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), tc.expectedContentType))
+			assert.Equal(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+// csvHeader lists the columns every import must have.
+const csvHeader = "timestamp,city,country,temperature,humidity,pressure,wind_speed,weather_status\n"
+
+func TestImportWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		inputBody          string
+		repoBuilder        serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:  "Line-numbered errors",
+			query: "?mode=partial",
+			inputBody: "Timestamp,City,Country,Temperature,Humidity,Pressure,Wind Speed,Weather Status\n" +
+				"2024-05-01 12:00:00,Minsk,Belarus,3.5,80,1010,4,rain\n" +
+				"yesterday,Minsk,Belarus,3,80,1010,4,rain\n" +
+				"2024-05-01T13:00:00Z,Minsk,Belarus,warm,80,1010,4,rain\n" +
+				"2024-05-01T14:00:00Z,Minsk,Belarus,3,,1010,4,rain\n" +
+				"2024-05-01T15:00:00Z,Minsk\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.MatchedBy(func(items []*models.BatchItem) bool {
+						return len(items) == 5 &&
+							items[0].Index == 2 && items[0].Error == "" &&
+							items[0].Weather.WindSpeed == 4 &&
+							items[1].Error == `column timestamp: invalid timestamp "yesterday"` &&
+							items[2].Error == `column temperature: invalid number "warm"` &&
+							items[3].Error == "column humidity: is required" &&
+							items[4].Index == 6 && items[4].Error == "expected 8 fields, got 2"
+					}), models.BatchPartial).
+					Return(&models.BatchResult{
+						Inserted: 1,
+						Failed:   4,
+						Items: []models.BatchItemResult{
+							{Index: 2, ID: 1},
+							{Index: 3, Error: `column timestamp: invalid timestamp "yesterday"`},
+							{Index: 4, Error: `column temperature: invalid number "warm"`},
+							{Index: 5, Error: "column humidity: is required"},
+							{Index: 6, Error: "expected 8 fields, got 2"},
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"inserted": 1,
+				"failed": 4,
+				"errors": [
+					{"line": 3, "error": "column timestamp: invalid timestamp \"yesterday\""},
+					{"line": 4, "error": "column temperature: invalid number \"warm\""},
+					{"line": 5, "error": "column humidity: is required"},
+					{"line": 6, "error": "expected 8 fields, got 2"}
+				]
+			}`,
+		},
+		{
+			name:      "Rejected atomic import",
+			inputBody: csvHeader + "2024-05-01T12:00:00Z,,Belarus,3,80,1010,4,rain\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeathers", mock.Anything, mock.Anything, models.BatchAtomic).
+					Return(&models.BatchResult{
+						Failed: 1,
+						Items:  []models.BatchItemResult{{Index: 2, Error: "city is required"}},
+					}, service.ErrBatchRejected).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse:   `{"inserted": 0, "failed": 1, "errors": [{"line": 2, "error": "city is required"}]}`,
+		},
+		{
+			name:      "Unknown column",
+			inputBody: "timestamp,city,country,visibility\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input: unknown column \"visibility\""}`,
+		},
+		{
+			name:      "Missing required column",
+			inputBody: "timestamp,city\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input: missing required column \"country\""}`,
+		},
+		{
+			name:      "Missing measurement column",
+			inputBody: "timestamp,city,country,temperature,humidity,pressure,weather_status\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input: missing required column \"wind_speed\""}`,
+		},
+		{
+			name:      "Malformed quoted field",
+			inputBody: csvHeader + "\"2024\"x,Minsk,BY,1,80,1010,4,rain\n",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: `{"message": "invalid input: line 2: parse error on line 2, column 6: ` +
+				`extraneous or missing \" in quoted-field"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/weathers/import"+tc.query,
+				strings.NewReader(tc.inputBody),
+			)
+			req.Header.Set(echo.HeaderContentType, "text/csv")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.ImportWeathersHandler(tc.repoBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestImportWeathersHandlerMultipart(t *testing.T) {
+	t.Parallel()
+
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "weathers.csv")
+	require.NoError(t, err)
+
+	_, err = file.Write([]byte("\ufeff" + csvHeader + "2024-05-01T12:00:00Z,Minsk,Belarus,3,80,1010,4,rain\n"))
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+
+	mockService := NewMockWeatherService(t)
+	mockService.
+		On("AddWeathers", mock.Anything, mock.MatchedBy(func(items []*models.BatchItem) bool {
+			return len(items) == 1 && items[0].Weather.City == "Minsk"
+		}), models.BatchAtomic).
+		Return(&models.BatchResult{Inserted: 1, Items: []models.BatchItemResult{{Index: 2, ID: 7}}}, nil).
+		Once()
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodPost, "/weathers/import", &body)
+	req.Header.Set(echo.HeaderContentType, form.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err = weather.ImportWeathersHandler(mockService)(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"inserted": 1, "failed": 0, "errors": []}`, rec.Body.String())
+}
@@ -0,0 +1,140 @@
+package weather_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDerivedMetricsWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		target             string
+		handler            func(s weather.WeatherService) echo.HandlerFunc
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tm := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	observation := func() *models.Weather {
+		return &models.Weather{
+			ID:            1,
+			City:          "Madrid",
+			Country:       "Spain",
+			Timestamp:     tm,
+			Temperature:   30,
+			Humidity:      70,
+			Pressure:      1010,
+			WindSpeed:     2,
+			WeatherStatus: models.StatusClear,
+			Version:       1,
+		}
+	}
+
+	const expectedObservation = `{
+		"id": 1,
+		"city": "Madrid",
+		"country": "Spain",
+		"timestamp": "2024-07-01T12:00:00Z",
+		"temperature": 30,
+		"humidity": 70,
+		"pressure": 1010,
+		"wind_speed": 2,
+		"weather_status": "clear",
+		"version": 1,
+		"derived": {
+			"heat_index": 35.04,
+			"apparent_temperature": 34.38,
+			"dew_point": 23.93,
+			"absolute_humidity": 21.18,
+			"beaufort": 2
+		}
+	}`
+
+	tt := []testCase{
+		{
+			name:    "Get with derived metrics",
+			target:  "/weather/1?derived=true",
+			handler: weather.GetWeatherHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.On("GetWeather", mock.Anything, 1).Return(observation(), nil).Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   expectedObservation,
+		},
+		{
+			name:    "List with derived metrics",
+			target:  "/weathers?derived=1",
+			handler: weather.ListWeathersHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathers", mock.Anything, mock.Anything).
+					Return(&models.WeatherPage{Items: []*models.Weather{observation()}}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": [` + expectedObservation + `]}`,
+		},
+		{
+			name:    "Invalid derived flag",
+			target:  "/weather/1?derived=maybe",
+			handler: weather.GetWeatherHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: `{
+				"message": "invalid query: failed to parse derived=\"maybe\": strconv.ParseBool: parsing \"maybe\": invalid syntax"
+			}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetPath("/weather/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			handler := tc.handler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package weather
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerETag    = "ETag"
+	headerIfMatch = "If-Match"
+)
+
+var (
+	errIfMatchRequired = errors.New("missing If-Match header")
+	errInvalidIfMatch  = errors.New("invalid If-Match header")
+)
+
+func setETag(c echo.Context, ob *models.Weather) {
+	c.Response().Header().Set(headerETag, strconv.Quote(strconv.Itoa(ob.Version)))
+}
+
+// parseIfMatch returns the version from a strong entity tag. The "*" wildcard
+// matches any version and is returned as zero.
+func parseIfMatch(c echo.Context) (int, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if value == "" {
+		return 0, errIfMatchRequired
+	}
+
+	if value == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return 0, fmt.Errorf("%w: %s", errInvalidIfMatch, value)
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("%w: %s", errInvalidIfMatch, value)
+	}
+
+	return version, nil
+}
+
+func ifMatchError(c echo.Context, err error) error {
+	if errors.Is(err, errIfMatchRequired) {
+		return c.JSONPretty(http.StatusPreconditionRequired, EchoMessage{Msg: err.Error()}, "\t")
+	}
+
+	return c.JSONPretty(http.StatusBadRequest, EchoMessage{Msg: err.Error()}, "\t")
+}
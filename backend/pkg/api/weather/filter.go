@@ -0,0 +1,219 @@
+package weather
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+var sortFields = map[string]models.WeatherSortField{
+	string(models.SortByTimestamp):   models.SortByTimestamp,
+	string(models.SortByTemperature): models.SortByTemperature,
+	string(models.SortByHumidity):    models.SortByHumidity,
+	string(models.SortByPressure):    models.SortByPressure,
+	string(models.SortByWindSpeed):   models.SortByWindSpeed,
+}
+
+func parseWeatherFilter(c echo.Context) (models.WeatherFilter, error) {
+	var (
+		filter models.WeatherFilter
+		err    error
+	)
+
+	if filter.LocationID, err = queryInt(c, "location_id"); err != nil {
+		return filter, err
+	}
+
+	filter.City = queryString(c, "city")
+	filter.Country = queryString(c, "country")
+	filter.WeatherStatus = queryString(c, "weather_status")
+
+	if filter.From, err = queryTime(c, "from"); err != nil {
+		return filter, err
+	}
+
+	if filter.To, err = queryTime(c, "to"); err != nil {
+		return filter, err
+	}
+
+	ranges := []struct {
+		name string
+		rng  *models.FloatRange
+	}{
+		{name: "temperature", rng: &filter.Temperature},
+		{name: "humidity", rng: &filter.Humidity},
+		{name: "pressure", rng: &filter.Pressure},
+		{name: "wind_speed", rng: &filter.WindSpeed},
+	}
+
+	for _, r := range ranges {
+		if r.rng.Min, err = queryFloat(c, "min_"+r.name); err != nil {
+			return filter, err
+		}
+
+		if r.rng.Max, err = queryFloat(c, "max_"+r.name); err != nil {
+			return filter, err
+		}
+	}
+
+	if sort := c.QueryParam("sort"); sort != "" {
+		field, ok := sortFields[sort]
+		if !ok {
+			return filter, fmt.Errorf("unsupported sort field %q", sort)
+		}
+
+		filter.SortBy = field
+	}
+
+	switch order := c.QueryParam("order"); order {
+	case "":
+	case string(models.SortAsc), string(models.SortDesc):
+		filter.Order = models.SortOrder(order)
+	default:
+		return filter, fmt.Errorf("unsupported order %q", order)
+	}
+
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		after, err := decodeCursor(cursor)
+		if err != nil {
+			return filter, err
+		}
+
+		if filter.SortBy == "" {
+			filter.SortBy = after.SortBy
+		}
+
+		if filter.Order == "" {
+			filter.Order = after.Order
+		}
+
+		if filter.SortBy != after.SortBy || filter.Order != after.Order {
+			return filter, fmt.Errorf("cursor does not match sort=%s order=%s", filter.SortBy, filter.Order)
+		}
+
+		filter.After = after
+	}
+
+	return filter, nil
+}
+
+func encodeCursor(cursor *models.WeatherCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	raw, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (*models.WeatherCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var cursor models.WeatherCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	if _, ok := sortFields[string(cursor.SortBy)]; !ok {
+		return nil, fmt.Errorf("invalid cursor: unsupported sort field %q", cursor.SortBy)
+	}
+
+	if cursor.Order != models.SortAsc && cursor.Order != models.SortDesc {
+		return nil, fmt.Errorf("invalid cursor: unsupported order %q", cursor.Order)
+	}
+
+	return &cursor, nil
+}
+
+func queryLimit(c echo.Context) (int, error) {
+	value := c.QueryParam("limit")
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("invalid limit=%q", value)
+	}
+
+	return limit, nil
+}
+
+func queryString(c echo.Context, name string) *string {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func queryInt(c echo.Context, name string) (*int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s=%q: %w", name, value, err)
+	}
+
+	return &i, nil
+}
+
+func queryFloat(c echo.Context, name string) (*float64, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s=%q: %w", name, value, err)
+	}
+
+	return &f, nil
+}
+
+func queryTime(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s=%q: %w", name, value, err)
+	}
+
+	return &t, nil
+}
+
+func queryBool(c echo.Context, name string) (bool, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s=%q: %w", name, value, err)
+	}
+
+	return b, nil
+}
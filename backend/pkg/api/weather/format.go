@@ -0,0 +1,99 @@
+package weather
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/units"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	tzUTC   = "utc"
+	tzLocal = "local"
+)
+
+// responseFormat is how stored observations are rendered: in which unit
+// system, and whether times are given in UTC or in the local time of their
+// location.
+type responseFormat struct {
+	units units.System
+	local bool
+}
+
+// parseResponseFormat reads the units preference and the tz query parameter,
+// which is utc (default) or local.
+func parseResponseFormat(c echo.Context) (responseFormat, error) {
+	var (
+		format responseFormat
+		err    error
+	)
+
+	if format.units, err = responseUnits(c); err != nil {
+		return format, err
+	}
+
+	switch tz := c.QueryParam("tz"); tz {
+	case "", tzUTC:
+	case tzLocal:
+		format.local = true
+	default:
+		return format, fmt.Errorf("invalid query: unsupported tz %q", tz)
+	}
+
+	return format, nil
+}
+
+func formatError(c echo.Context, err error) error {
+	return c.JSONPretty(http.StatusBadRequest, EchoMessage{Msg: err.Error()}, "\t")
+}
+
+func (f responseFormat) weathers(obs ...*models.Weather) {
+	convertWeathers(f.units, obs...)
+
+	if !f.local {
+		return
+	}
+
+	zones := timeZones{}
+	for _, ob := range obs {
+		if ob.Location != nil {
+			ob.Timestamp = ob.Timestamp.In(zones.load(ob.Location.Timezone))
+		}
+	}
+}
+
+func (f responseFormat) stats(stats []*models.WeatherStats) {
+	convertStats(f.units, stats)
+
+	if !f.local {
+		return
+	}
+
+	zones := timeZones{}
+	for _, st := range stats {
+		st.PeriodStart = st.PeriodStart.In(zones.load(st.Timezone))
+	}
+}
+
+// timeZones caches loaded locations for the duration of a response. Names that
+// fail to load fall back to UTC.
+type timeZones map[string]*time.Location
+
+func (z timeZones) load(name string) *time.Location {
+	if loc, ok := z[name]; ok {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	z[name] = loc
+
+	return loc
+}
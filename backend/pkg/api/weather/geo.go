@@ -0,0 +1,175 @@
+package weather
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoWeathers struct {
+	Items []*models.Weather `json:"items"`
+}
+
+func NearWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := parseNearFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		obList, err := weatherService.ListWeathersNear(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		format.weathers(obList...)
+
+		return c.JSONPretty(http.StatusOK, EchoWeathers{Items: obList}, "\t")
+	}
+}
+
+func BBoxWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := parseBBoxFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		obList, err := weatherService.ListWeathersInBBox(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		format.weathers(obList...)
+
+		return c.JSONPretty(http.StatusOK, EchoWeathers{Items: obList}, "\t")
+	}
+}
+
+func parseNearFilter(c echo.Context) (models.NearFilter, error) {
+	var (
+		filter models.NearFilter
+		err    error
+	)
+
+	if filter.Latitude, err = queryCoordinate(c, "lat", 90); err != nil {
+		return filter, err
+	}
+
+	if filter.Longitude, err = queryCoordinate(c, "lon", 180); err != nil {
+		return filter, err
+	}
+
+	radius, err := queryFloat(c, "radius_km")
+	switch {
+	case err != nil:
+		return filter, err
+	case radius == nil:
+		return filter, fmt.Errorf("missing radius_km")
+	case math.IsNaN(*radius) || *radius <= 0 || *radius > service.MaxRadiusKm:
+		return filter, fmt.Errorf("radius_km must be greater than 0 and at most %d", service.MaxRadiusKm)
+	}
+
+	filter.RadiusKm = *radius
+
+	if filter.From, filter.To, err = queryTimeRange(c); err != nil {
+		return filter, err
+	}
+
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseBBoxFilter reads min_lat, min_lon, max_lat and max_lon. min_lon greater
+// than max_lon selects a box crossing the antimeridian.
+func parseBBoxFilter(c echo.Context) (models.BBoxFilter, error) {
+	var (
+		filter models.BBoxFilter
+		err    error
+	)
+
+	coords := []struct {
+		name  string
+		limit float64
+		dst   *float64
+	}{
+		{name: "min_lat", limit: 90, dst: &filter.MinLatitude},
+		{name: "min_lon", limit: 180, dst: &filter.MinLongitude},
+		{name: "max_lat", limit: 90, dst: &filter.MaxLatitude},
+		{name: "max_lon", limit: 180, dst: &filter.MaxLongitude},
+	}
+
+	for _, coord := range coords {
+		if *coord.dst, err = queryCoordinate(c, coord.name, coord.limit); err != nil {
+			return filter, err
+		}
+	}
+
+	if filter.MinLatitude > filter.MaxLatitude {
+		return filter, fmt.Errorf("min_lat must not be greater than max_lat")
+	}
+
+	if filter.From, filter.To, err = queryTimeRange(c); err != nil {
+		return filter, err
+	}
+
+	if filter.Limit, err = queryLimit(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// queryCoordinate reads a required value in [-limit, limit] degrees.
+func queryCoordinate(c echo.Context, name string, limit float64) (float64, error) {
+	value, err := queryFloat(c, name)
+	switch {
+	case err != nil:
+		return 0, err
+	case value == nil:
+		return 0, fmt.Errorf("missing %s", name)
+	case math.IsNaN(*value) || *value < -limit || *value > limit:
+		return 0, fmt.Errorf("%s must be between %g and %g", name, -limit, limit)
+	}
+
+	return *value, nil
+}
+
+func queryTimeRange(c echo.Context) (from, to *time.Time, err error) {
+	if from, err = queryTime(c, "from"); err != nil {
+		return nil, nil, err
+	}
+
+	if to, err = queryTime(c, "to"); err != nil {
+		return nil, nil, err
+	}
+
+	return from, to, nil
+}
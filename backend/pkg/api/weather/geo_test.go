@@ -0,0 +1,267 @@
+package weather_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNearWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	lat, lon, distance := 53.9, 27.5667, 1.25
+
+	tt := []testCase{
+		{
+			name:  "Valid query",
+			query: "?lat=53.9&lon=27.56&radius_km=10&limit=5",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathersNear", mock.Anything, models.NearFilter{
+						Latitude:  53.9,
+						Longitude: 27.56,
+						RadiusKm:  10,
+						Limit:     5,
+					}).
+					Return([]*models.Weather{
+						{
+							ID:            1,
+							LocationID:    3,
+							Timestamp:     tm,
+							City:          "Minsk",
+							Country:       "Belarus",
+							Latitude:      &lat,
+							Longitude:     &lon,
+							Distance:      &distance,
+							Temperature:   20,
+							Humidity:      50,
+							Pressure:      1013,
+							WindSpeed:     5,
+							WeatherStatus: "clear",
+							Version:       1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{"items": [{
+				"id": 1,
+				"location_id": 3,
+				"timestamp": "2024-05-01T12:00:00Z",
+				"city": "Minsk",
+				"country": "Belarus",
+				"latitude": 53.9,
+				"longitude": 27.5667,
+				"distance": 1.25,
+				"temperature": 20,
+				"humidity": 50,
+				"pressure": 1013,
+				"wind_speed": 5,
+				"weather_status": "clear",
+				"version": 1
+			}]}`,
+		},
+		{
+			name:  "Imperial units",
+			query: "?lat=53.9&lon=27.56&radius_km=10&limit=5&units=imperial",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathersNear", mock.Anything, mock.Anything).
+					Return([]*models.Weather{
+						{
+							ID:            1,
+							LocationID:    3,
+							Timestamp:     tm,
+							City:          "Minsk",
+							Country:       "Belarus",
+							Distance:      &distance,
+							Temperature:   20,
+							Humidity:      50,
+							Pressure:      1013,
+							WindSpeed:     5,
+							WeatherStatus: "clear",
+							Version:       1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{"items": [{
+				"id": 1,
+				"location_id": 3,
+				"timestamp": "2024-05-01T12:00:00Z",
+				"city": "Minsk",
+				"country": "Belarus",
+				"distance": 0.776714,
+				"temperature": 68,
+				"humidity": 50,
+				"pressure": 29.913873,
+				"wind_speed": 11.184681,
+				"weather_status": "clear",
+				"version": 1
+			}]}`,
+		},
+		{
+			name:  "Missing latitude",
+			query: "?lon=27.56&radius_km=10",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: missing lat"}`,
+		},
+		{
+			name:  "Latitude out of range",
+			query: "?lat=91&lon=27.56&radius_km=10",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: lat must be between -90 and 90"}`,
+		},
+		{
+			name:  "Radius too large",
+			query: "?lat=53.9&lon=27.56&radius_km=5000",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: radius_km must be greater than 0 and at most 1000"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weathers/near"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.NearWeathersHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestBBoxWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:  "Box across the antimeridian",
+			query: "?min_lat=-20&min_lon=170&max_lat=-10&max_lon=-170",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathersInBBox", mock.Anything, models.BBoxFilter{
+						BBox: models.BBox{
+							MinLatitude:  -20,
+							MinLongitude: 170,
+							MaxLatitude:  -10,
+							MaxLongitude: -170,
+						},
+					}).
+					Return([]*models.Weather{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": []}`,
+		},
+		{
+			name:  "Inverted latitudes",
+			query: "?min_lat=10&min_lon=0&max_lat=-10&max_lon=10",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: min_lat must not be greater than max_lat"}`,
+		},
+		{
+			name:  "Missing corner",
+			query: "?min_lat=-10&min_lon=0&max_lat=10",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: missing max_lon"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weathers/bbox"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.BBoxWeathersHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
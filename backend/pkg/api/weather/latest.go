@@ -0,0 +1,40 @@
+package weather
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LatestWeathersHandler returns the newest observation per location. The city
+// parameter may be repeated to select several cities.
+func LatestWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter := models.LatestFilter{
+			Country: queryString(c, "country"),
+		}
+
+		for _, city := range c.QueryParams()["city"] {
+			if city = strings.TrimSpace(city); city != "" {
+				filter.Cities = append(filter.Cities, city)
+			}
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		obList, err := weatherService.LatestWeathers(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		format.weathers(obList...)
+
+		return c.JSONPretty(http.StatusOK, EchoWeathers{Items: obList}, "\t")
+	}
+}
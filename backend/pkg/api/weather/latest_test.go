@@ -0,0 +1,163 @@
+package weather_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLatestWeathersHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tm := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tt := []testCase{
+		{
+			name:  "Selected cities",
+			query: "?country=Belarus&city=Minsk&city=%20Brest%20&city=",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				country := "Belarus"
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("LatestWeathers", mock.Anything, models.LatestFilter{
+						Country: &country,
+						Cities:  []string{"Minsk", "Brest"},
+					}).
+					Return([]*models.Weather{
+						{
+							ID:            7,
+							LocationID:    3,
+							Timestamp:     tm,
+							City:          "Minsk",
+							Country:       "Belarus",
+							Temperature:   20,
+							Humidity:      50,
+							Pressure:      1013,
+							WindSpeed:     5,
+							WeatherStatus: "clear",
+							Version:       1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{"items": [{
+				"id": 7,
+				"location_id": 3,
+				"timestamp": "2024-05-01T12:00:00Z",
+				"city": "Minsk",
+				"country": "Belarus",
+				"temperature": 20,
+				"humidity": 50,
+				"pressure": 1013,
+				"wind_speed": 5,
+				"weather_status": "clear",
+				"version": 1
+			}]}`,
+		},
+		{
+			name:  "Local time",
+			query: "?tz=local",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("LatestWeathers", mock.Anything, models.LatestFilter{}).
+					Return([]*models.Weather{
+						{
+							ID:         7,
+							LocationID: 3,
+							Location: &models.Location{
+								ID:       3,
+								Name:     "Minsk",
+								Country:  "Belarus",
+								Timezone: "Europe/Minsk",
+							},
+							Timestamp:     tm,
+							City:          "Minsk",
+							Country:       "Belarus",
+							WeatherStatus: "clear",
+							Version:       1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{"items": [{
+				"id": 7,
+				"location_id": 3,
+				"location": {"id": 3, "name": "Minsk", "country": "Belarus", "timezone": "Europe/Minsk"},
+				"timestamp": "2024-05-01T15:00:00+03:00",
+				"city": "Minsk",
+				"country": "Belarus",
+				"temperature": 0,
+				"humidity": 0,
+				"pressure": 0,
+				"wind_speed": 0,
+				"weather_status": "clear",
+				"version": 1
+			}]}`,
+		},
+		{
+			name: "Every location",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("LatestWeathers", mock.Anything, models.LatestFilter{}).
+					Return([]*models.Weather{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": []}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weather/latest"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.LatestWeathersHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -42,9 +42,39 @@ func (_m *MockWeatherService) AddWeather(ctx context.Context, ob *models.Weather
 	return r0, r1
 }
 
-// DeleteWeather provides a mock function with given fields: ctx, id
-func (_m *MockWeatherService) DeleteWeather(ctx context.Context, id int) (*models.Weather, error) {
-	ret := _m.Called(ctx, id)
+// AddWeathers provides a mock function with given fields: ctx, items, mode
+func (_m *MockWeatherService) AddWeathers(ctx context.Context, items []*models.BatchItem, mode models.BatchMode) (*models.BatchResult, error) {
+	ret := _m.Called(ctx, items, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWeathers")
+	}
+
+	var r0 *models.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.BatchItem, models.BatchMode) (*models.BatchResult, error)); ok {
+		return rf(ctx, items, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.BatchItem, models.BatchMode) *models.BatchResult); ok {
+		r0 = rf(ctx, items, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*models.BatchItem, models.BatchMode) error); ok {
+		r1 = rf(ctx, items, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteWeather provides a mock function with given fields: ctx, id, version
+func (_m *MockWeatherService) DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteWeather")
@@ -52,19 +82,19 @@ func (_m *MockWeatherService) DeleteWeather(ctx context.Context, id int) (*model
 
 	var r0 *models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Weather, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Weather, error)); ok {
+		return rf(ctx, id, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Weather); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Weather); ok {
+		r0 = rf(ctx, id, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,6 +102,24 @@ func (_m *MockWeatherService) DeleteWeather(ctx context.Context, id int) (*model
 	return r0, r1
 }
 
+// ExportWeathers provides a mock function with given fields: ctx, filter, fn
+func (_m *MockWeatherService) ExportWeathers(ctx context.Context, filter models.WeatherFilter, fn func(*models.Weather) error) error {
+	ret := _m.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWeathers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter, func(*models.Weather) error) error); ok {
+		r0 = rf(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetWeather provides a mock function with given fields: ctx, id
 func (_m *MockWeatherService) GetWeather(ctx context.Context, id int) (*models.Weather, error) {
 	ret := _m.Called(ctx, id)
@@ -102,29 +150,149 @@ func (_m *MockWeatherService) GetWeather(ctx context.Context, id int) (*models.W
 	return r0, r1
 }
 
-// ListWeathers provides a mock function with given fields: ctx
-func (_m *MockWeatherService) ListWeathers(ctx context.Context) ([]*models.Weather, error) {
-	ret := _m.Called(ctx)
+// LatestWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherService) LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestWeathers")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.LatestFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.LatestFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathers provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherService) ListWeathers(ctx context.Context, filter models.WeatherFilter) (*models.WeatherPage, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListWeathers")
 	}
 
+	var r0 *models.WeatherPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) (*models.WeatherPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.WeatherFilter) *models.WeatherPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WeatherPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.WeatherFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersInBBox provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherService) ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersInBBox")
+	}
+
 	var r0 []*models.Weather
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*models.Weather, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*models.Weather); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, models.BBoxFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Weather)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, models.BBoxFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWeathersNear provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherService) ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeathersNear")
+	}
+
+	var r0 []*models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) ([]*models.Weather, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.NearFilter) []*models.Weather); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.NearFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchWeather provides a mock function with given fields: ctx, id, version, patch
+func (_m *MockWeatherService) PatchWeather(ctx context.Context, id int, version int, patch *models.WeatherPatch) (*models.Weather, error) {
+	ret := _m.Called(ctx, id, version, patch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchWeather")
+	}
+
+	var r0 *models.Weather
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) (*models.Weather, error)); ok {
+		return rf(ctx, id, version, patch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *models.WeatherPatch) *models.Weather); ok {
+		r0 = rf(ctx, id, version, patch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Weather)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *models.WeatherPatch) error); ok {
+		r1 = rf(ctx, id, version, patch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -150,6 +318,36 @@ func (_m *MockWeatherService) UpdateWeather(ctx context.Context, ob *models.Weat
 	return r0
 }
 
+// WeatherStats provides a mock function with given fields: ctx, filter
+func (_m *MockWeatherService) WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WeatherStats")
+	}
+
+	var r0 []*models.WeatherStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) ([]*models.WeatherStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatsFilter) []*models.WeatherStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WeatherStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockWeatherService creates a new instance of MockWeatherService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockWeatherService(t interface {
@@ -0,0 +1,107 @@
+package weather
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+const mimeApplicationMergePatch = "application/merge-patch+json"
+
+// weatherPatchBody accepts the read-only members of Weather, so a document
+// fetched with GET can be sent back as a patch, and drops them. The version is
+// checked through If-Match only.
+type weatherPatchBody struct {
+	models.WeatherPatch
+
+	ID       json.RawMessage `json:"id"`
+	Location json.RawMessage `json:"location"`
+	APIKeyID json.RawMessage `json:"api_key_id"`
+	Version  json.RawMessage `json:"version"`
+	Distance json.RawMessage `json:"distance"`
+	Derived  json.RawMessage `json:"derived"`
+}
+
+// PatchWeatherHandler applies a JSON Merge Patch (RFC 7386). Members that are
+// absent keep their stored value; unknown members are rejected.
+func PatchWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		mediaType, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+		if mediaType != mimeApplicationMergePatch && mediaType != echo.MIMEApplicationJSON {
+			return c.JSONPretty(
+				http.StatusUnsupportedMediaType,
+				EchoMessage{Msg: fmt.Sprintf("content type must be %s", mimeApplicationMergePatch)},
+				"\t",
+			)
+		}
+
+		id, err := parseID(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("parseID: %s", err)},
+				"\t",
+			)
+		}
+
+		var body weatherPatchBody
+
+		dec := json.NewDecoder(c.Request().Body)
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&body); err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid input: %s", err)},
+				"\t",
+			)
+		}
+
+		inputUnits, err := requestUnits(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		patch := body.WeatherPatch
+		patchToMetric(inputUnits, &patch)
+
+		var cities []string
+
+		switch {
+		case patch.City.Ptr() != nil:
+			cities = append(cities, patch.City.Value)
+		case patch.LocationID.Set:
+			// a bare location_id carries no city, as on other writes
+			cities = append(cities, "")
+		}
+
+		if err := checkCityScope(c.Request().Context(), weatherService, id, cities...); err != nil {
+			return scopeError(c, err)
+		}
+
+		version, err := parseIfMatch(c)
+		if err != nil {
+			return ifMatchError(c, err)
+		}
+
+		ob, err := weatherService.PatchWeather(c.Request().Context(), id, version, &patch)
+		if err != nil {
+			return err
+		}
+
+		setETag(c, ob)
+		format.weathers(ob)
+
+		return c.JSONPretty(http.StatusOK, ob, "\t")
+	}
+}
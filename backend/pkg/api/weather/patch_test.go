@@ -0,0 +1,261 @@
+package weather_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPatchWeatherHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		contentType        string
+		ifMatch            string
+		inputBody          string
+		repoBuilder        serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+		expectedETag       string
+	}
+
+	tt := []testCase{
+		{
+			name:        "Zero temperature",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"2"`,
+			inputBody:   `{"temperature": 0}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("PatchWeather", mock.Anything, 1, 2, mock.MatchedBy(func(p *models.WeatherPatch) bool {
+						return p.Temperature.Set && p.Temperature.Value == 0 && !p.City.Set
+					})).
+					Return(&models.Weather{
+						ID:          1,
+						Timestamp:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+						City:        "Minsk",
+						Country:     "Belarus",
+						Temperature: 0,
+						Version:     3,
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"id": 1,
+				"timestamp": "2024-05-01T12:00:00Z",
+				"city": "Minsk",
+				"country": "Belarus",
+				"temperature": 0,
+				"humidity": 0,
+				"pressure": 0,
+				"wind_speed": 0,
+				"weather_status": "",
+				"version": 3
+			}`,
+			expectedETag: `"3"`,
+		},
+		{
+			name:        "Echoed read-only members",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"2"`,
+			inputBody: `{
+				"id": 1,
+				"location_id": 4,
+				"location": {"id": 3, "name": "Minsk", "country": "Belarus"},
+				"humidity": 40,
+				"version": 2,
+				"derived": {"beaufort": 3}
+			}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("PatchWeather", mock.Anything, 1, 2, mock.MatchedBy(func(p *models.WeatherPatch) bool {
+						return p.LocationID.Set && p.LocationID.Value == 4 &&
+							p.Humidity.Set && p.Humidity.Value == 40
+					})).
+					Return(&models.Weather{
+						ID:         1,
+						Timestamp:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+						LocationID: 4,
+						City:       "Grodno",
+						Country:    "Belarus",
+						Humidity:   40,
+						Version:    3,
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"id": 1,
+				"timestamp": "2024-05-01T12:00:00Z",
+				"location_id": 4,
+				"city": "Grodno",
+				"country": "Belarus",
+				"temperature": 0,
+				"humidity": 40,
+				"pressure": 0,
+				"wind_speed": 0,
+				"weather_status": "",
+				"version": 3
+			}`,
+			expectedETag: `"3"`,
+		},
+		{
+			name:        "Null member",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"2"`,
+			inputBody:   `{"city": null}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				verr := &service.ValidationError{Fields: []models.FieldError{
+					{Field: "city", Code: models.CodeRequired, Message: "must not be null"},
+				}}
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("PatchWeather", mock.Anything, 1, 2, mock.Anything).
+					Return(nil, verr).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: `{
+				"message": "validation failed",
+				"errors": [{"field": "city", "code": "required", "message": "must not be null"}]
+			}`,
+		},
+		{
+			name:        "Missing record",
+			contentType: echo.MIMEApplicationJSON,
+			ifMatch:     `"2"`,
+			inputBody:   `{"humidity": 40}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("PatchWeather", mock.Anything, 1, 2, mock.Anything).
+					Return(nil, repository.NewErrNotFound(1)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"message": "no record with id=1"}`,
+		},
+		{
+			name:        "Unknown member",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"2"`,
+			inputBody:   `{"temp": 3}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid input: json: unknown field \"temp\""}`,
+		},
+		{
+			name:        "Missing If-Match",
+			contentType: "application/merge-patch+json",
+			inputBody:   `{"humidity": 40}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusPreconditionRequired,
+			expectedResponse:   `{"message": "missing If-Match header"}`,
+		},
+		{
+			name:        "Stale version",
+			contentType: "application/merge-patch+json",
+			ifMatch:     `"2"`,
+			inputBody:   `{"humidity": 40}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("PatchWeather", mock.Anything, 1, 2, mock.Anything).
+					Return(nil, fmt.Errorf("failed to patch weather: %w", repository.ErrVersionConflict)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedResponse:   `{"message": "record has been modified"}`,
+		},
+		{
+			name:        "Unsupported media type",
+			contentType: echo.MIMETextPlain,
+			ifMatch:     `"2"`,
+			inputBody:   `{"humidity": 40}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedResponse:   `{"message": "content type must be application/merge-patch+json"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPatch,
+				"/weather/1",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req.Header.Set(echo.HeaderContentType, tc.contentType)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+			handler := weather.PatchWeatherHandler(tc.repoBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
+		})
+	}
+}
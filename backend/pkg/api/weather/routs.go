@@ -2,13 +2,13 @@ package weather
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
-	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -22,29 +22,124 @@ type EchoID struct {
 	ID int `json:"id"`
 }
 
+type EchoWeatherPage struct {
+	Items      []*models.Weather `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
 //go:generate mockery --name WeatherService --structname MockWeatherService --filename mock_weather_service_test.go --outpkg weather_test --output .
 type WeatherService interface {
 	AddWeather(ctx context.Context, ob *models.Weather) (int, error)
+	AddWeathers(
+		ctx context.Context,
+		items []*models.BatchItem,
+		mode models.BatchMode,
+	) (*models.BatchResult, error)
 	GetWeather(ctx context.Context, id int) (*models.Weather, error)
 	UpdateWeather(ctx context.Context, ob *models.Weather) error
-	DeleteWeather(ctx context.Context, id int) (*models.Weather, error)
-	ListWeathers(ctx context.Context) ([]*models.Weather, error)
+	PatchWeather(
+		ctx context.Context,
+		id int,
+		version int,
+		patch *models.WeatherPatch,
+	) (*models.Weather, error)
+	DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error)
+	ListWeathers(ctx context.Context, filter models.WeatherFilter) (*models.WeatherPage, error)
+	ListWeathersNear(ctx context.Context, filter models.NearFilter) ([]*models.Weather, error)
+	ListWeathersInBBox(ctx context.Context, filter models.BBoxFilter) ([]*models.Weather, error)
+	WeatherStats(ctx context.Context, filter models.StatsFilter) ([]*models.WeatherStats, error)
+	LatestWeathers(ctx context.Context, filter models.LatestFilter) ([]*models.Weather, error)
+	ExportWeathers(
+		ctx context.Context,
+		filter models.WeatherFilter,
+		fn func(ob *models.Weather) error,
+	) error
 }
 
 func RegisterWeatherRoutes(
 	ctx context.Context,
 	server *echo.Echo,
 	weatherService WeatherService,
+	authenticate echo.MiddlewareFunc,
 ) {
-	server.POST("/weather", AddWeatherHandler(weatherService))
-	server.GET("/weather/:id", GetWeatherHandler(weatherService))
-	server.PUT("/weather/:id", UpdateWeatherHandler(weatherService))
-	server.DELETE("/weather/:id", DeleteWeatherHandler(weatherService))
-	server.GET("/weathers", ListWeathersHandler(weatherService))
+	auth.RegisterRoutes(server, authenticate,
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/weather",
+			Handler:     AddWeatherHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherCreate},
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weather/latest",
+			Handler: LatestWeathersHandler(weatherService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weather/:id",
+			Handler: GetWeatherHandler(weatherService),
+		},
+		auth.Route{
+			Method:      http.MethodPut,
+			Path:        "/weather/:id",
+			Handler:     UpdateWeatherHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherUpdate},
+		},
+		auth.Route{
+			Method:      http.MethodPatch,
+			Path:        "/weather/:id",
+			Handler:     PatchWeatherHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherUpdate},
+		},
+		auth.Route{
+			Method:      http.MethodDelete,
+			Path:        "/weather/:id",
+			Handler:     DeleteWeatherHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherDelete},
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/weathers/batch",
+			Handler:     AddWeathersHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherCreate},
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weathers",
+			Handler: ListWeathersHandler(weatherService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weathers/near",
+			Handler: NearWeathersHandler(weatherService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weathers/bbox",
+			Handler: BBoxWeathersHandler(weatherService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weathers/stats",
+			Handler: WeatherStatsHandler(weatherService),
+		},
+		auth.Route{
+			Method:  http.MethodGet,
+			Path:    "/weathers/export",
+			Handler: ExportWeathersHandler(weatherService),
+		},
+		auth.Route{
+			Method:      http.MethodPost,
+			Path:        "/weathers/import",
+			Handler:     ImportWeathersHandler(weatherService),
+			Permissions: []models.Permission{models.PermissionWeatherCreate},
+		},
+	)
 
 	server.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins:                             []string{"http://localhost:3000"},
-		AllowMethods:                             []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
+		AllowMethods:                             []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE},
+		ExposeHeaders:                            []string{headerETag, headerContentUnits, echo.HeaderXRequestID},
 		AllowCredentials:                         true,
 		UnsafeWildcardOriginWithAllowCredentials: true,
 	}))
@@ -62,20 +157,36 @@ func AddWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
 			)
 		}
 
-		id, err := weatherService.AddWeather(c.Request().Context(), &ob)
+		system, err := requestUnits(c)
 		if err != nil {
-			c.Logger().Errorf("failed to add: %s", err)
-			return c.JSONPretty(
-				http.StatusInternalServerError,
-				EchoMessage{Msg: "The server is temporarily unavailable, please try again later"},
-				"\t",
-			)
+			return formatError(c, err)
+		}
+
+		weatherToMetric(system, &ob)
+
+		ctx := c.Request().Context()
+
+		if err := checkCityScope(ctx, weatherService, 0, ob.City); err != nil {
+			return scopeError(c, err)
+		}
+
+		ob.APIKeyID = nil
+		if principal, ok := auth.PrincipalFromContext(ctx); ok && principal.APIKeyID != 0 {
+			ob.APIKeyID = &principal.APIKeyID
+		}
+
+		id, err := weatherService.AddWeather(ctx, &ob)
+		if err != nil {
+			return err
 		}
 
 		return c.JSONPretty(http.StatusOK, EchoID{ID: id}, "\t")
 	}
 }
 
+// GetWeatherHandler returns a single observation. With derived=true the
+// response carries the derived metrics as well. With tz=local the timestamp is
+// given in the time zone of the observation's location.
 func GetWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		id, err := parseID(c)
@@ -87,24 +198,33 @@ func GetWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
 			)
 		}
 
-		ob, err := weatherService.GetWeather(c.Request().Context(), id)
+		derived, err := queryBool(c, "derived")
 		if err != nil {
-			if errors.As(err, &repository.ErrNotFound{}) {
-				return c.JSONPretty(
-					http.StatusNotFound,
-					EchoMessage{Msg: fmt.Sprintf("record with id=%d not found", id)},
-					"\t",
-				)
-			}
-
-			c.Logger().Errorf("failed to get: %s", err)
 			return c.JSONPretty(
-				http.StatusInternalServerError,
-				EchoMessage{Msg: "The server is temporarily unavailable, please try again later"},
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
 				"\t",
 			)
 		}
 
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		ob, err := weatherService.GetWeather(c.Request().Context(), id)
+		if err != nil {
+			return err
+		}
+
+		if derived {
+			service.AddDerived(ob)
+		}
+
+		format.weathers(ob)
+
+		setETag(c, ob)
+
 		return c.JSONPretty(http.StatusOK, ob, "\t")
 	}
 }
@@ -130,26 +250,32 @@ func UpdateWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
 			)
 		}
 
+		system, err := requestUnits(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		weatherToMetric(system, &ob)
+
+		if err := checkCityScope(c.Request().Context(), weatherService, id, ob.City); err != nil {
+			return scopeError(c, err)
+		}
+
+		version, err := parseIfMatch(c)
+		if err != nil {
+			return ifMatchError(c, err)
+		}
+
 		ob.ID = id
+		ob.Version = version
 
 		err = weatherService.UpdateWeather(c.Request().Context(), &ob)
 		if err != nil {
-			if errors.As(err, &repository.ErrNotFound{}) {
-				return c.JSONPretty(
-					http.StatusNotFound,
-					EchoMessage{Msg: fmt.Sprintf("record with id=%d not found", id)},
-					"\t",
-				)
-			}
-
-			c.Logger().Errorf("failed to update: %s", err)
-			return c.JSONPretty(
-				http.StatusInternalServerError,
-				EchoMessage{Msg: "The server is temporarily unavailable, please try again later"},
-				"\t",
-			)
+			return err
 		}
 
+		setETag(c, &ob)
+
 		return c.JSONPretty(http.StatusOK, EchoMessage{Msg: "successfully updated"}, "\t")
 	}
 }
@@ -165,41 +291,82 @@ func DeleteWeatherHandler(weatherService WeatherService) echo.HandlerFunc {
 			)
 		}
 
-		ob, err := weatherService.DeleteWeather(c.Request().Context(), id)
+		if err := checkCityScope(c.Request().Context(), weatherService, id); err != nil {
+			return scopeError(c, err)
+		}
+
+		version, err := parseIfMatch(c)
 		if err != nil {
-			if errors.As(err, &repository.ErrNotFound{}) {
-				return c.JSONPretty(
-					http.StatusNotFound,
-					EchoMessage{Msg: fmt.Sprintf("record with id=%d not found", id)},
-					"\t",
-				)
-			}
+			return ifMatchError(c, err)
+		}
 
-			c.Logger().Errorf("failed to delete: %s", err)
-			return c.JSONPretty(
-				http.StatusInternalServerError,
-				EchoMessage{Msg: "The server is temporarily unavailable, please try again later"},
-				"\t",
-			)
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
 		}
 
+		ob, err := weatherService.DeleteWeather(c.Request().Context(), id, version)
+		if err != nil {
+			return err
+		}
+
+		format.weathers(ob)
+
 		return c.JSONPretty(http.StatusOK, ob, "\t")
 	}
 }
 
+// ListWeathersHandler returns a page of observations. With derived=true
+// every item carries the derived metrics as well. Value ranges are given in
+// the units of the response.
 func ListWeathersHandler(weatherService WeatherService) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		obs, err := weatherService.ListWeathers(c.Request().Context())
+		filter, err := parseWeatherFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		derived, err := queryBool(c, "derived")
 		if err != nil {
-			c.Logger().Errorf("failed to get list of weathers: %s", err)
 			return c.JSONPretty(
-				http.StatusInternalServerError,
-				EchoMessage{Msg: "The server is temporarily unavailable, please try again later"},
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
 				"\t",
 			)
 		}
 
-		return c.JSONPretty(http.StatusOK, obs, "\t")
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		filterToMetric(format.units, &filter)
+
+		page, err := weatherService.ListWeathers(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		if derived {
+			service.AddDerived(page.Items...)
+		}
+
+		format.weathers(page.Items...)
+
+		items := page.Items
+		if items == nil {
+			items = []*models.Weather{}
+		}
+
+		return c.JSONPretty(
+			http.StatusOK,
+			EchoWeatherPage{Items: items, NextCursor: encodeCursor(page.Next)},
+			"\t",
+		)
 	}
 }
 
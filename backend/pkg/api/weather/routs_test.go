@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/service"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
 	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
 
 	"github.com/labstack/echo/v4"
@@ -78,6 +81,32 @@ func TestAddWeatherHandlerWithBuilder(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   `{"message":"The server is temporarily unavailable, please try again later"}`,
 		},
+		{
+			name:      "Validation error",
+			inputBody: `{"humidity": 500}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeather", mock.Anything, mock.Anything).
+					Return(0, &service.ValidationError{Fields: []models.FieldError{
+						{Field: "city", Code: models.CodeRequired, Message: "is required"},
+						{Field: "humidity", Code: models.CodeOutOfRange, Message: "must be between 0 and 100"},
+					}}).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusUnprocessableEntity,
+			expectedResponse: `{
+				"message": "validation failed",
+				"errors": [
+					{"field": "city", "code": "required", "message": "is required"},
+					{"field": "humidity", "code": "out_of_range", "message": "must be between 0 and 100"}
+				]
+			}`,
+		},
 	}
 
 	for _, tc := range tt {
@@ -98,8 +127,9 @@ func TestAddWeatherHandlerWithBuilder(t *testing.T) {
 			c := e.NewContext(req, rec)
 			handler := weather.AddWeatherHandler(mockService)
 
-			err := handler(c)
-			require.NoError(t, err)
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
 		})
@@ -115,6 +145,7 @@ func TestGetWeatherHandlerWithBuilder(t *testing.T) {
 		repoBuilder        serviceBuilder
 		expectedStatusCode int
 		expectedResponse   string
+		expectedETag       string
 	}
 
 	tm := time.Now()
@@ -139,6 +170,7 @@ func TestGetWeatherHandlerWithBuilder(t *testing.T) {
 						Pressure:      1013,
 						WindSpeed:     5.4,
 						WeatherStatus: "Clear",
+						Version:       3,
 					}, nil).
 					Once()
 
@@ -154,8 +186,10 @@ func TestGetWeatherHandlerWithBuilder(t *testing.T) {
                 "humidity": 80,
                 "pressure": 1013,
 								"wind_speed": 5.4,
-                "weather_status": "Clear"
+                "weather_status": "Clear",
+                "version": 3
             }`,
+			expectedETag: `"3"`,
 		},
 		{
 			name:    "Invalid ID",
@@ -178,14 +212,14 @@ func TestGetWeatherHandlerWithBuilder(t *testing.T) {
 				mockService := NewMockWeatherService(t)
 				mockService.
 					On("GetWeather", mock.Anything, 2).
-					Return(&models.Weather{}, repository.ErrNotFound{}).
+					Return(&models.Weather{}, repository.NewErrNotFound(2)).
 					Once()
 
 				return mockService
 			},
 			expectedStatusCode: http.StatusNotFound,
 			expectedResponse: `{
-				"message": "record with id=2 not found"
+				"message": "no record with id=2"
 			}`,
 		},
 		{
@@ -228,10 +262,12 @@ func TestGetWeatherHandlerWithBuilder(t *testing.T) {
 
 			handler := weather.GetWeatherHandler(mockService)
 
-			err := handler(c)
-			require.NoError(t, err)
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
 		})
 	}
 }
@@ -242,6 +278,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 	type testCase struct {
 		name               string
 		inputID            string
+		ifMatch            string
 		inputWeatherObs    *models.Weather
 		repoBuilder        serviceBuilder
 		expectedStatusCode int
@@ -252,6 +289,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 		{
 			name:    "Valid ID and valid data",
 			inputID: "1",
+			ifMatch: `"1"`,
 			inputWeatherObs: &models.Weather{
 				Temperature:   22.5,
 				Humidity:      70,
@@ -274,6 +312,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 							Pressure:      1012,
 							WindSpeed:     3.5,
 							WeatherStatus: "Cloudy",
+							Version:       1,
 						},
 					).
 					Return(nil).
@@ -289,6 +328,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 		{
 			name:            "Invalid ID",
 			inputID:         "invalid_id",
+			ifMatch:         `"1"`,
 			inputWeatherObs: &models.Weather{},
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
@@ -302,6 +342,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 		{
 			name:    "Not Found",
 			inputID: "2",
+			ifMatch: `"1"`,
 			inputWeatherObs: &models.Weather{
 				Temperature:   22.5,
 				Humidity:      70,
@@ -324,21 +365,23 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 							Pressure:      1012,
 							WindSpeed:     3.5,
 							WeatherStatus: "Cloudy",
+							Version:       1,
 						},
 					).
-					Return(repository.ErrNotFound{}).
+					Return(repository.NewErrNotFound(2)).
 					Once()
 
 				return mockService
 			},
 			expectedStatusCode: http.StatusNotFound,
 			expectedResponse: `{
-				"message": "record with id=2 not found"
+				"message": "no record with id=2"
 			}`,
 		},
 		{
 			name:    "Service error",
 			inputID: "3",
+			ifMatch: `"1"`,
 			inputWeatherObs: &models.Weather{
 				Temperature:   22.5,
 				Humidity:      70,
@@ -361,6 +404,7 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 							Pressure:      1012,
 							WindSpeed:     3.5,
 							WeatherStatus: "Cloudy",
+							Version:       1,
 						},
 					).
 					Return(errors.New("database error")).
@@ -373,9 +417,48 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 				"message": "The server is temporarily unavailable, please try again later"
 			}`,
 		},
+		{
+			name:    "Missing If-Match",
+			inputID: "1",
+			inputWeatherObs: &models.Weather{
+				Temperature: 22.5,
+			},
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusPreconditionRequired,
+			expectedResponse: `{
+				"message": "missing If-Match header"
+			}`,
+		},
+		{
+			name:    "Stale version",
+			inputID: "4",
+			ifMatch: `"1"`,
+			inputWeatherObs: &models.Weather{
+				Temperature: 22.5,
+			},
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("UpdateWeather", mock.Anything, &models.Weather{ID: 4, Temperature: 22.5, Version: 1}).
+					Return(fmt.Errorf("failed to update weather: %w", repository.ErrVersionConflict)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedResponse: `{
+				"message": "record has been modified"
+			}`,
+		},
 		{
 			name:            "Invalid JSON format",
 			inputID:         "1",
+			ifMatch:         `"1"`,
 			inputWeatherObs: &models.Weather{},
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
@@ -410,6 +493,10 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodPut, "/weather", bytes.NewReader(reqBody))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
 			rec := httptest.NewRecorder()
 
 			c := e.NewContext(req, rec)
@@ -419,8 +506,9 @@ func TestUpdateWeatherHandlerWithBuilder(t *testing.T) {
 
 			handler := weather.UpdateWeatherHandler(mockService)
 
-			err := handler(c)
-			require.NoError(t, err)
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
 		})
@@ -433,6 +521,7 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 	type testCase struct {
 		name               string
 		inputID            string
+		ifMatch            string
 		repoBuilder        serviceBuilder
 		expectedStatusCode int
 		expectedResponse   string
@@ -444,12 +533,13 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 		{
 			name:    "Valid ID",
 			inputID: "1",
+			ifMatch: `"1"`,
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
 
 				mockService := NewMockWeatherService(t)
 				mockService.
-					On("DeleteWeather", mock.Anything, 1).
+					On("DeleteWeather", mock.Anything, 1, 1).
 					Return(&models.Weather{
 						ID:            1,
 						City:          "Berlin",
@@ -475,12 +565,14 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 				"humidity": 80,
 				"pressure": 1013,
 				"wind_speed": 5.4,
-				"weather_status": "Clear"
+				"weather_status": "Clear",
+				"version": 0
 			}`,
 		},
 		{
 			name:    "Invalid ID",
 			inputID: "invalid_id",
+			ifMatch: `"1"`,
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
 				return NewMockWeatherService(t)
@@ -493,31 +585,33 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 		{
 			name:    "Not Found",
 			inputID: "2",
+			ifMatch: `"1"`,
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
 
 				mockService := NewMockWeatherService(t)
 				mockService.
-					On("DeleteWeather", mock.Anything, 2).
-					Return(&models.Weather{}, repository.ErrNotFound{}).
+					On("DeleteWeather", mock.Anything, 2, 1).
+					Return(&models.Weather{}, repository.NewErrNotFound(2)).
 					Once()
 
 				return mockService
 			},
 			expectedStatusCode: http.StatusNotFound,
 			expectedResponse: `{
-				"message": "record with id=2 not found"
+				"message": "no record with id=2"
 			}`,
 		},
 		{
 			name:    "Service error",
 			inputID: "3",
+			ifMatch: `"1"`,
 			repoBuilder: func(t *testing.T) weather.WeatherService {
 				t.Helper()
 
 				mockService := NewMockWeatherService(t)
 				mockService.
-					On("DeleteWeather", mock.Anything, 3).
+					On("DeleteWeather", mock.Anything, 3, 1).
 					Return(&models.Weather{}, errors.New("database error")).
 					Once()
 
@@ -528,6 +622,68 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 				"message": "The server is temporarily unavailable, please try again later"
 			}`,
 		},
+		{
+			name:    "Stale version",
+			inputID: "4",
+			ifMatch: `"1"`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("DeleteWeather", mock.Anything, 4, 1).
+					Return(nil, fmt.Errorf("failed to delete weather: %w", repository.ErrVersionConflict)).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusPreconditionFailed,
+			expectedResponse: `{
+				"message": "record has been modified"
+			}`,
+		},
+		{
+			name:    "Wildcard If-Match",
+			inputID: "5",
+			ifMatch: "*",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("DeleteWeather", mock.Anything, 5, 0).
+					Return(&models.Weather{ID: 5, Timestamp: tm}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"id": 5,
+				"city": "",
+				"country": "",
+				"timestamp": "` + tm.Format(time.RFC3339Nano) + `",
+				"temperature": 0,
+				"humidity": 0,
+				"pressure": 0,
+				"wind_speed": 0,
+				"weather_status": "",
+				"version": 0
+			}`,
+		},
+		{
+			name:    "Weak entity tag",
+			inputID: "6",
+			ifMatch: `W/"1"`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse: `{
+				"message": "invalid If-Match header: W/\"1\""
+			}`,
+		},
 	}
 
 	for _, tc := range tt {
@@ -540,6 +696,10 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 
 			req := httptest.NewRequest(http.MethodDelete, "/weather", nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
 			rec := httptest.NewRecorder()
 
 			c := e.NewContext(req, rec)
@@ -549,8 +709,9 @@ func TestDeleteWeatherHandlerWithBuilder(t *testing.T) {
 
 			handler := weather.DeleteWeatherHandler(mockService)
 
-			err := handler(c)
-			require.NoError(t, err)
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
 		})
@@ -562,12 +723,16 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 
 	type testCase struct {
 		name               string
+		query              string
 		repoBuilder        serviceBuilder
 		expectedStatusCode int
 		expectedResponse   string
 	}
 
 	tm := time.Now()
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	city := "Berlin"
+	minTemp := -5.5
 
 	tt := []testCase{
 		{
@@ -577,29 +742,31 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 
 				mockService := NewMockWeatherService(t)
 				mockService.
-					On("ListWeathers", mock.Anything).
-					Return([]*models.Weather{
-						{
-							ID:            1,
-							City:          "Berlin",
-							Country:       "Germany",
-							Timestamp:     tm,
-							Temperature:   25.5,
-							Humidity:      80,
-							Pressure:      1013,
-							WindSpeed:     5.4,
-							WeatherStatus: "Clear",
-						},
-						{
-							ID:            2,
-							City:          "Paris",
-							Country:       "France",
-							Timestamp:     tm.Add(-time.Hour * 24 * 365),
-							Temperature:   15.0,
-							Humidity:      75,
-							Pressure:      1012,
-							WindSpeed:     3.2,
-							WeatherStatus: "Cloudy",
+					On("ListWeathers", mock.Anything, models.WeatherFilter{}).
+					Return(&models.WeatherPage{
+						Items: []*models.Weather{
+							{
+								ID:            1,
+								City:          "Berlin",
+								Country:       "Germany",
+								Timestamp:     tm,
+								Temperature:   25.5,
+								Humidity:      80,
+								Pressure:      1013,
+								WindSpeed:     5.4,
+								WeatherStatus: "Clear",
+							},
+							{
+								ID:            2,
+								City:          "Paris",
+								Country:       "France",
+								Timestamp:     tm.Add(-time.Hour * 24 * 365),
+								Temperature:   15.0,
+								Humidity:      75,
+								Pressure:      1012,
+								WindSpeed:     3.2,
+								WeatherStatus: "Cloudy",
+							},
 						},
 					}, nil).
 					Once()
@@ -607,7 +774,7 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 				return mockService
 			},
 			expectedStatusCode: http.StatusOK,
-			expectedResponse: `[
+			expectedResponse: `{"items": [
 				{
 					"id": 1,
 					"city": "Berlin",
@@ -617,7 +784,8 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 					"humidity": 80,
 					"pressure": 1013,
 					"wind_speed": 5.4,
-					"weather_status": "Clear"
+					"weather_status": "Clear",
+					"version": 0
 				},
 				{
 					"id": 2,
@@ -628,9 +796,131 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 					"humidity": 75,
 					"pressure": 1012,
 					"wind_speed": 3.2,
-					"weather_status": "Cloudy"
+					"weather_status": "Cloudy",
+					"version": 0
 				}
-			]`,
+			]}`,
+		},
+		{
+			name:  "Filters and next cursor",
+			query: "?city=Berlin&from=2024-05-01T00:00:00Z&min_temperature=-5.5&sort=temperature&order=asc&limit=1",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathers", mock.Anything, models.WeatherFilter{
+						City:        &city,
+						From:        &from,
+						Temperature: models.FloatRange{Min: &minTemp},
+						SortBy:      models.SortByTemperature,
+						Order:       models.SortAsc,
+						Limit:       1,
+					}).
+					Return(&models.WeatherPage{
+						Items: []*models.Weather{},
+						Next: &models.WeatherCursor{
+							SortBy: models.SortByTemperature,
+							Order:  models.SortAsc,
+							ID:     7,
+							Value:  -1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": [], "next_cursor": "eyJzIjoidGVtcGVyYXR1cmUiLCJvIjoiYXNjIiwiaSI6NywidCI6IjAwMDEtMDEtMDFUMDA6MDA6MDBaIiwidiI6LTF9"}`,
+		},
+		{
+			name:  "Cursor sets sort and order",
+			query: "?cursor=eyJzIjoidGVtcGVyYXR1cmUiLCJvIjoiYXNjIiwiaSI6NywidCI6IjAwMDEtMDEtMDFUMDA6MDA6MDBaIiwidiI6LTF9",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathers", mock.Anything, models.WeatherFilter{
+						SortBy: models.SortByTemperature,
+						Order:  models.SortAsc,
+						After: &models.WeatherCursor{
+							SortBy: models.SortByTemperature,
+							Order:  models.SortAsc,
+							ID:     7,
+							Value:  -1,
+						},
+					}).
+					Return(&models.WeatherPage{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": []}`,
+		},
+		{
+			name:  "Cursor does not match sort",
+			query: "?sort=humidity&cursor=eyJzIjoidGVtcGVyYXR1cmUiLCJvIjoiYXNjIiwiaSI6NywidCI6IjAwMDEtMDEtMDFUMDA6MDA6MDBaIiwidiI6LTF9",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: cursor does not match sort=humidity order=asc"}`,
+		},
+		{
+			name:  "Unsupported sort field",
+			query: "?sort=city",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported sort field \"city\""}`,
+		},
+		{
+			name:  "Location filter",
+			query: "?location_id=3",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				locationID := 3
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathers", mock.Anything, models.WeatherFilter{LocationID: &locationID}).
+					Return(&models.WeatherPage{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": []}`,
+		},
+		{
+			name:  "Invalid location filter",
+			query: "?location_id=minsk",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: failed to parse location_id=\"minsk\": strconv.Atoi: parsing \"minsk\": invalid syntax"}`,
+		},
+		{
+			name:  "Invalid limit",
+			query: "?limit=-1",
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: invalid limit=\"-1\""}`,
 		},
 		{
 			name: "Service error",
@@ -639,7 +929,7 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 
 				mockService := NewMockWeatherService(t)
 				mockService.
-					On("ListWeathers", mock.Anything).
+					On("ListWeathers", mock.Anything, mock.Anything).
 					Return(nil, errors.New("database error")).
 					Once()
 
@@ -660,7 +950,7 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 
 			e := echo.New()
 
-			req := httptest.NewRequest(http.MethodGet, "/weathers", nil)
+			req := httptest.NewRequest(http.MethodGet, "/weathers"+tc.query, nil)
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 
@@ -668,8 +958,9 @@ func TestListWeathersHandlerWithBuilder(t *testing.T) {
 
 			handler := weather.ListWeathersHandler(mockService)
 
-			err := handler(c)
-			require.NoError(t, err)
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
 			assert.Equal(t, tc.expectedStatusCode, rec.Code)
 			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
 		})
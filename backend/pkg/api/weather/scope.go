@@ -0,0 +1,54 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+var errCityNotAllowed = errors.New("api key is not allowed to write observations for city")
+
+// checkCityScope verifies that a city-scoped API key may write the given
+// cities and, when id is set, the city of the stored observation. Writes that
+// name only a location_id carry no city and are rejected for such keys.
+func checkCityScope(
+	ctx context.Context,
+	weatherService WeatherService,
+	id int,
+	cities ...string,
+) error {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok || principal.APIKeyID == 0 {
+		return nil
+	}
+
+	if id != 0 {
+		ob, err := weatherService.GetWeather(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		cities = append(cities, ob.City)
+	}
+
+	for _, city := range cities {
+		if !principal.CanAccessCity(city) {
+			return fmt.Errorf("%w %q", errCityNotAllowed, city)
+		}
+	}
+
+	return nil
+}
+
+func scopeError(c echo.Context, err error) error {
+	if errors.Is(err, errCityNotAllowed) {
+		return c.JSONPretty(http.StatusForbidden, EchoMessage{Msg: err.Error()}, "\t")
+	}
+
+	return err
+}
@@ -0,0 +1,119 @@
+package weather_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/auth"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var stationPrincipal = &models.Principal{
+	Username:    "minsk-station",
+	Permissions: []models.Permission{models.PermissionWeatherCreate, models.PermissionWeatherDelete},
+	APIKeyID:    3,
+	Cities:      []string{"Minsk"},
+}
+
+func TestAddWeatherHandlerWithAPIKey(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		inputBody          string
+		repoBuilder        serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	tt := []testCase{
+		{
+			name:      "City in scope",
+			inputBody: `{"city": "minsk", "temperature": 3, "api_key_id": 99}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeather", mock.Anything, mock.MatchedBy(func(ob *models.Weather) bool {
+						return ob.APIKeyID != nil && *ob.APIKeyID == 3
+					})).
+					Return(1, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"id":1}`,
+		},
+		{
+			name:      "City out of scope",
+			inputBody: `{"city": "Berlin", "temperature": 3}`,
+			repoBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"message":"api key is not allowed to write observations for city \"Berlin\""}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(
+				http.MethodPost,
+				"/weather",
+				bytes.NewReader([]byte(tc.inputBody)),
+			)
+			req = req.WithContext(auth.WithPrincipal(req.Context(), stationPrincipal))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.AddWeatherHandler(tc.repoBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestDeleteWeatherHandlerWithAPIKey(t *testing.T) {
+	t.Parallel()
+
+	mockService := NewMockWeatherService(t)
+	mockService.
+		On("GetWeather", mock.Anything, 5).
+		Return(&models.Weather{ID: 5, City: "Berlin"}, nil).
+		Once()
+
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodDelete, "/weather/5", nil)
+	req = req.WithContext(auth.WithPrincipal(req.Context(), stationPrincipal))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("5")
+
+	err := weather.DeleteWeatherHandler(mockService)(c)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockService.AssertNotCalled(t, "DeleteWeather", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,74 @@
+package weather
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type EchoWeatherStats struct {
+	Bucket models.StatsBucket     `json:"bucket"`
+	Items  []*models.WeatherStats `json:"items"`
+}
+
+func WeatherStatsHandler(weatherService WeatherService) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := parseStatsFilter(c)
+		if err != nil {
+			return c.JSONPretty(
+				http.StatusBadRequest,
+				EchoMessage{Msg: fmt.Sprintf("invalid query: %s", err)},
+				"\t",
+			)
+		}
+
+		format, err := parseResponseFormat(c)
+		if err != nil {
+			return formatError(c, err)
+		}
+
+		stats, err := weatherService.WeatherStats(c.Request().Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		format.stats(stats)
+
+		return c.JSONPretty(http.StatusOK, EchoWeatherStats{Bucket: filter.Bucket, Items: stats}, "\t")
+	}
+}
+
+func parseStatsFilter(c echo.Context) (models.StatsFilter, error) {
+	filter := models.StatsFilter{Bucket: models.BucketDay}
+
+	var err error
+
+	if filter.LocationID, err = queryInt(c, "location_id"); err != nil {
+		return filter, err
+	}
+
+	filter.City = queryString(c, "city")
+	filter.Country = queryString(c, "country")
+
+	if filter.From, filter.To, err = queryTimeRange(c); err != nil {
+		return filter, err
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("from must be before to")
+	}
+
+	if bucket := c.QueryParam("bucket"); bucket != "" {
+		if !slices.Contains(models.StatsBuckets, models.StatsBucket(bucket)) {
+			return filter, fmt.Errorf("unsupported bucket %q", bucket)
+		}
+
+		filter.Bucket = models.StatsBucket(bucket)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,194 @@
+package weather_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWeatherStatsHandlerWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		query              string
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+	}
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)
+
+	tt := []testCase{
+		{
+			name:  "Weekly stats for a city",
+			query: "?city=Minsk&bucket=week&from=2024-05-01T00:00:00Z&to=2024-05-08T00:00:00Z",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				city := "Minsk"
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("WeatherStats", mock.Anything, models.StatsFilter{
+						City:   &city,
+						From:   &from,
+						To:     &to,
+						Bucket: models.BucketWeek,
+					}).
+					Return([]*models.WeatherStats{
+						{
+							LocationID:  3,
+							City:        "Minsk",
+							Country:     "Belarus",
+							Timezone:    "Europe/Minsk",
+							PeriodStart: time.Date(2024, 4, 28, 21, 0, 0, 0, time.UTC),
+							Count:       2,
+							Temperature: models.MetricStats{Min: 10, Max: 14, Mean: 12, Stddev: 2},
+							Humidity:    models.MetricStats{Min: 50, Max: 50, Mean: 50},
+							Pressure:    models.MetricStats{Min: 1010, Max: 1012, Mean: 1011, Stddev: 1},
+							WindSpeed:   models.MetricStats{Min: 3, Max: 5, Mean: 4, Stddev: 1},
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"bucket": "week",
+				"items": [{
+					"location_id": 3,
+					"city": "Minsk",
+					"country": "Belarus",
+					"timezone": "Europe/Minsk",
+					"period_start": "2024-04-28T21:00:00Z",
+					"count": 2,
+					"temperature": {"min": 10, "max": 14, "mean": 12, "stddev": 2},
+					"humidity": {"min": 50, "max": 50, "mean": 50, "stddev": 0},
+					"pressure": {"min": 1010, "max": 1012, "mean": 1011, "stddev": 1},
+					"wind_speed": {"min": 3, "max": 5, "mean": 4, "stddev": 1}
+				}]
+			}`,
+		},
+		{
+			name: "Daily by default",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("WeatherStats", mock.Anything, models.StatsFilter{Bucket: models.BucketDay}).
+					Return([]*models.WeatherStats{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"bucket": "day", "items": []}`,
+		},
+		{
+			name:  "Local period starts",
+			query: "?location_id=3&tz=local",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("WeatherStats", mock.Anything, mock.Anything).
+					Return([]*models.WeatherStats{
+						{
+							LocationID:  3,
+							City:        "Minsk",
+							Country:     "Belarus",
+							Timezone:    "Europe/Minsk",
+							PeriodStart: time.Date(2024, 4, 28, 21, 0, 0, 0, time.UTC),
+							Count:       1,
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"bucket": "day",
+				"items": [{
+					"location_id": 3,
+					"city": "Minsk",
+					"country": "Belarus",
+					"timezone": "Europe/Minsk",
+					"period_start": "2024-04-29T00:00:00+03:00",
+					"count": 1,
+					"temperature": {"min": 0, "max": 0, "mean": 0, "stddev": 0},
+					"humidity": {"min": 0, "max": 0, "mean": 0, "stddev": 0},
+					"pressure": {"min": 0, "max": 0, "mean": 0, "stddev": 0},
+					"wind_speed": {"min": 0, "max": 0, "mean": 0, "stddev": 0}
+				}]
+			}`,
+		},
+		{
+			name:  "Unsupported tz",
+			query: "?tz=mars",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported tz \"mars\""}`,
+		},
+		{
+			name:  "Unsupported bucket",
+			query: "?bucket=year",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported bucket \"year\""}`,
+		},
+		{
+			name:  "Empty time range",
+			query: "?from=2024-05-08T00:00:00Z&to=2024-05-01T00:00:00Z",
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: from must be before to"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(http.MethodGet, "/weathers/stats"+tc.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			handler := weather.WeatherStatsHandler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package weather
+
+import (
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/units"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// headerAcceptUnits asks for responses in a unit system.
+	headerAcceptUnits = "Accept-Units"
+	// headerContentUnits declares the unit system of a request or response
+	// body.
+	headerContentUnits = "Content-Units"
+)
+
+// responseUnits returns the unit system the client wants responses in: the
+// units query parameter, else the Accept-Units header, else metric. The
+// chosen system is announced in the Content-Units response header.
+func responseUnits(c echo.Context) (units.System, error) {
+	system, err := preferredUnits(c, headerAcceptUnits)
+	if err != nil {
+		return "", err
+	}
+
+	header := c.Response().Header()
+	header.Set(headerContentUnits, string(system))
+	header.Add(echo.HeaderVary, headerAcceptUnits)
+
+	return system, nil
+}
+
+// requestUnits returns the unit system of the request body: the units query
+// parameter, else the Content-Units header, else metric.
+func requestUnits(c echo.Context) (units.System, error) {
+	return preferredUnits(c, headerContentUnits)
+}
+
+func preferredUnits(c echo.Context, header string) (units.System, error) {
+	if value := c.QueryParam("units"); value != "" {
+		system, err := units.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid query: %w", err)
+		}
+
+		return system, nil
+	}
+
+	if value := c.Request().Header.Get(header); value != "" {
+		system, err := units.Parse(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s header: %w", header, err)
+		}
+
+		return system, nil
+	}
+
+	return units.Metric, nil
+}
+
+// convertWeathers converts stored observations, including their derived
+// metrics, to system.
+func convertWeathers(system units.System, obs ...*models.Weather) {
+	if system == units.Metric {
+		return
+	}
+
+	for _, ob := range obs {
+		ob.Temperature = system.Temperature(ob.Temperature)
+		ob.Pressure = system.Pressure(ob.Pressure)
+		ob.WindSpeed = system.WindSpeed(ob.WindSpeed)
+		convertOptional(&ob.Distance, system.Distance)
+
+		if d := ob.Derived; d != nil {
+			d.ApparentTemperature = system.Temperature(d.ApparentTemperature)
+			convertOptional(&d.HeatIndex, system.Temperature)
+			convertOptional(&d.WindChill, system.Temperature)
+			convertOptional(&d.DewPoint, system.Temperature)
+		}
+	}
+}
+
+// convertStats converts aggregated statistics to system. Standard deviations
+// are differences and so are never offset.
+func convertStats(system units.System, stats []*models.WeatherStats) {
+	if system == units.Metric {
+		return
+	}
+
+	for _, st := range stats {
+		convertMetricStats(&st.Temperature, system.Temperature, system.TemperatureDelta)
+		convertMetricStats(&st.Pressure, system.Pressure, system.Pressure)
+		convertMetricStats(&st.WindSpeed, system.WindSpeed, system.WindSpeed)
+	}
+}
+
+// weatherToMetric converts an observation given in system to the canonical
+// units the service stores.
+func weatherToMetric(system units.System, ob *models.Weather) {
+	ob.Temperature = system.MetricTemperature(ob.Temperature)
+	ob.Pressure = system.MetricPressure(ob.Pressure)
+	ob.WindSpeed = system.MetricWindSpeed(ob.WindSpeed)
+}
+
+func batchToMetric(system units.System, items []*models.BatchItem) {
+	for _, item := range items {
+		if item.Weather != nil {
+			weatherToMetric(system, item.Weather)
+		}
+	}
+}
+
+func patchToMetric(system units.System, patch *models.WeatherPatch) {
+	convertPatchValue(&patch.Temperature, system.MetricTemperature)
+	convertPatchValue(&patch.Pressure, system.MetricPressure)
+	convertPatchValue(&patch.WindSpeed, system.MetricWindSpeed)
+}
+
+// filterToMetric converts the value ranges of a filter given in system.
+func filterToMetric(system units.System, filter *models.WeatherFilter) {
+	convertOptional(&filter.Temperature.Min, system.MetricTemperature)
+	convertOptional(&filter.Temperature.Max, system.MetricTemperature)
+	convertOptional(&filter.Pressure.Min, system.MetricPressure)
+	convertOptional(&filter.Pressure.Max, system.MetricPressure)
+	convertOptional(&filter.WindSpeed.Min, system.MetricWindSpeed)
+	convertOptional(&filter.WindSpeed.Max, system.MetricWindSpeed)
+}
+
+func convertMetricStats(st *models.MetricStats, convert, delta func(float64) float64) {
+	st.Min = convert(st.Min)
+	st.Max = convert(st.Max)
+	st.Mean = convert(st.Mean)
+	st.Stddev = delta(st.Stddev)
+}
+
+func convertOptional(value **float64, convert func(float64) float64) {
+	if *value == nil {
+		return
+	}
+
+	converted := convert(**value)
+	*value = &converted
+}
+
+func convertPatchValue(o *models.Optional[float64], convert func(float64) float64) {
+	if o.Set && !o.Null {
+		o.Value = convert(o.Value)
+	}
+}
@@ -0,0 +1,249 @@
+package weather_test
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/apierror"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/api/weather"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWeatherUnitsWithBuilder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name               string
+		method             string
+		target             string
+		header             http.Header
+		body               string
+		handler            func(s weather.WeatherService) echo.HandlerFunc
+		serviceBuilder     serviceBuilder
+		expectedStatusCode int
+		expectedResponse   string
+		expectedUnits      string
+	}
+
+	tm := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	observation := func() *models.Weather {
+		return &models.Weather{
+			ID:            1,
+			City:          "Boston",
+			Country:       "USA",
+			Timestamp:     tm,
+			Temperature:   25,
+			Humidity:      60,
+			Pressure:      1013.25,
+			WindSpeed:     10,
+			WeatherStatus: models.StatusClear,
+			Version:       1,
+		}
+	}
+
+	getObservation := func(t *testing.T) weather.WeatherService {
+		t.Helper()
+
+		mockService := NewMockWeatherService(t)
+		mockService.On("GetWeather", mock.Anything, 1).Return(observation(), nil).Once()
+
+		return mockService
+	}
+
+	tt := []testCase{
+		{
+			name:               "Imperial by query",
+			method:             http.MethodGet,
+			target:             "/weather/1?units=imperial",
+			handler:            weather.GetWeatherHandler,
+			serviceBuilder:     getObservation,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"id": 1,
+				"city": "Boston",
+				"country": "USA",
+				"timestamp": "2024-07-01T12:00:00Z",
+				"temperature": 77,
+				"humidity": 60,
+				"pressure": 29.921255,
+				"wind_speed": 22.369363,
+				"weather_status": "clear",
+				"version": 1
+			}`,
+			expectedUnits: "imperial",
+		},
+		{
+			name:               "SI by header",
+			method:             http.MethodGet,
+			target:             "/weather/1",
+			header:             http.Header{"Accept-Units": {"SI"}},
+			handler:            weather.GetWeatherHandler,
+			serviceBuilder:     getObservation,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"id": 1,
+				"city": "Boston",
+				"country": "USA",
+				"timestamp": "2024-07-01T12:00:00Z",
+				"temperature": 298.15,
+				"humidity": 60,
+				"pressure": 101325,
+				"wind_speed": 10,
+				"weather_status": "clear",
+				"version": 1
+			}`,
+			expectedUnits: "si",
+		},
+		{
+			name:    "Unsupported units",
+			method:  http.MethodGet,
+			target:  "/weather/1?units=nautical",
+			handler: weather.GetWeatherHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				return NewMockWeatherService(t)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"message": "invalid query: unsupported units \"nautical\""}`,
+		},
+		{
+			name:   "Imperial input is stored as metric",
+			method: http.MethodPost,
+			target: "/weather",
+			header: http.Header{"Content-Units": {"imperial"}},
+			body: `{
+				"city": "Boston",
+				"country": "USA",
+				"timestamp": "2024-07-01T12:00:00Z",
+				"temperature": 77,
+				"humidity": 60,
+				"pressure": 29.921255,
+				"wind_speed": 22.369363,
+				"weather_status": "clear"
+			}`,
+			handler: weather.AddWeatherHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("AddWeather", mock.Anything, mock.MatchedBy(func(ob *models.Weather) bool {
+						return ob.Temperature == 25 && ob.Humidity == 60 &&
+							math.Abs(ob.Pressure-1013.25) < 1e-4 && ob.WindSpeed == 10
+					})).
+					Return(7, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"id": 7}`,
+		},
+		{
+			name:    "List ranges in response units",
+			method:  http.MethodGet,
+			target:  "/weathers?units=imperial&min_temperature=77",
+			handler: weather.ListWeathersHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("ListWeathers", mock.Anything, mock.MatchedBy(func(f models.WeatherFilter) bool {
+						return f.Temperature.Min != nil && *f.Temperature.Min == 25
+					})).
+					Return(&models.WeatherPage{}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"items": []}`,
+			expectedUnits:      "imperial",
+		},
+		{
+			name:    "Imperial stats",
+			method:  http.MethodGet,
+			target:  "/weathers/stats?units=imperial",
+			handler: weather.WeatherStatsHandler,
+			serviceBuilder: func(t *testing.T) weather.WeatherService {
+				t.Helper()
+
+				mockService := NewMockWeatherService(t)
+				mockService.
+					On("WeatherStats", mock.Anything, mock.Anything).
+					Return([]*models.WeatherStats{
+						{
+							LocationID:  1,
+							City:        "Boston",
+							Country:     "USA",
+							PeriodStart: tm,
+							Count:       2,
+							Temperature: models.MetricStats{Min: 20, Max: 30, Mean: 25, Stddev: 5},
+						},
+					}, nil).
+					Once()
+
+				return mockService
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse: `{
+				"bucket": "day",
+				"items": [{
+					"location_id": 1,
+					"city": "Boston",
+					"country": "USA",
+					"timezone": "",
+					"period_start": "2024-07-01T12:00:00Z",
+					"count": 2,
+					"temperature": {"min": 68, "max": 86, "mean": 77, "stddev": 9},
+					"humidity": {"min": 0, "max": 0, "mean": 0, "stddev": 0},
+					"pressure": {"min": 0, "max": 0, "mean": 0, "stddev": 0},
+					"wind_speed": {"min": 0, "max": 0, "mean": 0, "stddev": 0}
+				}]
+			}`,
+			expectedUnits: "imperial",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			e := echo.New()
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+			for key, values := range tc.header {
+				req.Header[key] = values
+			}
+
+			rec := httptest.NewRecorder()
+
+			c := e.NewContext(req, rec)
+			c.SetPath("/weather/:id")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			handler := tc.handler(tc.serviceBuilder(t))
+
+			if err := handler(c); err != nil {
+				apierror.Handler(err, c)
+			}
+
+			assert.Equal(t, tc.expectedStatusCode, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			assert.Equal(t, tc.expectedUnits, rec.Header().Get("Content-Units"))
+		})
+	}
+}
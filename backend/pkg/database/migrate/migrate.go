@@ -0,0 +1,371 @@
+// Package migrate applies the numbered SQL migrations of an fs.FS to a
+// Postgres database. Files are named NNNNNN_name.up.sql and
+// NNNNNN_name.down.sql; the current version is kept in schema_migrations in
+// the same format golang-migrate uses, so either tool can take over.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// lockID is the advisory lock key shared by every instance of the service.
+const lockID = 7_316_825_104
+
+var (
+	ErrDirty          = errors.New("database is dirty, a migration failed halfway and has to be fixed by hand")
+	ErrUnknownVersion = errors.New("unknown migration version")
+	ErrNoDown         = errors.New("migration has no down script")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version int    `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New reads the migrations in the root of fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads the migrations in the root of fsys sorted by version. Every
+// migration needs an up script; the down script is optional.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %q and %q", version, m.Name, match[2])
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	latest := 0
+	if n := len(m.migrations); n > 0 {
+		latest = m.migrations[n-1].Version
+	}
+
+	return m.Goto(ctx, latest)
+}
+
+// Down rolls back the given number of applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		target := 0
+		if i := m.index(current) - steps; i >= 0 {
+			target = m.migrations[i].Version
+		}
+
+		return m.migrate(ctx, conn, current, target)
+	})
+}
+
+// Goto migrates up or down to the given version; 0 rolls back everything.
+func (m *Migrator) Goto(ctx context.Context, version int) error {
+	if version != 0 && m.index(version) < 0 {
+		return fmt.Errorf("%w %d", ErrUnknownVersion, version)
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		current, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		return m.migrate(ctx, conn, current, version)
+	})
+}
+
+// Version returns the version of the last applied migration, 0 if none is.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var version int
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		version, err = m.version(ctx, conn)
+
+		return err
+	})
+
+	return version, err
+}
+
+// Current reads the applied version without taking the migration lock, so it
+// never waits for a running migration. It is meant for health checks; a
+// missing schema_migrations table reads as version 0.
+func (m *Migrator) Current(ctx context.Context) (version int, dirty bool, err error) {
+	var exists bool
+
+	err = m.db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).
+		Scan(&exists)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	if !exists {
+		return 0, false, nil
+	}
+
+	err = m.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).
+		Scan(&version, &dirty)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("failed to get version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+// Latest returns the version of the newest known migration, 0 if there are
+// none.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status lists every known migration and whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	current, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]Status, len(m.migrations))
+	for i, mg := range m.migrations {
+		status[i] = Status{Version: mg.Version, Name: mg.Name, Applied: mg.Version <= current}
+	}
+
+	return status, nil
+}
+
+// Plan returns the migrations that lead from the current version to the
+// target, in the order they run. Going down, each listed migration is rolled
+// back.
+func Plan(migrations []Migration, current, target int) []Migration {
+	var steps []Migration
+
+	if target >= current {
+		for _, mg := range migrations {
+			if mg.Version > current && mg.Version <= target {
+				steps = append(steps, mg)
+			}
+		}
+
+		return steps
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if mg := migrations[i]; mg.Version <= current && mg.Version > target {
+			steps = append(steps, mg)
+		}
+	}
+
+	return steps
+}
+
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, current, target int) error {
+	up := target >= current
+
+	for _, mg := range Plan(m.migrations, current, target) {
+		script, version := mg.Up, mg.Version
+		if !up {
+			script, version = mg.Down, m.previous(mg.Version)
+		}
+
+		if script == "" {
+			return fmt.Errorf("%w: %d_%s", ErrNoDown, mg.Version, mg.Name)
+		}
+
+		if err := m.apply(ctx, conn, script, version); err != nil {
+			return fmt.Errorf("failed to migrate %d_%s: %w", mg.Version, mg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// apply runs a script and records the new version in one transaction, so a
+// failed migration leaves no trace.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script string, version int) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return fmt.Errorf("failed to clear version: %w", err)
+	}
+
+	if version > 0 {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, version)
+		if err != nil {
+			return fmt.Errorf("failed to record version: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// withLock runs fn on a single connection holding the migration advisory
+// lock, so that instances starting together migrate one after another.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	defer func() {
+		// the lock goes with the session if this fails
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID)
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations
+(
+  version BIGINT  NOT NULL,
+  dirty   BOOLEAN NOT NULL,
+  PRIMARY KEY (version)
+)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (int, error) {
+	var (
+		version int
+		dirty   bool
+	)
+
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).
+		Scan(&version, &dirty)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("failed to get version: %w", err)
+	case dirty:
+		return 0, fmt.Errorf("%w (version %d)", ErrDirty, version)
+	}
+
+	if m.index(version) < 0 {
+		return 0, fmt.Errorf("%w %d in schema_migrations", ErrUnknownVersion, version)
+	}
+
+	return version, nil
+}
+
+func (m *Migrator) index(version int) int {
+	return slices.IndexFunc(m.migrations, func(mg Migration) bool { return mg.Version == version })
+}
+
+// previous returns the version before the given one, 0 for the first.
+func (m *Migrator) previous(version int) int {
+	if i := m.index(version); i > 0 {
+		return m.migrations[i-1].Version
+	}
+
+	return 0
+}
@@ -0,0 +1,108 @@
+package migrate_test
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/database"
+	"github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/migrate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type TestCase struct {
+		name        string
+		fsys        fstest.MapFS
+		expected    []migrate.Migration
+		expectedErr string
+	}
+
+	tt := []TestCase{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"000010_forecasts.up.sql":   {Data: []byte("CREATE TABLE forecasts ();")},
+				"000002_indexes.up.sql":     {Data: []byte("CREATE INDEX i ON t (c);")},
+				"000002_indexes.down.sql":   {Data: []byte("DROP INDEX i;")},
+				"000010_forecasts.down.sql": {Data: []byte("DROP TABLE forecasts;")},
+				"README.md":                 {Data: []byte("not a migration")},
+			},
+			expected: []migrate.Migration{
+				{Version: 2, Name: "indexes", Up: "CREATE INDEX i ON t (c);", Down: "DROP INDEX i;"},
+				{Version: 10, Name: "forecasts", Up: "CREATE TABLE forecasts ();", Down: "DROP TABLE forecasts;"},
+			},
+		},
+		{
+			name: "missing up script",
+			fsys: fstest.MapFS{
+				"000001_init.down.sql": {Data: []byte("DROP TABLE weather;")},
+			},
+			expectedErr: "migration 1_init has no up script",
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"000001_init.up.sql":  {Data: []byte("SELECT 1;")},
+				"000001_other.up.sql": {Data: []byte("SELECT 2;")},
+			},
+			expectedErr: `migration 1 is named both "init" and "other"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			migrations, err := migrate.Load(tc.fsys)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, migrations)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	migrations := []migrate.Migration{{Version: 1}, {Version: 2}, {Version: 5}}
+
+	versions := func(steps []migrate.Migration) []int {
+		res := []int{}
+		for _, m := range steps {
+			res = append(res, m.Version)
+		}
+
+		return res
+	}
+
+	assert.Equal(t, []int{1, 2, 5}, versions(migrate.Plan(migrations, 0, 5)))
+	assert.Equal(t, []int{5}, versions(migrate.Plan(migrations, 2, 5)))
+	assert.Equal(t, []int{5, 2}, versions(migrate.Plan(migrations, 5, 1)))
+	assert.Equal(t, []int{5, 2, 1}, versions(migrate.Plan(migrations, 5, 0)))
+	assert.Equal(t, []int{}, versions(migrate.Plan(migrations, 2, 2)))
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+
+	fsys, err := fs.Sub(database.Migrations, "migrations")
+	require.NoError(t, err)
+
+	migrations, err := migrate.Load(fsys)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, i+1, m.Version, "migrations are numbered without gaps")
+		assert.NotEmpty(t, m.Down, "migration %d_%s can be rolled back", m.Version, m.Name)
+	}
+}
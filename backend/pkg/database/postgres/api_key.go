@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+)
+
+func (db *DB) AddAPIKey(ctx context.Context, key *models.APIKey) (*models.APIKey, error) {
+	permissions := make([]string, len(key.Permissions))
+	for i, v := range key.Permissions {
+		permissions[i] = string(v)
+	}
+
+	arg := AddAPIKeyParams{
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		KeyHash:     key.KeyHash,
+		Cities:      key.Cities,
+		Permissions: permissions,
+		CreatedBy:   nullInt64(key.CreatedBy),
+	}
+
+	res, err := db.queries.AddAPIKey(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add api key: %w", translateError(err, nil))
+	}
+
+	k := dbAPIKeyToGlobal(res)
+	return &k, nil
+}
+
+func (db *DB) GetAPIKey(ctx context.Context, id int) (*models.APIKey, error) {
+	res, err := db.queries.GetAPIKey(ctx, int64(id))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get api key: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	k := dbAPIKeyToGlobal(res)
+	return &k, nil
+}
+
+func (db *DB) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	res, err := db.queries.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get api key by prefix: %w",
+			translateError(err, repository.NewErrNotFoundBy("prefix", prefix)),
+		)
+	}
+
+	k := dbAPIKeyToGlobal(res)
+	return &k, nil
+}
+
+func (db *DB) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	res, err := db.queries.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", translateError(err, nil))
+	}
+
+	keys := make([]*models.APIKey, len(res))
+	for i, v := range res {
+		k := dbAPIKeyToGlobal(v)
+		keys[i] = &k
+	}
+
+	return keys, nil
+}
+
+func (db *DB) RotateAPIKey(
+	ctx context.Context,
+	id int,
+	prefix, keyHash string,
+) (*models.APIKey, error) {
+	res, err := db.queries.RotateAPIKey(ctx, RotateAPIKeyParams{
+		ID:      int64(id),
+		Prefix:  prefix,
+		KeyHash: keyHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to rotate api key: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	k := dbAPIKeyToGlobal(res)
+	return &k, nil
+}
+
+func (db *DB) RevokeAPIKey(ctx context.Context, id int) (*models.APIKey, error) {
+	res, err := db.queries.RevokeAPIKey(ctx, int64(id))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to revoke api key: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	k := dbAPIKeyToGlobal(res)
+	return &k, nil
+}
+
+func (db *DB) TouchAPIKey(ctx context.Context, id int) error {
+	if err := db.queries.TouchAPIKey(ctx, int64(id)); err != nil {
+		return fmt.Errorf("failed to touch api key: %w", translateError(err, nil))
+	}
+
+	return nil
+}
+
+func dbAPIKeyToGlobal(key ApiKey) models.APIKey {
+	var createdBy *int
+	if key.CreatedBy.Valid {
+		id := int(key.CreatedBy.Int64)
+		createdBy = &id
+	}
+
+	var lastUsedAt, revokedAt *time.Time
+	if key.LastUsedAt.Valid {
+		lastUsedAt = &key.LastUsedAt.Time
+	}
+
+	if key.RevokedAt.Valid {
+		revokedAt = &key.RevokedAt.Time
+	}
+
+	cities := key.Cities
+	if cities == nil {
+		cities = []string{}
+	}
+
+	permissions := make([]models.Permission, len(key.Permissions))
+	for i, v := range key.Permissions {
+		permissions[i] = models.Permission(v)
+	}
+
+	return models.APIKey{
+		ID:          int(key.ID),
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		KeyHash:     key.KeyHash,
+		Cities:      cities,
+		Permissions: permissions,
+		CreatedBy:   createdBy,
+		CreatedAt:   key.CreatedAt,
+		LastUsedAt:  lastUsedAt,
+		RevokedAt:   revokedAt,
+	}
+}
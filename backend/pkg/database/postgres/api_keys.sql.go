@@ -0,0 +1,209 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: api_keys.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const addAPIKey = `-- name: AddAPIKey :one
+INSERT INTO api_keys (name, prefix, key_hash, cities, permissions, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+`
+
+type AddAPIKeyParams struct {
+	Name        string
+	Prefix      string
+	KeyHash     string
+	Cities      []string
+	Permissions []string
+	CreatedBy   sql.NullInt64
+}
+
+func (q *Queries) AddAPIKey(ctx context.Context, arg AddAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, addAPIKey,
+		arg.Name,
+		arg.Prefix,
+		arg.KeyHash,
+		pq.Array(arg.Cities),
+		pq.Array(arg.Permissions),
+		arg.CreatedBy,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Cities),
+		pq.Array(&i.Permissions),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getAPIKey = `-- name: GetAPIKey :one
+SELECT id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+FROM api_keys
+WHERE id = $1
+`
+
+func (q *Queries) GetAPIKey(ctx context.Context, id int64) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Cities),
+		pq.Array(&i.Permissions),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getAPIKeyByPrefix = `-- name: GetAPIKeyByPrefix :one
+SELECT id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+FROM api_keys
+WHERE prefix = $1
+`
+
+func (q *Queries) GetAPIKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getAPIKeyByPrefix, prefix)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Cities),
+		pq.Array(&i.Permissions),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const listAPIKeys = `-- name: ListAPIKeys :many
+SELECT id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+FROM api_keys
+ORDER BY id
+`
+
+func (q *Queries) ListAPIKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ApiKey
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Prefix,
+			&i.KeyHash,
+			pq.Array(&i.Cities),
+			pq.Array(&i.Permissions),
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, now())
+WHERE id = $1
+RETURNING id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id int64) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, revokeAPIKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Cities),
+		pq.Array(&i.Permissions),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const rotateAPIKey = `-- name: RotateAPIKey :one
+UPDATE api_keys
+SET prefix = $2,
+    key_hash = $3
+WHERE id = $1
+RETURNING id, name, prefix, key_hash, cities, permissions, created_by, created_at, last_used_at, revoked_at
+`
+
+type RotateAPIKeyParams struct {
+	ID      int64
+	Prefix  string
+	KeyHash string
+}
+
+func (q *Queries) RotateAPIKey(ctx context.Context, arg RotateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, rotateAPIKey, arg.ID, arg.Prefix, arg.KeyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Prefix,
+		&i.KeyHash,
+		pq.Array(&i.Cities),
+		pq.Array(&i.Permissions),
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) TouchAPIKey(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, touchAPIKey, id)
+	return err
+}
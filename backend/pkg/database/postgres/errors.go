@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/lib/pq"
+)
+
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeUniqueViolation     pq.ErrorCode = "23505"
+	codeForeignKeyViolation pq.ErrorCode = "23503"
+	codeExclusionViolation  pq.ErrorCode = "23P01"
+	codeAdminShutdown       pq.ErrorCode = "57P01"
+	codeCrashShutdown       pq.ErrorCode = "57P02"
+	codeCannotConnectNow    pq.ErrorCode = "57P03"
+
+	classDataException        pq.ErrorClass = "22"
+	classIntegrityViolation   pq.ErrorClass = "23"
+	classConnectionException  pq.ErrorClass = "08"
+	classTransactionRollback  pq.ErrorClass = "40"
+	classInsufficientResource pq.ErrorClass = "53"
+)
+
+// translateError maps a driver error onto the repository error taxonomy.
+// notFound replaces sql.ErrNoRows and may be nil for statements that always
+// return rows.
+func translateError(err error, notFound error) error {
+	if errors.Is(err, sql.ErrNoRows) && notFound != nil {
+		return notFound
+	}
+
+	if kind := errorKind(err); kind != nil {
+		return fmt.Errorf("%w: %w", kind, err)
+	}
+
+	return err
+}
+
+func errorKind(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case codeUniqueViolation, codeForeignKeyViolation, codeExclusionViolation:
+			return repository.ErrConflict
+		case codeAdminShutdown, codeCrashShutdown, codeCannotConnectNow:
+			return repository.ErrUnavailable
+		}
+
+		switch pqErr.Code.Class() {
+		case classDataException, classIntegrityViolation:
+			return repository.ErrInvalid
+		case classConnectionException, classTransactionRollback, classInsufficientResource:
+			return repository.ErrUnavailable
+		}
+
+		return nil
+	}
+
+	var netErr net.Error
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.As(err, &netErr) {
+		return repository.ErrUnavailable
+	}
+
+	return nil
+}
+
+// rowError returns the error to report for a single row the database
+// rejected, or nil if err is not down to the row. Driver details are left
+// out, as the error goes back to the client.
+func rowError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		// an unknown location_id, from resolving the location of the row
+		if errors.Is(err, repository.ErrInvalid) {
+			return err
+		}
+
+		return nil
+	}
+
+	switch kind := errorKind(err); kind {
+	case repository.ErrConflict, repository.ErrInvalid:
+		return kind
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+	"github.com/lib/pq"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateError(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{
+			name: "unique violation",
+			err:  &pq.Error{Code: "23505"},
+			kind: repository.ErrConflict,
+		},
+		{
+			name: "foreign key violation",
+			err:  &pq.Error{Code: "23503"},
+			kind: repository.ErrConflict,
+		},
+		{
+			name: "check violation",
+			err:  &pq.Error{Code: "23514"},
+			kind: repository.ErrInvalid,
+		},
+		{
+			name: "numeric value out of range",
+			err:  &pq.Error{Code: "22003"},
+			kind: repository.ErrInvalid,
+		},
+		{
+			name: "connection failure",
+			err:  &pq.Error{Code: "08006"},
+			kind: repository.ErrUnavailable,
+		},
+		{
+			name: "admin shutdown",
+			err:  &pq.Error{Code: "57P01"},
+			kind: repository.ErrUnavailable,
+		},
+		{
+			name: "closed connection",
+			err:  fmt.Errorf("failed to query: %w", sql.ErrConnDone),
+			kind: repository.ErrUnavailable,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := translateError(tc.err, nil)
+			require.ErrorIs(t, err, tc.kind)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestTranslateErrorNoRows(t *testing.T) {
+	t.Parallel()
+
+	err := translateError(sql.ErrNoRows, repository.NewErrNotFound(3))
+	require.ErrorAs(t, err, &repository.ErrNotFound{})
+	assert.EqualError(t, err, "no record with id=3")
+
+	err = translateError(sql.ErrNoRows, nil)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	other := errors.New("syntax error")
+	assert.Equal(t, other, translateError(other, nil))
+}
+
+func TestRowError(t *testing.T) {
+	t.Parallel()
+
+	unknownLocation := fmt.Errorf("%w: unknown location_id=5", repository.ErrInvalid)
+
+	tt := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "foreign key violation",
+			err:      fmt.Errorf("failed to insert: %w", &pq.Error{Code: "23503"}),
+			expected: repository.ErrConflict,
+		},
+		{
+			name:     "check violation",
+			err:      &pq.Error{Code: "23514"},
+			expected: repository.ErrInvalid,
+		},
+		{
+			name:     "unknown location",
+			err:      unknownLocation,
+			expected: unknownLocation,
+		},
+		{
+			name: "connection failure",
+			err:  &pq.Error{Code: "08006"},
+		},
+		{
+			name: "closed connection",
+			err:  sql.ErrConnDone,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, rowError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+// SaveForecast stores every point of a forecast run, replacing the points of
+// an earlier run with the same location, model, granularity and issue time.
+func (db *DB) SaveForecast(ctx context.Context, fc *models.Forecast) error {
+	err := db.inTx(ctx, func(q *Queries) error {
+		for _, p := range fc.Points {
+			arg := UpsertForecastParams{
+				LocationID:  int64(fc.LocationID),
+				Model:       fc.Model,
+				Granularity: string(fc.Granularity),
+				IssuedAt:    fc.IssuedAt,
+				TargetTime:  p.Time,
+			}
+
+			arg.Temperature, arg.TemperatureLower, arg.TemperatureUpper = nullPrediction(p.Temperature)
+			arg.Humidity, arg.HumidityLower, arg.HumidityUpper = nullPrediction(p.Humidity)
+			arg.Pressure, arg.PressureLower, arg.PressureUpper = nullPrediction(p.Pressure)
+			arg.WindSpeed, arg.WindSpeedLower, arg.WindSpeedUpper = nullPrediction(p.WindSpeed)
+
+			if err := q.UpsertForecast(ctx, arg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save forecast: %w", translateError(err, nil))
+	}
+
+	return nil
+}
+
+func (db *DB) ForecastAccuracy(
+	ctx context.Context,
+	filter models.AccuracyFilter,
+) ([]*models.ForecastAccuracy, error) {
+	observedTo := sql.NullTime{}
+	if filter.To != nil {
+		step := time.Hour
+		if filter.Granularity == models.BucketDay {
+			step = 24 * time.Hour
+		}
+
+		observedTo = sql.NullTime{Time: filter.To.Add(step), Valid: true}
+	}
+
+	res, err := db.queries.ForecastAccuracy(ctx, ForecastAccuracyParams{
+		Granularity:   string(filter.Granularity),
+		Model:         nullString(filter.Model),
+		LocationID:    nullInt64(filter.LocationID),
+		City:          nullString(filter.City),
+		Country:       nullString(filter.Country),
+		FromTimestamp: nullTime(filter.From),
+		ToTimestamp:   nullTime(filter.To),
+		ObservedTo:    observedTo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecast accuracy: %w", translateError(err, nil))
+	}
+
+	accuracy := make([]*models.ForecastAccuracy, len(res))
+	for i, v := range res {
+		accuracy[i] = &models.ForecastAccuracy{
+			Model:      v.Model,
+			LocationID: int(v.LocationID),
+			City:       v.City,
+			Country:    v.Country,
+			Horizon:    int(v.Horizon),
+			VariableErrors: models.VariableErrors{
+				Temperature: errorStats(v.TemperatureCount, v.TemperatureMae, v.TemperatureRmse, v.TemperatureBias),
+				Humidity:    errorStats(v.HumidityCount, v.HumidityMae, v.HumidityRmse, v.HumidityBias),
+				Pressure:    errorStats(v.PressureCount, v.PressureMae, v.PressureRmse, v.PressureBias),
+				WindSpeed:   errorStats(v.WindSpeedCount, v.WindSpeedMae, v.WindSpeedRmse, v.WindSpeedBias),
+			},
+		}
+	}
+
+	return accuracy, nil
+}
+
+func nullPrediction(p *models.Prediction) (value, lower, upper sql.NullFloat64) {
+	if p == nil {
+		return value, lower, upper
+	}
+
+	return sql.NullFloat64{Float64: p.Value, Valid: true}, nullFloat64(p.Lower), nullFloat64(p.Upper)
+}
+
+func errorStats(count int64, mae, rmse, bias float64) *models.ErrorStats {
+	if count == 0 {
+		return nil
+	}
+
+	return &models.ErrorStats{
+		Count: int(count),
+		MAE:   mae,
+		RMSE:  rmse,
+		Bias:  bias,
+	}
+}
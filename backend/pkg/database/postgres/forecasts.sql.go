@@ -0,0 +1,224 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: forecasts.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const forecastAccuracy = `-- name: ForecastAccuracy :many
+WITH actual AS (
+    SELECT weather.location_id,
+           date_trunc($1::text, weather.timestamp, 'UTC')::timestamptz AS period_start,
+           avg(weather.temperature)::float8 AS temperature,
+           avg(weather.humidity)::float8 AS humidity,
+           avg(weather.pressure)::float8 AS pressure,
+           avg(weather.wind_speed)::float8 AS wind_speed
+    FROM weather
+    WHERE ($3::bigint IS NULL OR weather.location_id = $3::bigint)
+      AND ($6::timestamptz IS NULL OR weather.timestamp >= $6::timestamptz)
+      AND ($8::timestamptz IS NULL OR weather.timestamp < $8::timestamptz)
+    GROUP BY weather.location_id, period_start
+)
+SELECT forecasts.model,
+       locations.id AS location_id,
+       locations.name AS city,
+       locations.country,
+       ceil(extract(epoch FROM forecasts.target_time - forecasts.issued_at)
+            / CASE forecasts.granularity WHEN 'day' THEN 86400 ELSE 3600 END + 1)::int AS horizon,
+       count(forecasts.temperature) AS temperature_count,
+       COALESCE(avg(abs(forecasts.temperature - actual.temperature)), 0)::float8 AS temperature_mae,
+       COALESCE(sqrt(avg(power(forecasts.temperature - actual.temperature, 2))), 0)::float8 AS temperature_rmse,
+       COALESCE(avg(forecasts.temperature - actual.temperature), 0)::float8 AS temperature_bias,
+       count(forecasts.humidity) AS humidity_count,
+       COALESCE(avg(abs(forecasts.humidity - actual.humidity)), 0)::float8 AS humidity_mae,
+       COALESCE(sqrt(avg(power(forecasts.humidity - actual.humidity, 2))), 0)::float8 AS humidity_rmse,
+       COALESCE(avg(forecasts.humidity - actual.humidity), 0)::float8 AS humidity_bias,
+       count(forecasts.pressure) AS pressure_count,
+       COALESCE(avg(abs(forecasts.pressure - actual.pressure)), 0)::float8 AS pressure_mae,
+       COALESCE(sqrt(avg(power(forecasts.pressure - actual.pressure, 2))), 0)::float8 AS pressure_rmse,
+       COALESCE(avg(forecasts.pressure - actual.pressure), 0)::float8 AS pressure_bias,
+       count(forecasts.wind_speed) AS wind_speed_count,
+       COALESCE(avg(abs(forecasts.wind_speed - actual.wind_speed)), 0)::float8 AS wind_speed_mae,
+       COALESCE(sqrt(avg(power(forecasts.wind_speed - actual.wind_speed, 2))), 0)::float8 AS wind_speed_rmse,
+       COALESCE(avg(forecasts.wind_speed - actual.wind_speed), 0)::float8 AS wind_speed_bias
+FROM forecasts
+JOIN locations ON locations.id = forecasts.location_id
+JOIN actual ON actual.location_id = forecasts.location_id AND actual.period_start = forecasts.target_time
+WHERE forecasts.granularity = $1::text
+  AND ($2::text IS NULL OR forecasts.model = $2::text)
+  AND ($3::bigint IS NULL OR forecasts.location_id = $3::bigint)
+  AND ($4::text IS NULL OR lower(locations.name) = lower($4::text))
+  AND ($5::text IS NULL OR lower(locations.country) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR forecasts.target_time >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR forecasts.target_time < $7::timestamptz)
+GROUP BY forecasts.model, locations.id, horizon
+ORDER BY forecasts.model, locations.name, locations.country, locations.id, horizon
+`
+
+type ForecastAccuracyParams struct {
+	Granularity   string
+	Model         sql.NullString
+	LocationID    sql.NullInt64
+	City          sql.NullString
+	Country       sql.NullString
+	FromTimestamp sql.NullTime
+	ToTimestamp   sql.NullTime
+	ObservedTo    sql.NullTime
+}
+
+type ForecastAccuracyRow struct {
+	Model            string
+	LocationID       int64
+	City             string
+	Country          string
+	Horizon          int32
+	TemperatureCount int64
+	TemperatureMae   float64
+	TemperatureRmse  float64
+	TemperatureBias  float64
+	HumidityCount    int64
+	HumidityMae      float64
+	HumidityRmse     float64
+	HumidityBias     float64
+	PressureCount    int64
+	PressureMae      float64
+	PressureRmse     float64
+	PressureBias     float64
+	WindSpeedCount   int64
+	WindSpeedMae     float64
+	WindSpeedRmse    float64
+	WindSpeedBias    float64
+}
+
+// The horizon counts periods from the issue time to the end of the predicted
+// period, rounded up, so the first period of an hourly run has horizon 1.
+// Forecast periods are UTC periods matched with the mean of the observations
+// within them; observed_to is to_timestamp plus one period.
+func (q *Queries) ForecastAccuracy(ctx context.Context, arg ForecastAccuracyParams) ([]ForecastAccuracyRow, error) {
+	rows, err := q.db.QueryContext(ctx, forecastAccuracy,
+		arg.Granularity,
+		arg.Model,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.ObservedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ForecastAccuracyRow
+	for rows.Next() {
+		var i ForecastAccuracyRow
+		if err := rows.Scan(
+			&i.Model,
+			&i.LocationID,
+			&i.City,
+			&i.Country,
+			&i.Horizon,
+			&i.TemperatureCount,
+			&i.TemperatureMae,
+			&i.TemperatureRmse,
+			&i.TemperatureBias,
+			&i.HumidityCount,
+			&i.HumidityMae,
+			&i.HumidityRmse,
+			&i.HumidityBias,
+			&i.PressureCount,
+			&i.PressureMae,
+			&i.PressureRmse,
+			&i.PressureBias,
+			&i.WindSpeedCount,
+			&i.WindSpeedMae,
+			&i.WindSpeedRmse,
+			&i.WindSpeedBias,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertForecast = `-- name: UpsertForecast :exec
+INSERT INTO forecasts (
+    location_id, model, granularity, issued_at, target_time,
+    temperature, temperature_lower, temperature_upper,
+    humidity, humidity_lower, humidity_upper,
+    pressure, pressure_lower, pressure_upper,
+    wind_speed, wind_speed_lower, wind_speed_upper
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+ON CONFLICT (location_id, model, granularity, issued_at, target_time) DO UPDATE
+SET
+    temperature = excluded.temperature,
+    temperature_lower = excluded.temperature_lower,
+    temperature_upper = excluded.temperature_upper,
+    humidity = excluded.humidity,
+    humidity_lower = excluded.humidity_lower,
+    humidity_upper = excluded.humidity_upper,
+    pressure = excluded.pressure,
+    pressure_lower = excluded.pressure_lower,
+    pressure_upper = excluded.pressure_upper,
+    wind_speed = excluded.wind_speed,
+    wind_speed_lower = excluded.wind_speed_lower,
+    wind_speed_upper = excluded.wind_speed_upper,
+    created_at = excluded.created_at
+`
+
+type UpsertForecastParams struct {
+	LocationID       int64
+	Model            string
+	Granularity      string
+	IssuedAt         time.Time
+	TargetTime       time.Time
+	Temperature      sql.NullFloat64
+	TemperatureLower sql.NullFloat64
+	TemperatureUpper sql.NullFloat64
+	Humidity         sql.NullFloat64
+	HumidityLower    sql.NullFloat64
+	HumidityUpper    sql.NullFloat64
+	Pressure         sql.NullFloat64
+	PressureLower    sql.NullFloat64
+	PressureUpper    sql.NullFloat64
+	WindSpeed        sql.NullFloat64
+	WindSpeedLower   sql.NullFloat64
+	WindSpeedUpper   sql.NullFloat64
+}
+
+func (q *Queries) UpsertForecast(ctx context.Context, arg UpsertForecastParams) error {
+	_, err := q.db.ExecContext(ctx, upsertForecast,
+		arg.LocationID,
+		arg.Model,
+		arg.Granularity,
+		arg.IssuedAt,
+		arg.TargetTime,
+		arg.Temperature,
+		arg.TemperatureLower,
+		arg.TemperatureUpper,
+		arg.Humidity,
+		arg.HumidityLower,
+		arg.HumidityUpper,
+		arg.Pressure,
+		arg.PressureLower,
+		arg.PressureUpper,
+		arg.WindSpeed,
+		arg.WindSpeedLower,
+		arg.WindSpeedUpper,
+	)
+	return err
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+// earthRadiusKm must match the radius used by the haversine_km SQL function.
+const earthRadiusKm = 6371.0088
+
+func (db *DB) ListWeathersNear(
+	ctx context.Context,
+	filter models.NearFilter,
+) ([]*models.Weather, error) {
+	box := nearBBox(filter.Latitude, filter.Longitude, filter.RadiusKm)
+
+	res, err := db.queries.ListWeathersNear(ctx, ListWeathersNearParams{
+		Latitude:      filter.Latitude,
+		Longitude:     filter.Longitude,
+		MinLatitude:   box.MinLatitude,
+		MaxLatitude:   box.MaxLatitude,
+		MinLongitude:  box.MinLongitude,
+		MaxLongitude:  box.MaxLongitude,
+		RadiusKm:      filter.RadiusKm,
+		FromTimestamp: nullTime(filter.From),
+		ToTimestamp:   nullTime(filter.To),
+		PageSize:      int32(filter.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weathers near point: %w", translateError(err, nil))
+	}
+
+	weathers := make([]*models.Weather, len(res))
+	for i, v := range res {
+		wth := dbWeatherToGlobal(v.Weather, v.Location)
+		wth.Distance = &v.DistanceKm
+		weathers[i] = &wth
+	}
+
+	return weathers, nil
+}
+
+func (db *DB) ListWeathersInBBox(
+	ctx context.Context,
+	filter models.BBoxFilter,
+) ([]*models.Weather, error) {
+	res, err := weatherRows(db.queries.ListWeathersInBBox(ctx, ListWeathersInBBoxParams{
+		MinLatitude:   filter.MinLatitude,
+		MaxLatitude:   filter.MaxLatitude,
+		MinLongitude:  filter.MinLongitude,
+		MaxLongitude:  filter.MaxLongitude,
+		FromTimestamp: nullTime(filter.From),
+		ToTimestamp:   nullTime(filter.To),
+		PageSize:      int32(filter.Limit),
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list weathers in bbox: %w", translateError(err, nil))
+	}
+
+	weathers := make([]*models.Weather, len(res))
+	for i, v := range res {
+		wth := dbWeatherToGlobal(v.Weather, v.Location)
+		weathers[i] = &wth
+	}
+
+	return weathers, nil
+}
+
+// nearBBox returns the smallest latitude/longitude box containing the circle
+// of radiusKm around a point. The query uses it to narrow the rows it computes
+// exact distances for. Longitudes are wrapped into [-180, 180], so the box may
+// cross the antimeridian.
+func nearBBox(lat, lon, radiusKm float64) models.BBox {
+	angular := radiusKm / earthRadiusKm
+	dLat := angular * 180 / math.Pi
+
+	box := models.BBox{
+		MinLatitude:  math.Max(lat-dLat, -90),
+		MaxLatitude:  math.Min(lat+dLat, 90),
+		MinLongitude: -180,
+		MaxLongitude: 180,
+	}
+
+	// a circle reaching over a pole covers every longitude
+	ratio := math.Sin(angular) / math.Cos(lat*math.Pi/180)
+	if lat-dLat <= -90 || lat+dLat >= 90 || ratio >= 1 {
+		return box
+	}
+
+	dLon := math.Asin(ratio) * 180 / math.Pi
+
+	box.MinLongitude = wrapLongitude(lon - dLon)
+	box.MaxLongitude = wrapLongitude(lon + dLon)
+
+	return box
+}
+
+func wrapLongitude(lon float64) float64 {
+	switch {
+	case lon < -180:
+		return lon + 360
+	case lon > 180:
+		return lon - 360
+	}
+
+	return lon
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearBBox(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		lat      float64
+		lon      float64
+		radiusKm float64
+		expected models.BBox
+	}{
+		{
+			name:     "equator",
+			lat:      0,
+			lon:      0,
+			radiusKm: 111.19508,
+			expected: models.BBox{MinLatitude: -1, MinLongitude: -1, MaxLatitude: 1, MaxLongitude: 1},
+		},
+		{
+			name:     "crosses antimeridian",
+			lat:      0,
+			lon:      179.5,
+			radiusKm: 111.19508,
+			expected: models.BBox{MinLatitude: -1, MinLongitude: 178.5, MaxLatitude: 1, MaxLongitude: -179.5},
+		},
+		{
+			name:     "reaches pole",
+			lat:      89.5,
+			lon:      30,
+			radiusKm: 111.19508,
+			expected: models.BBox{MinLatitude: 88.5, MinLongitude: -180, MaxLatitude: 90, MaxLongitude: 180},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			box := nearBBox(tc.lat, tc.lon, tc.radiusKm)
+			assert.InDelta(t, tc.expected.MinLatitude, box.MinLatitude, 1e-4)
+			assert.InDelta(t, tc.expected.MaxLatitude, box.MaxLatitude, 1e-4)
+			assert.InDelta(t, tc.expected.MinLongitude, box.MinLongitude, 1e-4)
+			assert.InDelta(t, tc.expected.MaxLongitude, box.MaxLongitude, 1e-4)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/LLIEPJIOK/weather-forecast/backend/pkg/database/postgres"
+
+// QueryObserver receives the duration and outcome of every sqlc query.
+type QueryObserver interface {
+	ObserveQuery(name string, duration time.Duration, err error)
+}
+
+// Instrument reports every query made through db, in transactions as well,
+// to observer.
+func (db *DB) Instrument(observer QueryObserver) {
+	db.observer = observer
+	db.queries = New(db.dbtx(db.db))
+}
+
+// dbtx wraps conn so that every query gets a client span and, with an
+// observer set, is measured.
+func (db *DB) dbtx(conn DBTX) DBTX {
+	return &instrumentedDBTX{
+		conn:     conn,
+		observer: db.observer,
+		tracer:   otel.Tracer(tracerName),
+	}
+}
+
+type instrumentedDBTX struct {
+	conn     DBTX
+	observer QueryObserver
+	tracer   trace.Tracer
+}
+
+func (i *instrumentedDBTX) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, done := i.start(ctx, query)
+	res, err := i.conn.ExecContext(ctx, query, args...)
+	done(err)
+
+	return res, err
+}
+
+func (i *instrumentedDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return i.conn.PrepareContext(ctx, query)
+}
+
+// QueryContext measures the time to the first row only; reading the rest is
+// up to the caller.
+func (i *instrumentedDBTX) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, done := i.start(ctx, query)
+	rows, err := i.conn.QueryContext(ctx, query, args...)
+	done(err)
+
+	return rows, err
+}
+
+// QueryRowContext does not count sql.ErrNoRows as a failure, Row.Err only
+// reports it on Scan.
+func (i *instrumentedDBTX) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, done := i.start(ctx, query)
+	row := i.conn.QueryRowContext(ctx, query, args...)
+	done(row.Err())
+
+	return row
+}
+
+func (i *instrumentedDBTX) start(ctx context.Context, query string) (context.Context, func(err error)) {
+	name := queryName(query)
+	start := time.Now()
+
+	ctx, span := i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(name),
+			semconv.DBQueryText(query),
+		),
+	)
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+
+		if i.observer != nil {
+			i.observer.ObserveQuery(name, time.Since(start), err)
+		}
+	}
+}
+
+// queryName returns the name sqlc puts in front of every generated query, as
+// in "-- name: GetWeather :one".
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(query, "-- name: ")
+	if !ok {
+		return "unnamed"
+	}
+
+	name, _, _ := strings.Cut(rest, " ")
+
+	return name
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recordedQuery struct {
+	name string
+	err  error
+}
+
+type fakeObserver struct {
+	queries []recordedQuery
+}
+
+func (o *fakeObserver) ObserveQuery(name string, _ time.Duration, err error) {
+	o.queries = append(o.queries, recordedQuery{name: name, err: err})
+}
+
+type fakeConn struct {
+	DBTX
+	err error
+}
+
+func (c fakeConn) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, c.err
+}
+
+func (c fakeConn) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, c.err
+}
+
+func TestQueryName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "GetWeather", queryName(getWeather))
+	assert.Equal(t, "AddWeather", queryName(addWeather))
+	assert.Equal(t, "unnamed", queryName("SELECT 1"))
+}
+
+func TestObservedDBTX(t *testing.T) {
+	t.Parallel()
+
+	errBroken := errors.New("broken pipe")
+
+	observer := &fakeObserver{}
+	db := &DB{observer: observer}
+
+	_, _ = db.dbtx(fakeConn{}).ExecContext(context.Background(), addWeather)
+	_, _ = db.dbtx(fakeConn{err: errBroken}).QueryContext(context.Background(), listLocations)
+
+	assert.Equal(t, []recordedQuery{
+		{name: "AddWeather"},
+		{name: "ListLocations", err: errBroken},
+	}, observer.queries)
+}
+
+// TestQuerySpans installs the global tracer provider, so it does not run in
+// parallel.
+func TestQuerySpans(t *testing.T) {
+	exporter := tracing.InMemory()
+
+	errBroken := errors.New("broken pipe")
+
+	db := &DB{}
+
+	_, _ = db.dbtx(fakeConn{}).ExecContext(context.Background(), addWeather)
+	_, _ = db.dbtx(fakeConn{err: errBroken}).QueryContext(context.Background(), listLocations)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "AddWeather", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Contains(t, spans[0].Attributes, semconv.DBSystemPostgreSQL)
+	assert.Contains(t, spans[0].Attributes, semconv.DBQueryText(addWeather))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	assert.Equal(t, "ListLocations", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "broken pipe", spans[1].Status.Description)
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+)
+
+func (db *DB) AddLocation(ctx context.Context, location *models.Location) (*models.Location, error) {
+	res, err := db.queries.AddLocation(ctx, AddLocationParams{
+		Name:      location.Name,
+		Country:   location.Country,
+		Latitude:  nullFloat64(location.Latitude),
+		Longitude: nullFloat64(location.Longitude),
+		Elevation: nullFloat64(location.Elevation),
+		Timezone:  location.Timezone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add location: %w", translateError(err, nil))
+	}
+
+	loc := dbLocationToGlobal(res)
+	return &loc, nil
+}
+
+func (db *DB) GetLocation(ctx context.Context, id int) (*models.Location, error) {
+	res, err := db.queries.GetLocation(ctx, int64(id))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get location: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	loc := dbLocationToGlobal(res)
+	return &loc, nil
+}
+
+func (db *DB) GetLocationByName(
+	ctx context.Context,
+	name string,
+	country string,
+) (*models.Location, error) {
+	res, err := db.queries.GetLocationByName(ctx, GetLocationByNameParams{
+		Name:    name,
+		Country: country,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get location: %w",
+			translateError(err, repository.NewErrNotFoundBy("name", name+", "+country)),
+		)
+	}
+
+	loc := dbLocationToGlobal(res)
+	return &loc, nil
+}
+
+func (db *DB) ListLocations(ctx context.Context) ([]*models.Location, error) {
+	res, err := db.queries.ListLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", translateError(err, nil))
+	}
+
+	locations := make([]*models.Location, len(res))
+	for i, v := range res {
+		loc := dbLocationToGlobal(v)
+		locations[i] = &loc
+	}
+
+	return locations, nil
+}
+
+func (db *DB) UpdateLocation(
+	ctx context.Context,
+	location *models.Location,
+) (*models.Location, error) {
+	res, err := db.queries.UpdateLocation(ctx, UpdateLocationParams{
+		ID:        int64(location.ID),
+		Name:      location.Name,
+		Country:   location.Country,
+		Latitude:  nullFloat64(location.Latitude),
+		Longitude: nullFloat64(location.Longitude),
+		Elevation: nullFloat64(location.Elevation),
+		Timezone:  location.Timezone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to update location: %w",
+			translateError(err, repository.NewErrNotFound(location.ID)),
+		)
+	}
+
+	loc := dbLocationToGlobal(res)
+	return &loc, nil
+}
+
+// DeleteLocation fails with repository.ErrConflict while observations still
+// reference the location.
+func (db *DB) DeleteLocation(ctx context.Context, id int) (*models.Location, error) {
+	res, err := db.queries.DeleteLocation(ctx, int64(id))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to delete location: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	loc := dbLocationToGlobal(res)
+	return &loc, nil
+}
+
+func dbLocationToGlobal(location Location) models.Location {
+	return models.Location{
+		ID:        int(location.ID),
+		Name:      location.Name,
+		Country:   location.Country,
+		Latitude:  floatPtr(location.Latitude),
+		Longitude: floatPtr(location.Longitude),
+		Elevation: floatPtr(location.Elevation),
+		Timezone:  location.Timezone,
+	}
+}
+
+func floatPtr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+
+	return &f.Float64
+}
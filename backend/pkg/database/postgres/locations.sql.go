@@ -0,0 +1,313 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: locations.sql
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const addLocation = `-- name: AddLocation :one
+INSERT INTO locations (name, country, latitude, longitude, elevation, timezone)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, country, latitude, longitude, elevation, timezone
+`
+
+type AddLocationParams struct {
+	Name      string
+	Country   string
+	Latitude  sql.NullFloat64
+	Longitude sql.NullFloat64
+	Elevation sql.NullFloat64
+	Timezone  string
+}
+
+func (q *Queries) AddLocation(ctx context.Context, arg AddLocationParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, addLocation,
+		arg.Name,
+		arg.Country,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Elevation,
+		arg.Timezone,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const deleteLocation = `-- name: DeleteLocation :one
+DELETE FROM locations
+WHERE id = $1
+RETURNING id, name, country, latitude, longitude, elevation, timezone
+`
+
+func (q *Queries) DeleteLocation(ctx context.Context, id int64) (Location, error) {
+	row := q.db.QueryRowContext(ctx, deleteLocation, id)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const ensureLocation = `-- name: EnsureLocation :one
+INSERT INTO locations (name, country)
+VALUES ($1, $2)
+ON CONFLICT (lower(name), lower(country)) DO UPDATE SET name = locations.name
+RETURNING id, name, country, latitude, longitude, elevation, timezone
+`
+
+type EnsureLocationParams struct {
+	Name    string
+	Country string
+}
+
+// EnsureLocation returns the location matching name and country case-insensitively,
+// creating it if needed. The no-op update makes RETURNING yield the existing row.
+func (q *Queries) EnsureLocation(ctx context.Context, arg EnsureLocationParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, ensureLocation, arg.Name, arg.Country)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const ensureLocations = `-- name: EnsureLocations :many
+INSERT INTO locations (name, country)
+SELECT unnest($1::text[]), unnest($2::text[])
+ON CONFLICT (lower(name), lower(country)) DO UPDATE SET name = locations.name
+RETURNING id, name, country, latitude, longitude, elevation, timezone
+`
+
+type EnsureLocationsParams struct {
+	Names     []string
+	Countries []string
+}
+
+// EnsureLocations is EnsureLocation for many name and country pairs at once.
+// The pairs must be distinct case-insensitively, as an upsert cannot touch a
+// row twice.
+func (q *Queries) EnsureLocations(ctx context.Context, arg EnsureLocationsParams) ([]Location, error) {
+	rows, err := q.db.QueryContext(ctx, ensureLocations, pq.Array(arg.Names), pq.Array(arg.Countries))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Country,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Elevation,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLocation = `-- name: GetLocation :one
+SELECT id, name, country, latitude, longitude, elevation, timezone
+FROM locations
+WHERE id = $1
+`
+
+func (q *Queries) GetLocation(ctx context.Context, id int64) (Location, error) {
+	row := q.db.QueryRowContext(ctx, getLocation, id)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const getLocationByName = `-- name: GetLocationByName :one
+SELECT id, name, country, latitude, longitude, elevation, timezone
+FROM locations
+WHERE lower(name) = lower($1) AND lower(country) = lower($2)
+`
+
+type GetLocationByNameParams struct {
+	Name    string
+	Country string
+}
+
+func (q *Queries) GetLocationByName(ctx context.Context, arg GetLocationByNameParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, getLocationByName, arg.Name, arg.Country)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const listLocations = `-- name: ListLocations :many
+SELECT id, name, country, latitude, longitude, elevation, timezone
+FROM locations
+ORDER BY country, name, id
+`
+
+func (q *Queries) ListLocations(ctx context.Context) ([]Location, error) {
+	rows, err := q.db.QueryContext(ctx, listLocations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Country,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Elevation,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLocationsByID = `-- name: ListLocationsByID :many
+SELECT id, name, country, latitude, longitude, elevation, timezone
+FROM locations
+WHERE id = ANY($1::bigint[])
+`
+
+func (q *Queries) ListLocationsByID(ctx context.Context, ids []int64) ([]Location, error) {
+	rows, err := q.db.QueryContext(ctx, listLocationsByID, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Location
+	for rows.Next() {
+		var i Location
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Country,
+			&i.Latitude,
+			&i.Longitude,
+			&i.Elevation,
+			&i.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateLocation = `-- name: UpdateLocation :one
+UPDATE locations
+SET
+    name = $1,
+    country = $2,
+    latitude = $3,
+    longitude = $4,
+    elevation = $5,
+    timezone = $6
+WHERE id = $7
+RETURNING id, name, country, latitude, longitude, elevation, timezone
+`
+
+type UpdateLocationParams struct {
+	Name      string
+	Country   string
+	Latitude  sql.NullFloat64
+	Longitude sql.NullFloat64
+	Elevation sql.NullFloat64
+	Timezone  string
+	ID        int64
+}
+
+func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
+	row := q.db.QueryRowContext(ctx, updateLocation,
+		arg.Name,
+		arg.Country,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Elevation,
+		arg.Timezone,
+		arg.ID,
+	)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Country,
+		&i.Latitude,
+		&i.Longitude,
+		&i.Elevation,
+		&i.Timezone,
+	)
+	return i, err
+}
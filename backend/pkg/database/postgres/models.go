@@ -5,17 +5,100 @@
 package postgres
 
 import (
+	"database/sql"
 	"time"
 )
 
+type ApiKey struct {
+	ID          int64
+	Name        string
+	Prefix      string
+	KeyHash     string
+	Cities      []string
+	Permissions []string
+	CreatedBy   sql.NullInt64
+	CreatedAt   time.Time
+	LastUsedAt  sql.NullTime
+	RevokedAt   sql.NullTime
+}
+
+type Forecast struct {
+	ID               int64
+	LocationID       int64
+	Model            string
+	Granularity      string
+	IssuedAt         time.Time
+	TargetTime       time.Time
+	Temperature      sql.NullFloat64
+	TemperatureLower sql.NullFloat64
+	TemperatureUpper sql.NullFloat64
+	Humidity         sql.NullFloat64
+	HumidityLower    sql.NullFloat64
+	HumidityUpper    sql.NullFloat64
+	Pressure         sql.NullFloat64
+	PressureLower    sql.NullFloat64
+	PressureUpper    sql.NullFloat64
+	WindSpeed        sql.NullFloat64
+	WindSpeedLower   sql.NullFloat64
+	WindSpeedUpper   sql.NullFloat64
+	CreatedAt        time.Time
+}
+
+type Location struct {
+	ID        int64
+	Name      string
+	Country   string
+	Latitude  sql.NullFloat64
+	Longitude sql.NullFloat64
+	Elevation sql.NullFloat64
+	Timezone  string
+}
+
+type Permission struct {
+	Name        string
+	Description string
+}
+
+type RefreshToken struct {
+	ID        string
+	UserID    int64
+	ExpiresAt time.Time
+	RevokedAt sql.NullTime
+}
+
+type Role struct {
+	Name        string
+	Description string
+}
+
+type RolePermission struct {
+	Role       string
+	Permission string
+}
+
+type User struct {
+	ID           int64
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+type UserRole struct {
+	UserID int64
+	Role   string
+}
+
 type Weather struct {
 	ID            int64
 	Timestamp     time.Time
-	City          string
-	Country       string
+	LocationID    int64
 	Temperature   float64
 	Humidity      float64
 	Pressure      float64
 	WindSpeed     float64
 	WeatherStatus string
+	ApiKeyID      sql.NullInt64
+	Version       int32
+	Latitude      sql.NullFloat64
+	Longitude     sql.NullFloat64
 }
@@ -2,9 +2,15 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 )
@@ -18,13 +24,14 @@ type PostgresConfig struct {
 }
 
 type DB struct {
-	db      *sqlx.DB
-	queries *Queries
+	db       *sqlx.DB
+	queries  *Queries
+	observer QueryObserver
 }
 
 func NewPostgres(config PostgresConfig) (*DB, error) {
 	dsn := fmt.Sprintf(
-		"user=%s password=%s dbname=%s host=%s port=%s sslmode=disable",
+		"user=%s password=%s dbname=%s host=%s port=%s sslmode=disable timezone=UTC",
 		config.PostgresUserName,
 		config.PostgresPassword,
 		config.PostgresDBName,
@@ -41,93 +48,612 @@ func NewPostgres(config PostgresConfig) (*DB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return &DB{
-		queries: New(db),
-	}, nil
+	res := &DB{db: db}
+	res.queries = New(res.dbtx(db))
+
+	return res, nil
+}
+
+func (db *DB) inTx(ctx context.Context, fn func(q *Queries) error) error {
+	tx, err := db.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(New(db.dbtx(tx.Tx))); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 func (db *DB) AddWeather(
 	ctx context.Context,
 	weather *models.Weather,
 ) (*models.Weather, error) {
-	arg := AddWeatherParams{
-		Timestamp:     weather.Timestamp,
-		Temperature:   weather.Temperature,
-		Humidity:      weather.Humidity,
-		Pressure:      weather.Pressure,
-		WindSpeed:     weather.WindSpeed,
-		City:          weather.City,
-		Country:       weather.Country,
-		WeatherStatus: weather.WeatherStatus,
+	res, err := db.writeWeather(ctx, func(q *Queries) (Weather, error) {
+		arg, err := addWeatherParams(ctx, q, weather)
+		if err != nil {
+			return Weather{}, err
+		}
+
+		return q.AddWeather(ctx, arg)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add weather: %w", translateError(err, nil))
 	}
 
-	res, err := db.queries.AddWeather(ctx, arg)
+	return res, nil
+}
+
+// AddWeathers inserts all observations with one statement in a single
+// transaction and returns their ids in input order. The location id of every
+// observation is set to the location it was resolved to.
+func (db *DB) AddWeathers(ctx context.Context, weathers []*models.Weather) ([]int, error) {
+	var ids []int
+
+	err := db.inTx(ctx, func(q *Queries) error {
+		var err error
+		ids, err = insertWeathers(ctx, q, weathers)
+
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to add weather: %w", err)
+		return nil, fmt.Errorf("failed to add weathers: %w", translateError(err, nil))
 	}
 
-	wth := dbWeatherToGlobal(res)
-	return &wth, nil
+	return ids, nil
+}
+
+// AddWeathersPartial is AddWeathers for a batch that may be stored in part.
+// If the database rejects the batch, every observation is inserted on its own
+// under a savepoint, so a rejected one loses only its own row. The ids and
+// errors are in input order; an observation has either an id or an error.
+func (db *DB) AddWeathersPartial(
+	ctx context.Context,
+	weathers []*models.Weather,
+) ([]int, []error, error) {
+	ids := make([]int, len(weathers))
+	errs := make([]error, len(weathers))
+
+	err := db.inTx(ctx, func(q *Queries) error {
+		err := savepoint(ctx, q, func() error {
+			res, err := insertWeathers(ctx, q, weathers)
+			copy(ids, res)
+
+			return err
+		})
+		if err == nil || rowError(err) == nil {
+			return err
+		}
+
+		for i := range weathers {
+			err := savepoint(ctx, q, func() error {
+				res, err := insertWeathers(ctx, q, weathers[i:i+1])
+				copy(ids[i:], res)
+
+				return err
+			})
+			if err == nil {
+				continue
+			}
+
+			if errs[i] = rowError(err); errs[i] == nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to add weathers: %w", translateError(err, nil))
+	}
+
+	return ids, errs, nil
+}
+
+// insertWeathers resolves the locations of the observations and inserts them
+// with one statement, returning their ids in input order. The location id,
+// city and country of every observation are set to the location it was
+// resolved to.
+func insertWeathers(ctx context.Context, q *Queries, weathers []*models.Weather) ([]int, error) {
+	locations, err := resolveLocations(ctx, q, weathers)
+	if err != nil {
+		return nil, err
+	}
+
+	var arg AddWeathersParams
+
+	for i, weather := range weathers {
+		latitude, longitude := coordinates(weather, locations[i])
+
+		arg.Timestamps = append(arg.Timestamps, weather.Timestamp)
+		arg.Temperatures = append(arg.Temperatures, weather.Temperature)
+		arg.Humidities = append(arg.Humidities, weather.Humidity)
+		arg.Pressures = append(arg.Pressures, weather.Pressure)
+		arg.WindSpeeds = append(arg.WindSpeeds, weather.WindSpeed)
+		arg.LocationIds = append(arg.LocationIds, locations[i].ID)
+		arg.WeatherStatuses = append(arg.WeatherStatuses, weather.WeatherStatus)
+		arg.ApiKeyIds = append(arg.ApiKeyIds, nullInt64(weather.APIKeyID).Int64)
+		arg.Latitudes = append(arg.Latitudes, nanIfNull(latitude))
+		arg.Longitudes = append(arg.Longitudes, nanIfNull(longitude))
+	}
+
+	rows, err := q.AddWeathers(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) != len(weathers) {
+		return nil, fmt.Errorf("inserted %d of %d weathers", len(rows), len(weathers))
+	}
+
+	ids := make([]int, len(weathers))
+	for _, row := range rows {
+		// ordinals count from 1
+		ids[row.Ord-1] = int(row.ID)
+	}
+
+	for i, weather := range weathers {
+		weather.LocationID = int(locations[i].ID)
+		weather.City, weather.Country = locations[i].Name, locations[i].Country
+	}
+
+	return ids, nil
+}
+
+// resolveLocations is resolveLocation for a batch: every distinct city and
+// country pair and every bare location id is looked up once. The locations
+// are returned in the order of weathers.
+func resolveLocations(ctx context.Context, q *Queries, weathers []*models.Weather) ([]Location, error) {
+	type place struct {
+		name    string
+		country string
+	}
+
+	// locations are matched case-insensitively, as the database does
+	placeOf := func(weather *models.Weather) place {
+		return place{name: strings.ToLower(weather.City), country: strings.ToLower(weather.Country)}
+	}
+
+	byPlace := make(map[place]Location)
+	byID := make(map[int64]Location)
+
+	var arg EnsureLocationsParams
+
+	var ids []int64
+
+	for _, weather := range weathers {
+		if weather.City != "" || weather.Country != "" {
+			if _, ok := byPlace[placeOf(weather)]; !ok {
+				byPlace[placeOf(weather)] = Location{}
+				arg.Names = append(arg.Names, weather.City)
+				arg.Countries = append(arg.Countries, weather.Country)
+			}
+
+			continue
+		}
+
+		if _, ok := byID[int64(weather.LocationID)]; !ok {
+			byID[int64(weather.LocationID)] = Location{}
+			ids = append(ids, int64(weather.LocationID))
+		}
+	}
+
+	if len(arg.Names) > 0 {
+		locs, err := q.EnsureLocations(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, loc := range locs {
+			byPlace[place{name: strings.ToLower(loc.Name), country: strings.ToLower(loc.Country)}] = loc
+		}
+	}
+
+	if len(ids) > 0 {
+		locs, err := q.ListLocationsByID(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, loc := range locs {
+			byID[loc.ID] = loc
+		}
+	}
+
+	res := make([]Location, len(weathers))
+
+	for i, weather := range weathers {
+		if weather.City == "" && weather.Country == "" {
+			if res[i] = byID[int64(weather.LocationID)]; res[i].ID == 0 {
+				return nil, fmt.Errorf(
+					"%w: unknown location_id=%d",
+					repository.ErrInvalid,
+					weather.LocationID,
+				)
+			}
+
+			continue
+		}
+
+		res[i] = byPlace[placeOf(weather)]
+		if res[i].ID != 0 {
+			continue
+		}
+
+		// the database lowercases some names differently; ask for this one alone
+		loc, err := resolveLocation(ctx, q, weather)
+		if err != nil {
+			return nil, err
+		}
+
+		byPlace[placeOf(weather)] = loc
+		res[i] = loc
+	}
+
+	return res, nil
+}
+
+// savepoint runs fn under a savepoint and rolls the transaction back to it if
+// fn fails, leaving the transaction usable.
+func savepoint(ctx context.Context, q *Queries, fn func() error) error {
+	if _, err := q.db.ExecContext(ctx, "SAVEPOINT weather_batch"); err != nil {
+		return fmt.Errorf("failed to set savepoint: %w", err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := q.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT weather_batch"); rbErr != nil {
+			return fmt.Errorf("failed to roll back to savepoint: %w", rbErr)
+		}
+
+		return err
+	}
+
+	if _, err := q.db.ExecContext(ctx, "RELEASE SAVEPOINT weather_batch"); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	return nil
 }
 
 func (db *DB) GetWeather(ctx context.Context, id int) (*models.Weather, error) {
 	res, err := db.queries.GetWeather(ctx, int64(id))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get weather: :%w", err)
+		return nil, fmt.Errorf(
+			"failed to get weather: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
 	}
 
-	wth := dbWeatherToGlobal(res)
+	wth := dbWeatherToGlobal(res.Weather, res.Location)
 	return &wth, nil
 }
 
-func (db *DB) ListWeathers(ctx context.Context) ([]*models.Weather, error) {
-	res, err := db.queries.ListWeathers(ctx)
+func (db *DB) ListWeathers(
+	ctx context.Context,
+	filter models.WeatherFilter,
+) ([]*models.Weather, error) {
+	var (
+		res []weatherRow
+		err error
+	)
+
+	if filter.SortBy == models.SortByTimestamp {
+		res, err = db.listWeathersByTimestamp(ctx, filter)
+	} else {
+		res, err = db.listWeathersByValue(ctx, filter)
+	}
+
 	if err != nil {
-		return nil, fmt.Errorf("failed to list weathers: %w", err)
+		return nil, fmt.Errorf("failed to list weathers: %w", translateError(err, nil))
 	}
 
 	weathers := make([]*models.Weather, len(res))
 	for i, v := range res {
-		wth := dbWeatherToGlobal(v)
+		wth := dbWeatherToGlobal(v.Weather, v.Location)
 		weathers[i] = &wth
 	}
 
 	return weathers, nil
 }
 
+func (db *DB) LatestWeathers(
+	ctx context.Context,
+	filter models.LatestFilter,
+) ([]*models.Weather, error) {
+	var cities []string
+	if filter.Cities != nil {
+		cities = make([]string, len(filter.Cities))
+		for i, city := range filter.Cities {
+			cities[i] = strings.ToLower(city)
+		}
+	}
+
+	res, err := weatherRows(db.queries.LatestWeathers(ctx, LatestWeathersParams{
+		Country: nullString(filter.Country),
+		Cities:  cities,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest weathers: %w", translateError(err, nil))
+	}
+
+	weathers := make([]*models.Weather, len(res))
+	for i, v := range res {
+		wth := dbWeatherToGlobal(v.Weather, v.Location)
+		weathers[i] = &wth
+	}
+
+	return weathers, nil
+}
+
+func (db *DB) listWeathersByTimestamp(
+	ctx context.Context,
+	filter models.WeatherFilter,
+) ([]weatherRow, error) {
+	arg := ListWeathersByTimestampAscParams{
+		LocationID:     nullInt64(filter.LocationID),
+		City:           nullString(filter.City),
+		Country:        nullString(filter.Country),
+		WeatherStatus:  nullString(filter.WeatherStatus),
+		FromTimestamp:  nullTime(filter.From),
+		ToTimestamp:    nullTime(filter.To),
+		MinTemperature: nullFloat64(filter.Temperature.Min),
+		MaxTemperature: nullFloat64(filter.Temperature.Max),
+		MinHumidity:    nullFloat64(filter.Humidity.Min),
+		MaxHumidity:    nullFloat64(filter.Humidity.Max),
+		MinPressure:    nullFloat64(filter.Pressure.Min),
+		MaxPressure:    nullFloat64(filter.Pressure.Max),
+		MinWindSpeed:   nullFloat64(filter.WindSpeed.Min),
+		MaxWindSpeed:   nullFloat64(filter.WindSpeed.Max),
+		PageSize:       int32(filter.Limit),
+	}
+
+	if filter.After != nil {
+		arg.CursorID = sql.NullInt64{Int64: int64(filter.After.ID), Valid: true}
+		arg.CursorTimestamp = sql.NullTime{Time: filter.After.Timestamp, Valid: true}
+	}
+
+	if filter.Order == models.SortAsc {
+		return weatherRows(db.queries.ListWeathersByTimestampAsc(ctx, arg))
+	}
+
+	return weatherRows(db.queries.ListWeathersByTimestampDesc(ctx, ListWeathersByTimestampDescParams(arg)))
+}
+
+// All numeric sort queries share the same parameter layout, so a single
+// params value is converted to the query-specific type.
+func (db *DB) listWeathersByValue(
+	ctx context.Context,
+	filter models.WeatherFilter,
+) ([]weatherRow, error) {
+	arg := ListWeathersByTemperatureAscParams{
+		LocationID:     nullInt64(filter.LocationID),
+		City:           nullString(filter.City),
+		Country:        nullString(filter.Country),
+		WeatherStatus:  nullString(filter.WeatherStatus),
+		FromTimestamp:  nullTime(filter.From),
+		ToTimestamp:    nullTime(filter.To),
+		MinTemperature: nullFloat64(filter.Temperature.Min),
+		MaxTemperature: nullFloat64(filter.Temperature.Max),
+		MinHumidity:    nullFloat64(filter.Humidity.Min),
+		MaxHumidity:    nullFloat64(filter.Humidity.Max),
+		MinPressure:    nullFloat64(filter.Pressure.Min),
+		MaxPressure:    nullFloat64(filter.Pressure.Max),
+		MinWindSpeed:   nullFloat64(filter.WindSpeed.Min),
+		MaxWindSpeed:   nullFloat64(filter.WindSpeed.Max),
+		PageSize:       int32(filter.Limit),
+	}
+
+	if filter.After != nil {
+		arg.CursorID = sql.NullInt64{Int64: int64(filter.After.ID), Valid: true}
+		arg.CursorValue = sql.NullFloat64{Float64: filter.After.Value, Valid: true}
+	}
+
+	asc := filter.Order == models.SortAsc
+
+	switch filter.SortBy {
+	case models.SortByTemperature:
+		if asc {
+			return weatherRows(db.queries.ListWeathersByTemperatureAsc(ctx, arg))
+		}
+
+		return weatherRows(db.queries.ListWeathersByTemperatureDesc(ctx, ListWeathersByTemperatureDescParams(arg)))
+	case models.SortByHumidity:
+		if asc {
+			return weatherRows(db.queries.ListWeathersByHumidityAsc(ctx, ListWeathersByHumidityAscParams(arg)))
+		}
+
+		return weatherRows(db.queries.ListWeathersByHumidityDesc(ctx, ListWeathersByHumidityDescParams(arg)))
+	case models.SortByPressure:
+		if asc {
+			return weatherRows(db.queries.ListWeathersByPressureAsc(ctx, ListWeathersByPressureAscParams(arg)))
+		}
+
+		return weatherRows(db.queries.ListWeathersByPressureDesc(ctx, ListWeathersByPressureDescParams(arg)))
+	case models.SortByWindSpeed:
+		if asc {
+			return weatherRows(db.queries.ListWeathersByWindSpeedAsc(ctx, ListWeathersByWindSpeedAscParams(arg)))
+		}
+
+		return weatherRows(db.queries.ListWeathersByWindSpeedDesc(ctx, ListWeathersByWindSpeedDescParams(arg)))
+	default:
+		return nil, fmt.Errorf("unsupported sort field %q", filter.SortBy)
+	}
+}
+
 func (db *DB) UpdateWeather(
 	ctx context.Context,
 	weather *models.Weather,
 ) (*models.Weather, error) {
-	arg := UpdateWeatherParams{
-		ID:        int64(weather.ID),
-		Timestamp: weather.Timestamp,
-		Column3:   weather.Temperature,
-		Column4:   weather.Humidity,
-		Column5:   weather.Pressure,
-		Column6:   weather.WindSpeed,
-		Column7:   weather.City,
-		Column8:   weather.Country,
-		Column9:   weather.WeatherStatus,
+	res, err := db.writeWeather(ctx, func(q *Queries) (Weather, error) {
+		loc, err := resolveLocation(ctx, q, weather)
+		if err != nil {
+			return Weather{}, err
+		}
+
+		latitude, longitude := coordinates(weather, loc)
+
+		return q.UpdateWeather(ctx, UpdateWeatherParams{
+			ID:            int64(weather.ID),
+			Timestamp:     weather.Timestamp,
+			Temperature:   weather.Temperature,
+			Humidity:      weather.Humidity,
+			Pressure:      weather.Pressure,
+			WindSpeed:     weather.WindSpeed,
+			LocationID:    loc.ID,
+			WeatherStatus: weather.WeatherStatus,
+			Latitude:      latitude,
+			Longitude:     longitude,
+			Version:       nullVersion(weather.Version),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to update weather: %w",
+			translateError(err, repository.NewErrNotFound(weather.ID)),
+		)
+	}
+
+	return res, nil
+}
+
+// PatchWeather moves the observation to another location when both city and
+// country are provided, or else when location_id is; callers fill in the
+// missing one of city and country from the stored observation.
+func (db *DB) PatchWeather(
+	ctx context.Context,
+	id int,
+	version int,
+	patch *models.WeatherPatch,
+) (*models.Weather, error) {
+	res, err := db.writeWeather(ctx, func(q *Queries) (Weather, error) {
+		arg := PatchWeatherParams{
+			ID:             int64(id),
+			Timestamp:      nullTime(patch.Timestamp.Ptr()),
+			Temperature:    nullFloat64(patch.Temperature.Ptr()),
+			Humidity:       nullFloat64(patch.Humidity.Ptr()),
+			Pressure:       nullFloat64(patch.Pressure.Ptr()),
+			WindSpeed:      nullFloat64(patch.WindSpeed.Ptr()),
+			WeatherStatus:  nullString(patch.WeatherStatus.Ptr()),
+			SetCoordinates: patch.Latitude.Set || patch.Longitude.Set,
+			Latitude:       nullFloat64(patch.Latitude.Ptr()),
+			Longitude:      nullFloat64(patch.Longitude.Ptr()),
+			Version:        nullVersion(version),
+		}
+
+		var target *models.Weather
+
+		switch city, country := patch.City.Ptr(), patch.Country.Ptr(); {
+		case city != nil && country != nil:
+			target = &models.Weather{City: *city, Country: *country}
+		case patch.LocationID.Set:
+			target = &models.Weather{LocationID: patch.LocationID.Value}
+		}
+
+		if target != nil {
+			loc, err := resolveLocation(ctx, q, target)
+			if err != nil {
+				return Weather{}, err
+			}
+
+			arg.LocationID = sql.NullInt64{Int64: loc.ID, Valid: true}
+		}
+
+		return q.PatchWeather(ctx, arg)
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to patch weather: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
 	}
 
-	res, err := db.queries.UpdateWeather(ctx, arg)
+	return res, nil
+}
+
+func (db *DB) DeleteWeather(ctx context.Context, id int, version int) (*models.Weather, error) {
+	res, err := db.writeWeather(ctx, func(q *Queries) (Weather, error) {
+		return q.DeleteWeather(ctx, DeleteWeatherParams{
+			ID:      int64(id),
+			Version: nullVersion(version),
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update weather: %w", err)
+		return nil, fmt.Errorf(
+			"failed to delete weather: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
 	}
 
-	ord := dbWeatherToGlobal(res)
-	return &ord, nil
+	return res, nil
 }
 
-func (db *DB) DeleteWeather(ctx context.Context, id int) (*models.Weather, error) {
-	res, err := db.queries.DeleteWeather(ctx, int64(id))
+// writeWeather runs a statement returning a weather row in a transaction and
+// loads the location the row references.
+func (db *DB) writeWeather(
+	ctx context.Context,
+	fn func(q *Queries) (Weather, error),
+) (*models.Weather, error) {
+	var wth models.Weather
+
+	err := db.inTx(ctx, func(q *Queries) error {
+		res, err := fn(q)
+		if err != nil {
+			return err
+		}
+
+		loc, err := q.GetLocation(ctx, res.LocationID)
+		if err != nil {
+			return err
+		}
+
+		wth = dbWeatherToGlobal(res, loc)
+
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to delete weather: %w", err)
+		return nil, err
+	}
+
+	return &wth, nil
+}
+
+// resolveLocation finds the location an observation is written for. City and
+// country take precedence over LocationID and create the location if missing.
+func resolveLocation(ctx context.Context, q *Queries, weather *models.Weather) (Location, error) {
+	if weather.City != "" || weather.Country != "" {
+		return q.EnsureLocation(ctx, EnsureLocationParams{
+			Name:    weather.City,
+			Country: weather.Country,
+		})
 	}
 
-	ord := dbWeatherToGlobal(res)
-	return &ord, nil
+	loc, err := q.GetLocation(ctx, int64(weather.LocationID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Location{}, fmt.Errorf(
+			"%w: unknown location_id=%d",
+			repository.ErrInvalid,
+			weather.LocationID,
+		)
+	}
+
+	return loc, err
+}
+
+// SQL returns the underlying connection pool for tools that work below the
+// query layer, such as the migration runner.
+func (db *DB) SQL() *sql.DB {
+	return db.db.DB
+}
+
+func (db *DB) Ping(ctx context.Context) error {
+	return db.db.PingContext(ctx)
 }
 
 func (db *DB) Close() error {
@@ -138,16 +664,139 @@ func (db *DB) Close() error {
 	return nil
 }
 
-func dbWeatherToGlobal(weather Weather) models.Weather {
+func addWeatherParams(
+	ctx context.Context,
+	q *Queries,
+	weather *models.Weather,
+) (AddWeatherParams, error) {
+	loc, err := resolveLocation(ctx, q, weather)
+	if err != nil {
+		return AddWeatherParams{}, err
+	}
+
+	latitude, longitude := coordinates(weather, loc)
+
+	return AddWeatherParams{
+		Timestamp:     weather.Timestamp,
+		Temperature:   weather.Temperature,
+		Humidity:      weather.Humidity,
+		Pressure:      weather.Pressure,
+		WindSpeed:     weather.WindSpeed,
+		LocationID:    loc.ID,
+		WeatherStatus: weather.WeatherStatus,
+		ApiKeyID:      nullInt64(weather.APIKeyID),
+		Latitude:      latitude,
+		Longitude:     longitude,
+	}, nil
+}
+
+// coordinates returns the coordinates of the observation, falling back to
+// those of its location.
+func coordinates(weather *models.Weather, loc Location) (sql.NullFloat64, sql.NullFloat64) {
+	if weather.Latitude != nil && weather.Longitude != nil {
+		return nullFloat64(weather.Latitude), nullFloat64(weather.Longitude)
+	}
+
+	return loc.Latitude, loc.Longitude
+}
+
+// weatherRow is the row shape of every query embedding the location of an
+// observation; sqlc generates a distinct but identical type for each one.
+type weatherRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func weatherRows[T ~struct {
+	Weather  Weather
+	Location Location
+}](rows []T, err error) ([]weatherRow, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]weatherRow, len(rows))
+	for i, row := range rows {
+		res[i] = weatherRow(row)
+	}
+
+	return res, nil
+}
+
+func dbWeatherToGlobal(weather Weather, location Location) models.Weather {
+	var apiKeyID *int
+	if weather.ApiKeyID.Valid {
+		id := int(weather.ApiKeyID.Int64)
+		apiKeyID = &id
+	}
+
+	loc := dbLocationToGlobal(location)
+
 	return models.Weather{
 		ID:            int(weather.ID),
-		Timestamp:     weather.Timestamp,
-		City:          weather.City,
-		Country:       weather.Country,
+		Timestamp:     weather.Timestamp.UTC(),
+		LocationID:    loc.ID,
+		Location:      &loc,
+		City:          loc.Name,
+		Country:       loc.Country,
+		Latitude:      floatPtr(weather.Latitude),
+		Longitude:     floatPtr(weather.Longitude),
 		Temperature:   weather.Temperature,
 		Humidity:      weather.Humidity,
 		Pressure:      weather.Pressure,
 		WindSpeed:     weather.WindSpeed,
 		WeatherStatus: weather.WeatherStatus,
+		APIKeyID:      apiKeyID,
+		Version:       int(weather.Version),
+	}
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: *s, Valid: true}
+}
+
+func nullInt64(i *int) sql.NullInt64 {
+	if i == nil {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(*i), Valid: true}
+}
+
+// nullVersion treats zero as "any version" and disables the check.
+func nullVersion(v int) sql.NullInt32 {
+	if v == 0 {
+		return sql.NullInt32{}
 	}
+
+	return sql.NullInt32{Int32: int32(v), Valid: true}
+}
+
+func nullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+// nanIfNull stands NaN in for NULL where a query cannot take NULLs.
+func nanIfNull(f sql.NullFloat64) float64 {
+	if !f.Valid {
+		return math.NaN()
+	}
+
+	return f.Float64
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
 }
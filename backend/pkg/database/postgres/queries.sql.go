@@ -7,13 +7,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 const addWeather = `-- name: AddWeather :one
-INSERT INTO weather (timestamp, temperature, humidity, pressure, wind_speed, city, country, weather_status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, timestamp, city, country, temperature, humidity, pressure, wind_speed, weather_status
+INSERT INTO weather (timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, timestamp, location_id, temperature, humidity, pressure, wind_speed, weather_status, api_key_id, version, latitude, longitude
 `
 
 type AddWeatherParams struct {
@@ -22,9 +25,11 @@ type AddWeatherParams struct {
 	Humidity      float64
 	Pressure      float64
 	WindSpeed     float64
-	City          string
-	Country       string
+	LocationID    int64
 	WeatherStatus string
+	ApiKeyID      sql.NullInt64
+	Latitude      sql.NullFloat64
+	Longitude     sql.NullFloat64
 }
 
 func (q *Queries) AddWeather(ctx context.Context, arg AddWeatherParams) (Weather, error) {
@@ -34,95 +39,1224 @@ func (q *Queries) AddWeather(ctx context.Context, arg AddWeatherParams) (Weather
 		arg.Humidity,
 		arg.Pressure,
 		arg.WindSpeed,
-		arg.City,
-		arg.Country,
+		arg.LocationID,
 		arg.WeatherStatus,
+		arg.ApiKeyID,
+		arg.Latitude,
+		arg.Longitude,
 	)
 	var i Weather
 	err := row.Scan(
 		&i.ID,
 		&i.Timestamp,
-		&i.City,
-		&i.Country,
+		&i.LocationID,
 		&i.Temperature,
 		&i.Humidity,
 		&i.Pressure,
 		&i.WindSpeed,
 		&i.WeatherStatus,
+		&i.ApiKeyID,
+		&i.Version,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
 
+const addWeathers = `-- name: AddWeathers :many
+WITH input AS (
+    SELECT nextval(pg_get_serial_sequence('weather', 'id')) AS id, rows.timestamp, rows.temperature, rows.humidity, rows.pressure, rows.wind_speed, rows.location_id, rows.weather_status, rows.api_key_id, rows.latitude, rows.longitude, rows.ord
+    FROM ROWS FROM (
+        unnest($1::timestamptz[]),
+        unnest($2::double precision[]),
+        unnest($3::double precision[]),
+        unnest($4::double precision[]),
+        unnest($5::double precision[]),
+        unnest($6::bigint[]),
+        unnest($7::text[]),
+        unnest($8::bigint[]),
+        unnest($9::double precision[]),
+        unnest($10::double precision[])
+    ) WITH ORDINALITY AS rows (timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude, ord)
+), inserted AS (
+    INSERT INTO weather (id, timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status, api_key_id, latitude, longitude)
+    OVERRIDING SYSTEM VALUE
+    SELECT id, timestamp, temperature, humidity, pressure, wind_speed, location_id, weather_status,
+        NULLIF(api_key_id, 0), NULLIF(latitude, 'NaN'), NULLIF(longitude, 'NaN')
+    FROM input
+    RETURNING id
+)
+SELECT input.ord::bigint AS ord, inserted.id
+FROM inserted
+JOIN input ON input.id = inserted.id
+`
+
+type AddWeathersParams struct {
+	Timestamps      []time.Time
+	Temperatures    []float64
+	Humidities      []float64
+	Pressures       []float64
+	WindSpeeds      []float64
+	LocationIds     []int64
+	WeatherStatuses []string
+	ApiKeyIds       []int64
+	Latitudes       []float64
+	Longitudes      []float64
+}
+
+type AddWeathersRow struct {
+	Ord int64
+	ID  int64
+}
+
+// Ids are drawn from the identity sequence up front so that every inserted row
+// can be matched back to the position of its input, as RETURNING has no order.
+// Arrays cannot carry NULLs here: a zero api key id and NaN coordinates stand
+// for them.
+func (q *Queries) AddWeathers(ctx context.Context, arg AddWeathersParams) ([]AddWeathersRow, error) {
+	rows, err := q.db.QueryContext(ctx, addWeathers,
+		pq.Array(arg.Timestamps),
+		pq.Array(arg.Temperatures),
+		pq.Array(arg.Humidities),
+		pq.Array(arg.Pressures),
+		pq.Array(arg.WindSpeeds),
+		pq.Array(arg.LocationIds),
+		pq.Array(arg.WeatherStatuses),
+		pq.Array(arg.ApiKeyIds),
+		pq.Array(arg.Latitudes),
+		pq.Array(arg.Longitudes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AddWeathersRow
+	for rows.Next() {
+		var i AddWeathersRow
+		if err := rows.Scan(&i.Ord, &i.ID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deleteWeather = `-- name: DeleteWeather :one
 DELETE FROM weather
 WHERE id = $1
-RETURNING id, timestamp, city, country, temperature, humidity, pressure, wind_speed, weather_status
+  AND ($2::int IS NULL OR version = $2::int)
+RETURNING id, timestamp, location_id, temperature, humidity, pressure, wind_speed, weather_status, api_key_id, version, latitude, longitude
 `
 
-func (q *Queries) DeleteWeather(ctx context.Context, id int64) (Weather, error) {
-	row := q.db.QueryRowContext(ctx, deleteWeather, id)
+type DeleteWeatherParams struct {
+	ID      int64
+	Version sql.NullInt32
+}
+
+func (q *Queries) DeleteWeather(ctx context.Context, arg DeleteWeatherParams) (Weather, error) {
+	row := q.db.QueryRowContext(ctx, deleteWeather, arg.ID, arg.Version)
 	var i Weather
 	err := row.Scan(
 		&i.ID,
 		&i.Timestamp,
-		&i.City,
-		&i.Country,
+		&i.LocationID,
 		&i.Temperature,
 		&i.Humidity,
 		&i.Pressure,
 		&i.WindSpeed,
 		&i.WeatherStatus,
+		&i.ApiKeyID,
+		&i.Version,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
 
 const getWeather = `-- name: GetWeather :one
-SELECT id, timestamp, city, country, temperature, humidity, pressure, wind_speed, weather_status 
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
 FROM weather
-WHERE id = $1
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.id = $1
 `
 
-func (q *Queries) GetWeather(ctx context.Context, id int64) (Weather, error) {
+type GetWeatherRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) GetWeather(ctx context.Context, id int64) (GetWeatherRow, error) {
 	row := q.db.QueryRowContext(ctx, getWeather, id)
-	var i Weather
+	var i GetWeatherRow
 	err := row.Scan(
-		&i.ID,
-		&i.Timestamp,
-		&i.City,
-		&i.Country,
-		&i.Temperature,
-		&i.Humidity,
-		&i.Pressure,
-		&i.WindSpeed,
-		&i.WeatherStatus,
+		&i.Weather.ID,
+		&i.Weather.Timestamp,
+		&i.Weather.LocationID,
+		&i.Weather.Temperature,
+		&i.Weather.Humidity,
+		&i.Weather.Pressure,
+		&i.Weather.WindSpeed,
+		&i.Weather.WeatherStatus,
+		&i.Weather.ApiKeyID,
+		&i.Weather.Version,
+		&i.Weather.Latitude,
+		&i.Weather.Longitude,
+		&i.Location.ID,
+		&i.Location.Name,
+		&i.Location.Country,
+		&i.Location.Latitude,
+		&i.Location.Longitude,
+		&i.Location.Elevation,
+		&i.Location.Timezone,
 	)
 	return i, err
 }
 
-const listWeathers = `-- name: ListWeathers :many
-SELECT id, timestamp, city, country, temperature, humidity, pressure, wind_speed, weather_status 
+const latestWeathers = `-- name: LatestWeathers :many
+SELECT DISTINCT ON (weather.location_id) weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
 FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::text IS NULL OR lower(locations.country) = lower($1::text))
+  AND ($2::text[] IS NULL OR lower(locations.name) = ANY($2::text[]))
+ORDER BY weather.location_id, weather.timestamp DESC, weather.id DESC
 `
 
-func (q *Queries) ListWeathers(ctx context.Context) ([]Weather, error) {
-	rows, err := q.db.QueryContext(ctx, listWeathers)
+type LatestWeathersParams struct {
+	Country sql.NullString
+	Cities  []string
+}
+
+type LatestWeathersRow struct {
+	Weather  Weather
+	Location Location
+}
+
+// Cities are compared in lower case, so callers pass them lowered.
+func (q *Queries) LatestWeathers(ctx context.Context, arg LatestWeathersParams) ([]LatestWeathersRow, error) {
+	rows, err := q.db.QueryContext(ctx, latestWeathers, arg.Country, pq.Array(arg.Cities))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Weather
+	var items []LatestWeathersRow
 	for rows.Next() {
-		var i Weather
+		var i LatestWeathersRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Timestamp,
-			&i.City,
-			&i.Country,
-			&i.Temperature,
-			&i.Humidity,
-			&i.Pressure,
-			&i.WindSpeed,
-			&i.WeatherStatus,
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByHumidityAsc = `-- name: ListWeathersByHumidityAsc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (humidity, weather.id) > ($16::float8, $15::bigint))
+ORDER BY humidity ASC, weather.id ASC
+LIMIT $17
+`
+
+type ListWeathersByHumidityAscParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByHumidityAscRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByHumidityAsc(ctx context.Context, arg ListWeathersByHumidityAscParams) ([]ListWeathersByHumidityAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByHumidityAsc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByHumidityAscRow
+	for rows.Next() {
+		var i ListWeathersByHumidityAscRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByHumidityDesc = `-- name: ListWeathersByHumidityDesc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (humidity, weather.id) < ($16::float8, $15::bigint))
+ORDER BY humidity DESC, weather.id DESC
+LIMIT $17
+`
+
+type ListWeathersByHumidityDescParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByHumidityDescRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByHumidityDesc(ctx context.Context, arg ListWeathersByHumidityDescParams) ([]ListWeathersByHumidityDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByHumidityDesc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByHumidityDescRow
+	for rows.Next() {
+		var i ListWeathersByHumidityDescRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByPressureAsc = `-- name: ListWeathersByPressureAsc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (pressure, weather.id) > ($16::float8, $15::bigint))
+ORDER BY pressure ASC, weather.id ASC
+LIMIT $17
+`
+
+type ListWeathersByPressureAscParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByPressureAscRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByPressureAsc(ctx context.Context, arg ListWeathersByPressureAscParams) ([]ListWeathersByPressureAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByPressureAsc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByPressureAscRow
+	for rows.Next() {
+		var i ListWeathersByPressureAscRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByPressureDesc = `-- name: ListWeathersByPressureDesc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (pressure, weather.id) < ($16::float8, $15::bigint))
+ORDER BY pressure DESC, weather.id DESC
+LIMIT $17
+`
+
+type ListWeathersByPressureDescParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByPressureDescRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByPressureDesc(ctx context.Context, arg ListWeathersByPressureDescParams) ([]ListWeathersByPressureDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByPressureDesc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByPressureDescRow
+	for rows.Next() {
+		var i ListWeathersByPressureDescRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByTemperatureAsc = `-- name: ListWeathersByTemperatureAsc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (temperature, weather.id) > ($16::float8, $15::bigint))
+ORDER BY temperature ASC, weather.id ASC
+LIMIT $17
+`
+
+type ListWeathersByTemperatureAscParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByTemperatureAscRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByTemperatureAsc(ctx context.Context, arg ListWeathersByTemperatureAscParams) ([]ListWeathersByTemperatureAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByTemperatureAsc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByTemperatureAscRow
+	for rows.Next() {
+		var i ListWeathersByTemperatureAscRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByTemperatureDesc = `-- name: ListWeathersByTemperatureDesc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (temperature, weather.id) < ($16::float8, $15::bigint))
+ORDER BY temperature DESC, weather.id DESC
+LIMIT $17
+`
+
+type ListWeathersByTemperatureDescParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByTemperatureDescRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByTemperatureDesc(ctx context.Context, arg ListWeathersByTemperatureDescParams) ([]ListWeathersByTemperatureDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByTemperatureDesc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByTemperatureDescRow
+	for rows.Next() {
+		var i ListWeathersByTemperatureDescRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByTimestampAsc = `-- name: ListWeathersByTimestampAsc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (timestamp, weather.id) > ($16::timestamptz, $15::bigint))
+ORDER BY timestamp ASC, weather.id ASC
+LIMIT $17
+`
+
+type ListWeathersByTimestampAscParams struct {
+	LocationID      sql.NullInt64
+	City            sql.NullString
+	Country         sql.NullString
+	WeatherStatus   sql.NullString
+	FromTimestamp   sql.NullTime
+	ToTimestamp     sql.NullTime
+	MinTemperature  sql.NullFloat64
+	MaxTemperature  sql.NullFloat64
+	MinHumidity     sql.NullFloat64
+	MaxHumidity     sql.NullFloat64
+	MinPressure     sql.NullFloat64
+	MaxPressure     sql.NullFloat64
+	MinWindSpeed    sql.NullFloat64
+	MaxWindSpeed    sql.NullFloat64
+	CursorID        sql.NullInt64
+	CursorTimestamp sql.NullTime
+	PageSize        int32
+}
+
+type ListWeathersByTimestampAscRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByTimestampAsc(ctx context.Context, arg ListWeathersByTimestampAscParams) ([]ListWeathersByTimestampAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByTimestampAsc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorTimestamp,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByTimestampAscRow
+	for rows.Next() {
+		var i ListWeathersByTimestampAscRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByTimestampDesc = `-- name: ListWeathersByTimestampDesc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (timestamp, weather.id) < ($16::timestamptz, $15::bigint))
+ORDER BY timestamp DESC, weather.id DESC
+LIMIT $17
+`
+
+type ListWeathersByTimestampDescParams struct {
+	LocationID      sql.NullInt64
+	City            sql.NullString
+	Country         sql.NullString
+	WeatherStatus   sql.NullString
+	FromTimestamp   sql.NullTime
+	ToTimestamp     sql.NullTime
+	MinTemperature  sql.NullFloat64
+	MaxTemperature  sql.NullFloat64
+	MinHumidity     sql.NullFloat64
+	MaxHumidity     sql.NullFloat64
+	MinPressure     sql.NullFloat64
+	MaxPressure     sql.NullFloat64
+	MinWindSpeed    sql.NullFloat64
+	MaxWindSpeed    sql.NullFloat64
+	CursorID        sql.NullInt64
+	CursorTimestamp sql.NullTime
+	PageSize        int32
+}
+
+type ListWeathersByTimestampDescRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByTimestampDesc(ctx context.Context, arg ListWeathersByTimestampDescParams) ([]ListWeathersByTimestampDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByTimestampDesc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorTimestamp,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByTimestampDescRow
+	for rows.Next() {
+		var i ListWeathersByTimestampDescRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersByWindSpeedAsc = `-- name: ListWeathersByWindSpeedAsc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (wind_speed, weather.id) > ($16::float8, $15::bigint))
+ORDER BY wind_speed ASC, weather.id ASC
+LIMIT $17
+`
+
+type ListWeathersByWindSpeedAscParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByWindSpeedAscRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByWindSpeedAsc(ctx context.Context, arg ListWeathersByWindSpeedAscParams) ([]ListWeathersByWindSpeedAscRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByWindSpeedAsc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByWindSpeedAscRow
+	for rows.Next() {
+		var i ListWeathersByWindSpeedAscRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -137,56 +1271,548 @@ func (q *Queries) ListWeathers(ctx context.Context) ([]Weather, error) {
 	return items, nil
 }
 
+const listWeathersByWindSpeedDesc = `-- name: ListWeathersByWindSpeedDesc :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($1::bigint IS NULL OR weather.location_id = $1::bigint)
+  AND ($2::text IS NULL OR lower(locations.name) = lower($2::text))
+  AND ($3::text IS NULL OR lower(locations.country) = lower($3::text))
+  AND ($4::text IS NULL OR weather_status = $4::text)
+  AND ($5::timestamptz IS NULL OR timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR timestamp < $6::timestamptz)
+  AND ($7::float8 IS NULL OR temperature >= $7::float8)
+  AND ($8::float8 IS NULL OR temperature <= $8::float8)
+  AND ($9::float8 IS NULL OR humidity >= $9::float8)
+  AND ($10::float8 IS NULL OR humidity <= $10::float8)
+  AND ($11::float8 IS NULL OR pressure >= $11::float8)
+  AND ($12::float8 IS NULL OR pressure <= $12::float8)
+  AND ($13::float8 IS NULL OR wind_speed >= $13::float8)
+  AND ($14::float8 IS NULL OR wind_speed <= $14::float8)
+  AND ($15::bigint IS NULL
+       OR (wind_speed, weather.id) < ($16::float8, $15::bigint))
+ORDER BY wind_speed DESC, weather.id DESC
+LIMIT $17
+`
+
+type ListWeathersByWindSpeedDescParams struct {
+	LocationID     sql.NullInt64
+	City           sql.NullString
+	Country        sql.NullString
+	WeatherStatus  sql.NullString
+	FromTimestamp  sql.NullTime
+	ToTimestamp    sql.NullTime
+	MinTemperature sql.NullFloat64
+	MaxTemperature sql.NullFloat64
+	MinHumidity    sql.NullFloat64
+	MaxHumidity    sql.NullFloat64
+	MinPressure    sql.NullFloat64
+	MaxPressure    sql.NullFloat64
+	MinWindSpeed   sql.NullFloat64
+	MaxWindSpeed   sql.NullFloat64
+	CursorID       sql.NullInt64
+	CursorValue    sql.NullFloat64
+	PageSize       int32
+}
+
+type ListWeathersByWindSpeedDescRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersByWindSpeedDesc(ctx context.Context, arg ListWeathersByWindSpeedDescParams) ([]ListWeathersByWindSpeedDescRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersByWindSpeedDesc,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.WeatherStatus,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.MinTemperature,
+		arg.MaxTemperature,
+		arg.MinHumidity,
+		arg.MaxHumidity,
+		arg.MinPressure,
+		arg.MaxPressure,
+		arg.MinWindSpeed,
+		arg.MaxWindSpeed,
+		arg.CursorID,
+		arg.CursorValue,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersByWindSpeedDescRow
+	for rows.Next() {
+		var i ListWeathersByWindSpeedDescRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersInBBox = `-- name: ListWeathersInBBox :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.latitude BETWEEN $1::float8 AND $2::float8
+  AND (($3::float8 <= $4::float8
+        AND weather.longitude BETWEEN $3::float8 AND $4::float8)
+       OR ($3::float8 > $4::float8
+           AND (weather.longitude >= $3::float8 OR weather.longitude <= $4::float8)))
+  AND ($5::timestamptz IS NULL OR weather.timestamp >= $5::timestamptz)
+  AND ($6::timestamptz IS NULL OR weather.timestamp < $6::timestamptz)
+ORDER BY weather.timestamp DESC, weather.id DESC
+LIMIT $7
+`
+
+type ListWeathersInBBoxParams struct {
+	MinLatitude   float64
+	MaxLatitude   float64
+	MinLongitude  float64
+	MaxLongitude  float64
+	FromTimestamp sql.NullTime
+	ToTimestamp   sql.NullTime
+	PageSize      int32
+}
+
+type ListWeathersInBBoxRow struct {
+	Weather  Weather
+	Location Location
+}
+
+func (q *Queries) ListWeathersInBBox(ctx context.Context, arg ListWeathersInBBoxParams) ([]ListWeathersInBBoxRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersInBBox,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersInBBoxRow
+	for rows.Next() {
+		var i ListWeathersInBBoxRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeathersNear = `-- name: ListWeathersNear :many
+SELECT weather.id, weather.timestamp, weather.location_id, weather.temperature, weather.humidity, weather.pressure, weather.wind_speed, weather.weather_status, weather.api_key_id, weather.version, weather.latitude, weather.longitude, locations.id, locations.name, locations.country, locations.latitude, locations.longitude, locations.elevation, locations.timezone,
+       haversine_km($1::float8, $2::float8, weather.latitude, weather.longitude)::float8 AS distance_km
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE weather.latitude BETWEEN $3::float8 AND $4::float8
+  AND (($5::float8 <= $6::float8
+        AND weather.longitude BETWEEN $5::float8 AND $6::float8)
+       OR ($5::float8 > $6::float8
+           AND (weather.longitude >= $5::float8 OR weather.longitude <= $6::float8)))
+  AND haversine_km($1::float8, $2::float8, weather.latitude, weather.longitude) <= $7::float8
+  AND ($8::timestamptz IS NULL OR weather.timestamp >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR weather.timestamp < $9::timestamptz)
+ORDER BY distance_km ASC, weather.timestamp DESC, weather.id DESC
+LIMIT $10
+`
+
+type ListWeathersNearParams struct {
+	Latitude      float64
+	Longitude     float64
+	MinLatitude   float64
+	MaxLatitude   float64
+	MinLongitude  float64
+	MaxLongitude  float64
+	RadiusKm      float64
+	FromTimestamp sql.NullTime
+	ToTimestamp   sql.NullTime
+	PageSize      int32
+}
+
+type ListWeathersNearRow struct {
+	Weather    Weather
+	Location   Location
+	DistanceKm float64
+}
+
+// The latitude/longitude box is a coarse prefilter served by
+// weather_latitude_longitude_idx; min_longitude > max_longitude means that the
+// box crosses the antimeridian.
+func (q *Queries) ListWeathersNear(ctx context.Context, arg ListWeathersNearParams) ([]ListWeathersNearRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWeathersNear,
+		arg.Latitude,
+		arg.Longitude,
+		arg.MinLatitude,
+		arg.MaxLatitude,
+		arg.MinLongitude,
+		arg.MaxLongitude,
+		arg.RadiusKm,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWeathersNearRow
+	for rows.Next() {
+		var i ListWeathersNearRow
+		if err := rows.Scan(
+			&i.Weather.ID,
+			&i.Weather.Timestamp,
+			&i.Weather.LocationID,
+			&i.Weather.Temperature,
+			&i.Weather.Humidity,
+			&i.Weather.Pressure,
+			&i.Weather.WindSpeed,
+			&i.Weather.WeatherStatus,
+			&i.Weather.ApiKeyID,
+			&i.Weather.Version,
+			&i.Weather.Latitude,
+			&i.Weather.Longitude,
+			&i.Location.ID,
+			&i.Location.Name,
+			&i.Location.Country,
+			&i.Location.Latitude,
+			&i.Location.Longitude,
+			&i.Location.Elevation,
+			&i.Location.Timezone,
+			&i.DistanceKm,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const patchWeather = `-- name: PatchWeather :one
+UPDATE weather
+SET
+    timestamp = COALESCE($1::timestamptz, timestamp),
+    temperature = COALESCE($2::float8, temperature),
+    humidity = COALESCE($3::float8, humidity),
+    pressure = COALESCE($4::float8, pressure),
+    wind_speed = COALESCE($5::float8, wind_speed),
+    location_id = COALESCE($6::bigint, location_id),
+    weather_status = COALESCE($7::text, weather_status),
+    latitude = CASE WHEN $8::bool THEN $9::float8 ELSE latitude END,
+    longitude = CASE WHEN $8::bool THEN $10::float8 ELSE longitude END,
+    version = version + 1
+WHERE id = $11
+  AND ($12::int IS NULL OR version = $12::int)
+RETURNING id, timestamp, location_id, temperature, humidity, pressure, wind_speed, weather_status, api_key_id, version, latitude, longitude
+`
+
+type PatchWeatherParams struct {
+	Timestamp      sql.NullTime
+	Temperature    sql.NullFloat64
+	Humidity       sql.NullFloat64
+	Pressure       sql.NullFloat64
+	WindSpeed      sql.NullFloat64
+	LocationID     sql.NullInt64
+	WeatherStatus  sql.NullString
+	SetCoordinates bool
+	Latitude       sql.NullFloat64
+	Longitude      sql.NullFloat64
+	ID             int64
+	Version        sql.NullInt32
+}
+
+// Apart from the coordinates every column is NOT NULL, so a NULL argument
+// unambiguously means that the field was not provided and keeps its current
+// value. Coordinates may be cleared, hence the explicit set_coordinates flag.
+func (q *Queries) PatchWeather(ctx context.Context, arg PatchWeatherParams) (Weather, error) {
+	row := q.db.QueryRowContext(ctx, patchWeather,
+		arg.Timestamp,
+		arg.Temperature,
+		arg.Humidity,
+		arg.Pressure,
+		arg.WindSpeed,
+		arg.LocationID,
+		arg.WeatherStatus,
+		arg.SetCoordinates,
+		arg.Latitude,
+		arg.Longitude,
+		arg.ID,
+		arg.Version,
+	)
+	var i Weather
+	err := row.Scan(
+		&i.ID,
+		&i.Timestamp,
+		&i.LocationID,
+		&i.Temperature,
+		&i.Humidity,
+		&i.Pressure,
+		&i.WindSpeed,
+		&i.WeatherStatus,
+		&i.ApiKeyID,
+		&i.Version,
+		&i.Latitude,
+		&i.Longitude,
+	)
+	return i, err
+}
+
 const updateWeather = `-- name: UpdateWeather :one
 UPDATE weather
 SET 
-    timestamp = $2,
-    temperature = COALESCE(NULLIF($3, 0), temperature),
-    humidity = COALESCE(NULLIF($4, 0), humidity),
-    pressure = COALESCE(NULLIF($5, 0), pressure),
-    wind_speed = COALESCE(NULLIF($6, 0), wind_speed),
-    city = COALESCE(NULLIF($7, ''), city),
-    country = COALESCE(NULLIF($8, ''), country),
-    weather_status = COALESCE(NULLIF($9, ''), weather_status)
-WHERE id = $1
-RETURNING id, timestamp, city, country, temperature, humidity, pressure, wind_speed, weather_status
+    timestamp = $1,
+    temperature = $2,
+    humidity = $3,
+    pressure = $4,
+    wind_speed = $5,
+    location_id = $6,
+    weather_status = $7,
+    latitude = $8,
+    longitude = $9,
+    version = version + 1
+WHERE id = $10
+  AND ($11::int IS NULL OR version = $11::int)
+RETURNING id, timestamp, location_id, temperature, humidity, pressure, wind_speed, weather_status, api_key_id, version, latitude, longitude
 `
 
 type UpdateWeatherParams struct {
-	ID        int64
-	Timestamp time.Time
-	Column3   interface{}
-	Column4   interface{}
-	Column5   interface{}
-	Column6   interface{}
-	Column7   interface{}
-	Column8   interface{}
-	Column9   interface{}
+	Timestamp     time.Time
+	Temperature   float64
+	Humidity      float64
+	Pressure      float64
+	WindSpeed     float64
+	LocationID    int64
+	WeatherStatus string
+	Latitude      sql.NullFloat64
+	Longitude     sql.NullFloat64
+	ID            int64
+	Version       sql.NullInt32
 }
 
+// A NULL version skips the optimistic concurrency check (If-Match: *).
 func (q *Queries) UpdateWeather(ctx context.Context, arg UpdateWeatherParams) (Weather, error) {
 	row := q.db.QueryRowContext(ctx, updateWeather,
-		arg.ID,
 		arg.Timestamp,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Column6,
-		arg.Column7,
-		arg.Column8,
-		arg.Column9,
+		arg.Temperature,
+		arg.Humidity,
+		arg.Pressure,
+		arg.WindSpeed,
+		arg.LocationID,
+		arg.WeatherStatus,
+		arg.Latitude,
+		arg.Longitude,
+		arg.ID,
+		arg.Version,
 	)
 	var i Weather
 	err := row.Scan(
 		&i.ID,
 		&i.Timestamp,
-		&i.City,
-		&i.Country,
+		&i.LocationID,
 		&i.Temperature,
 		&i.Humidity,
 		&i.Pressure,
 		&i.WindSpeed,
 		&i.WeatherStatus,
+		&i.ApiKeyID,
+		&i.Version,
+		&i.Latitude,
+		&i.Longitude,
 	)
 	return i, err
 }
+
+const weatherStats = `-- name: WeatherStats :many
+SELECT locations.id AS location_id,
+       locations.name AS city,
+       locations.country,
+       COALESCE($1::text, locations.timezone)::text AS timezone,
+       date_trunc($2::text, weather.timestamp,
+                  COALESCE($1::text, locations.timezone))::timestamptz AS period_start,
+       count(*) AS count,
+       min(weather.temperature)::float8 AS min_temperature,
+       max(weather.temperature)::float8 AS max_temperature,
+       avg(weather.temperature)::float8 AS mean_temperature,
+       stddev_pop(weather.temperature)::float8 AS stddev_temperature,
+       min(weather.humidity)::float8 AS min_humidity,
+       max(weather.humidity)::float8 AS max_humidity,
+       avg(weather.humidity)::float8 AS mean_humidity,
+       stddev_pop(weather.humidity)::float8 AS stddev_humidity,
+       min(weather.pressure)::float8 AS min_pressure,
+       max(weather.pressure)::float8 AS max_pressure,
+       avg(weather.pressure)::float8 AS mean_pressure,
+       stddev_pop(weather.pressure)::float8 AS stddev_pressure,
+       min(weather.wind_speed)::float8 AS min_wind_speed,
+       max(weather.wind_speed)::float8 AS max_wind_speed,
+       avg(weather.wind_speed)::float8 AS mean_wind_speed,
+       stddev_pop(weather.wind_speed)::float8 AS stddev_wind_speed
+FROM weather
+JOIN locations ON locations.id = weather.location_id
+WHERE ($3::bigint IS NULL OR weather.location_id = $3::bigint)
+  AND ($4::text IS NULL OR lower(locations.name) = lower($4::text))
+  AND ($5::text IS NULL OR lower(locations.country) = lower($5::text))
+  AND ($6::timestamptz IS NULL OR weather.timestamp >= $6::timestamptz)
+  AND ($7::timestamptz IS NULL OR weather.timestamp < $7::timestamptz)
+GROUP BY locations.id, period_start
+ORDER BY locations.name, locations.country, locations.id, period_start
+`
+
+type WeatherStatsParams struct {
+	TimeZone      sql.NullString
+	Bucket        string
+	LocationID    sql.NullInt64
+	City          sql.NullString
+	Country       sql.NullString
+	FromTimestamp sql.NullTime
+	ToTimestamp   sql.NullTime
+}
+
+type WeatherStatsRow struct {
+	LocationID        int64
+	City              string
+	Country           string
+	Timezone          string
+	PeriodStart       time.Time
+	Count             int64
+	MinTemperature    float64
+	MaxTemperature    float64
+	MeanTemperature   float64
+	StddevTemperature float64
+	MinHumidity       float64
+	MaxHumidity       float64
+	MeanHumidity      float64
+	StddevHumidity    float64
+	MinPressure       float64
+	MaxPressure       float64
+	MeanPressure      float64
+	StddevPressure    float64
+	MinWindSpeed      float64
+	MaxWindSpeed      float64
+	MeanWindSpeed     float64
+	StddevWindSpeed   float64
+}
+
+// bucket is one of hour, day, week or month and is validated by the caller.
+// Periods start at local midnight (or hour, week, month) of every location
+// unless time_zone overrides the location's own time zone.
+func (q *Queries) WeatherStats(ctx context.Context, arg WeatherStatsParams) ([]WeatherStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, weatherStats,
+		arg.TimeZone,
+		arg.Bucket,
+		arg.LocationID,
+		arg.City,
+		arg.Country,
+		arg.FromTimestamp,
+		arg.ToTimestamp,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WeatherStatsRow
+	for rows.Next() {
+		var i WeatherStatsRow
+		if err := rows.Scan(
+			&i.LocationID,
+			&i.City,
+			&i.Country,
+			&i.Timezone,
+			&i.PeriodStart,
+			&i.Count,
+			&i.MinTemperature,
+			&i.MaxTemperature,
+			&i.MeanTemperature,
+			&i.StddevTemperature,
+			&i.MinHumidity,
+			&i.MaxHumidity,
+			&i.MeanHumidity,
+			&i.StddevHumidity,
+			&i.MinPressure,
+			&i.MaxPressure,
+			&i.MeanPressure,
+			&i.StddevPressure,
+			&i.MinWindSpeed,
+			&i.MaxWindSpeed,
+			&i.MeanWindSpeed,
+			&i.StddevWindSpeed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+)
+
+func (db *DB) WeatherStats(
+	ctx context.Context,
+	filter models.StatsFilter,
+) ([]*models.WeatherStats, error) {
+	res, err := db.queries.WeatherStats(ctx, WeatherStatsParams{
+		Bucket:        string(filter.Bucket),
+		LocationID:    nullInt64(filter.LocationID),
+		City:          nullString(filter.City),
+		Country:       nullString(filter.Country),
+		FromTimestamp: nullTime(filter.From),
+		ToTimestamp:   nullTime(filter.To),
+		TimeZone:      nullString(filter.TimeZone),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weather stats: %w", translateError(err, nil))
+	}
+
+	stats := make([]*models.WeatherStats, len(res))
+	for i, v := range res {
+		stats[i] = &models.WeatherStats{
+			LocationID:  int(v.LocationID),
+			City:        v.City,
+			Country:     v.Country,
+			Timezone:    v.Timezone,
+			PeriodStart: v.PeriodStart.UTC(),
+			Count:       int(v.Count),
+			Temperature: models.MetricStats{
+				Min:    v.MinTemperature,
+				Max:    v.MaxTemperature,
+				Mean:   v.MeanTemperature,
+				Stddev: v.StddevTemperature,
+			},
+			Humidity: models.MetricStats{
+				Min:    v.MinHumidity,
+				Max:    v.MaxHumidity,
+				Mean:   v.MeanHumidity,
+				Stddev: v.StddevHumidity,
+			},
+			Pressure: models.MetricStats{
+				Min:    v.MinPressure,
+				Max:    v.MaxPressure,
+				Mean:   v.MeanPressure,
+				Stddev: v.StddevPressure,
+			},
+			WindSpeed: models.MetricStats{
+				Min:    v.MinWindSpeed,
+				Max:    v.MaxWindSpeed,
+				Mean:   v.MeanWindSpeed,
+				Stddev: v.StddevWindSpeed,
+			},
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,241 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/models"
+	"github.com/LLIEPJIOK/weather-forecast/backend/internal/repository"
+)
+
+func (db *DB) AddUser(ctx context.Context, user *models.User) (*models.User, error) {
+	var usr models.User
+
+	err := db.inTx(ctx, func(q *Queries) error {
+		res, err := q.AddUser(ctx, AddUserParams{
+			Username:     user.Username,
+			PasswordHash: user.PasswordHash,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, role := range user.Roles {
+			err := q.AddUserRole(ctx, AddUserRoleParams{UserID: res.ID, Role: string(role)})
+			if err != nil {
+				return err
+			}
+		}
+
+		usr = dbUserToGlobal(res, user.Roles)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add user: %w", translateError(err, nil))
+	}
+
+	return &usr, nil
+}
+
+func (db *DB) GetUser(ctx context.Context, id int) (*models.User, error) {
+	res, err := db.queries.GetUser(ctx, int64(id))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get user: %w",
+			translateError(err, repository.NewErrNotFound(id)),
+		)
+	}
+
+	roles, err := db.queries.ListUserRoles(ctx, res.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", translateError(err, nil))
+	}
+
+	usr := dbUserToGlobal(res, toRoles(roles))
+	return &usr, nil
+}
+
+func (db *DB) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	res, err := db.queries.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get user by username: %w",
+			translateError(err, repository.NewErrNotFoundBy("username", username)),
+		)
+	}
+
+	roles, err := db.queries.ListUserRoles(ctx, res.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", translateError(err, nil))
+	}
+
+	usr := dbUserToGlobal(res, toRoles(roles))
+	return &usr, nil
+}
+
+func (db *DB) ListUsers(ctx context.Context) ([]*models.User, error) {
+	res, err := db.queries.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", translateError(err, nil))
+	}
+
+	userRoles, err := db.queries.ListAllUserRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user roles: %w", translateError(err, nil))
+	}
+
+	roles := make(map[int64][]models.Role, len(res))
+	for _, ur := range userRoles {
+		roles[ur.UserID] = append(roles[ur.UserID], models.Role(ur.Role))
+	}
+
+	users := make([]*models.User, len(res))
+	for i, v := range res {
+		usr := dbUserToGlobal(v, roles[v.ID])
+		users[i] = &usr
+	}
+
+	return users, nil
+}
+
+func (db *DB) SetUserRoles(ctx context.Context, userID int, roles []models.Role) error {
+	err := db.inTx(ctx, func(q *Queries) error {
+		if err := q.DeleteUserRoles(ctx, int64(userID)); err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			err := q.AddUserRole(ctx, AddUserRoleParams{UserID: int64(userID), Role: string(role)})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set user roles: %w", translateError(err, nil))
+	}
+
+	return nil
+}
+
+func (db *DB) ListUserPermissions(ctx context.Context, userID int) ([]models.Permission, error) {
+	res, err := db.queries.ListUserPermissions(ctx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user permissions: %w", translateError(err, nil))
+	}
+
+	permissions := make([]models.Permission, len(res))
+	for i, v := range res {
+		permissions[i] = models.Permission(v)
+	}
+
+	return permissions, nil
+}
+
+func (db *DB) ListRoles(ctx context.Context) ([]*models.RoleDefinition, error) {
+	res, err := db.queries.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", translateError(err, nil))
+	}
+
+	roles := make([]*models.RoleDefinition, len(res))
+	for i, v := range res {
+		permissions := make([]models.Permission, len(v.Permissions))
+		for j, p := range v.Permissions {
+			permissions[j] = models.Permission(p)
+		}
+
+		roles[i] = &models.RoleDefinition{
+			Name:        models.Role(v.Name),
+			Description: v.Description,
+			Permissions: permissions,
+		}
+	}
+
+	return roles, nil
+}
+
+func (db *DB) AddRefreshToken(
+	ctx context.Context,
+	token *models.RefreshToken,
+) (*models.RefreshToken, error) {
+	arg := AddRefreshTokenParams{
+		ID:        token.ID,
+		UserID:    int64(token.UserID),
+		ExpiresAt: token.ExpiresAt.UTC(),
+	}
+
+	res, err := db.queries.AddRefreshToken(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add refresh token: %w", translateError(err, nil))
+	}
+
+	tkn := dbRefreshTokenToGlobal(res)
+	return &tkn, nil
+}
+
+func (db *DB) GetRefreshToken(ctx context.Context, id string) (*models.RefreshToken, error) {
+	res, err := db.queries.GetRefreshToken(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get refresh token: %w",
+			translateError(err, repository.NewErrNotFoundBy("token", id)),
+		)
+	}
+
+	tkn := dbRefreshTokenToGlobal(res)
+	return &tkn, nil
+}
+
+// RevokeRefreshToken returns ErrNotFound unless it is the one to revoke the
+// token, so concurrent rotations of the same token cannot both succeed.
+func (db *DB) RevokeRefreshToken(ctx context.Context, id string) error {
+	if _, err := db.queries.RevokeRefreshToken(ctx, id); err != nil {
+		return fmt.Errorf(
+			"failed to revoke refresh token: %w",
+			translateError(err, repository.NewErrNotFoundBy("token", id)),
+		)
+	}
+
+	return nil
+}
+
+func dbUserToGlobal(user User, roles []models.Role) models.User {
+	if roles == nil {
+		roles = []models.Role{}
+	}
+
+	return models.User{
+		ID:           int(user.ID),
+		Username:     user.Username,
+		PasswordHash: user.PasswordHash,
+		Roles:        roles,
+		CreatedAt:    user.CreatedAt,
+	}
+}
+
+func dbRefreshTokenToGlobal(token RefreshToken) models.RefreshToken {
+	var revokedAt *time.Time
+	if token.RevokedAt.Valid {
+		revokedAt = &token.RevokedAt.Time
+	}
+
+	return models.RefreshToken{
+		ID:        token.ID,
+		UserID:    int(token.UserID),
+		ExpiresAt: token.ExpiresAt,
+		RevokedAt: revokedAt,
+	}
+}
+
+func toRoles(roles []string) []models.Role {
+	res := make([]models.Role, len(roles))
+	for i, v := range roles {
+		res[i] = models.Role(v)
+	}
+
+	return res
+}
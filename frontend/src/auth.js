@@ -0,0 +1,93 @@
+import axios from "axios"
+
+const API_URL = "http://localhost:8080"
+
+const ACCESS_TOKEN_KEY = "access_token"
+const REFRESH_TOKEN_KEY = "refresh_token"
+
+const decodePayload = token => {
+	try {
+		const payload = token.split(".")[1].replace(/-/g, "+").replace(/_/g, "/")
+		return JSON.parse(atob(payload))
+	} catch {
+		return null
+	}
+}
+
+const storeTokens = data => {
+	localStorage.setItem(ACCESS_TOKEN_KEY, data.access_token)
+	localStorage.setItem(REFRESH_TOKEN_KEY, data.refresh_token)
+}
+
+const clearTokens = () => {
+	localStorage.removeItem(ACCESS_TOKEN_KEY)
+	localStorage.removeItem(REFRESH_TOKEN_KEY)
+}
+
+const getPayload = () => {
+	const token = localStorage.getItem(ACCESS_TOKEN_KEY)
+	return token ? decodePayload(token) : null
+}
+
+export const isLoggedIn = () => getPayload() !== null
+
+export const hasPermission = permission => {
+	const payload = getPayload()
+	return Boolean(payload?.permissions?.includes(permission))
+}
+
+export const login = (username, password) =>
+	axios
+		.post(`${API_URL}/auth/login`, { username, password })
+		.then(response => storeTokens(response.data))
+
+export const logout = () => {
+	const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY)
+	clearTokens()
+
+	if (!refreshToken) {
+		return Promise.resolve()
+	}
+
+	return axios
+		.post(`${API_URL}/auth/logout`, { refresh_token: refreshToken })
+		.catch(error => console.error("Error logging out:", error))
+}
+
+axios.interceptors.request.use(config => {
+	const token = localStorage.getItem(ACCESS_TOKEN_KEY)
+	if (token && !config.url.startsWith(`${API_URL}/auth/`)) {
+		config.headers.Authorization = `Bearer ${token}`
+	}
+	return config
+})
+
+axios.interceptors.response.use(
+	response => response,
+	error => {
+		const original = error.config
+		const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY)
+
+		if (
+			error.response?.status !== 401 ||
+			!refreshToken ||
+			original._retry ||
+			original.url.startsWith(`${API_URL}/auth/`)
+		) {
+			return Promise.reject(error)
+		}
+
+		original._retry = true
+
+		return axios
+			.post(`${API_URL}/auth/refresh`, { refresh_token: refreshToken })
+			.then(response => {
+				storeTokens(response.data)
+				return axios(original)
+			})
+			.catch(refreshError => {
+				clearTokens()
+				return Promise.reject(refreshError)
+			})
+	}
+)
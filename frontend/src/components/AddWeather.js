@@ -1,7 +1,7 @@
 import axios from "axios"
-import Cookies from "js-cookie"
 import React, { useEffect, useState } from "react"
 import { useNavigate } from "react-router-dom"
+import { hasPermission } from "../auth"
 import AddWeatherForm from "./AddWeatherForm"
 import observationTemplate from "./observationTemplate"
 
@@ -10,8 +10,7 @@ const AddWeather = () => {
 	const navigate = useNavigate()
 
 	useEffect(() => {
-		var userRole = Cookies.get("X-User-Role")
-		if (userRole !== "admin") {
+		if (!hasPermission("weather:create")) {
 			navigate("/login")
 		}
 	}, [])
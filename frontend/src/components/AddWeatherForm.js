@@ -1,5 +1,6 @@
 import React, { useState } from "react"
 import "./AddWeatherForm.css"
+import weatherStatuses from "./weatherStatuses"
 
 const AddWeatherForm = ({ observation, handleChange, handleSubmit }) => {
 	const [errors, setErrors] = useState({})
@@ -179,12 +180,18 @@ const AddWeatherForm = ({ observation, handleChange, handleSubmit }) => {
 
 				<div className="form-group">
 					<label>Weather Status:</label>
-					<input
+					<select
 						name="weatherStatus"
 						value={observation.weatherStatus}
 						onChange={handleChange}
-						className={getInputClass("weatherStatus")}
-					/>
+						className={getInputClass("weatherStatus")}>
+						<option value="">Select a status</option>
+						{weatherStatuses.map(status => (
+							<option key={status.value} value={status.value}>
+								{status.label}
+							</option>
+						))}
+					</select>
 					{errors.weatherStatus && (
 						<span className="error-message">{errors.weatherStatus}</span>
 					)}
@@ -1,6 +1,6 @@
-import Cookies from "js-cookie"
 import React, { useEffect, useState } from "react"
 import { useNavigate } from "react-router-dom"
+import { isLoggedIn, login } from "../auth"
 import "./Login.css"
 
 const LoginPage = () => {
@@ -10,8 +10,7 @@ const LoginPage = () => {
 	const navigate = useNavigate()
 
 	useEffect(() => {
-		var userRole = Cookies.get("X-User-Role")
-		if (userRole === "admin") {
+		if (isLoggedIn()) {
 			navigate("/")
 		}
 	}, [])
@@ -19,17 +18,20 @@ const LoginPage = () => {
 	const handleLogin = e => {
 		e.preventDefault()
 
-		if (username === "admin" && password === "admin") {
-			Cookies.set("X-User-Role", "admin", { path: "/", expires: 1 })
-			navigate("/")
-		} else {
-			setError("Invalid username or password")
-		}
+		login(username, password)
+			.then(() => navigate("/"))
+			.catch(error => {
+				if (error.response?.status === 401) {
+					setError("Invalid username or password")
+				} else {
+					setError("The server is temporarily unavailable, please try again later")
+				}
+			})
 	}
 
 	return (
 		<div className="login-container">
-			<h1>Admin login</h1>
+			<h1>Login</h1>
 			<form onSubmit={handleLogin} className="login-form">
 				<div className="form-group">
 					<label htmlFor="username">Username:</label>
@@ -1,15 +1,14 @@
 import React from "react"
-import Cookies from "js-cookie"
 import { Link, useNavigate } from "react-router-dom"
+import { hasPermission, isLoggedIn, logout } from "../auth"
 import "./Navbar.css" // Импорт файла CSS
 
 const Navbar = () => {
 	const navigate = useNavigate()
-	const userRole = Cookies.get("X-User-Role")
+	const loggedIn = isLoggedIn()
 
 	const handleLogout = () => {
-		Cookies.remove("X-User-Role")
-		navigate("/login")
+		logout().then(() => navigate("/login"))
 	}
 
 	return (
@@ -19,12 +18,12 @@ const Navbar = () => {
 				<Link to="/" className="navbar-link">
 					View Weather
 				</Link>
-				{userRole === "admin" && (
+				{hasPermission("weather:create") && (
 					<Link to="/add" className="navbar-link">
 						Add Weather
 					</Link>
 				)}
-				{userRole ? (
+				{loggedIn ? (
 					<button onClick={handleLogout} className="navbar-button">
 						Logout
 					</button>
@@ -1,8 +1,9 @@
 import axios from "axios"
-import Cookies from "js-cookie"
 import React, { useEffect, useState } from "react"
 import { useNavigate, useParams } from "react-router-dom"
+import { hasPermission } from "../auth"
 import "./UpdateWeather.css"
+import weatherStatuses from "./weatherStatuses"
 
 const UpdateWeather = () => {
 	const { id } = useParams() // Getting the ID from the URL params
@@ -17,10 +18,11 @@ const UpdateWeather = () => {
 		country: "",
 		weather_status: "",
 	})
+	const [etag, setEtag] = useState(null)
+	const [conflict, setConflict] = useState(false)
 
 	useEffect(() => {
-		var userRole = Cookies.get("X-User-Role")
-		if (userRole !== "admin") {
+		if (!hasPermission("weather:update")) {
 			navigate("/login")
 		}
 
@@ -33,6 +35,7 @@ const UpdateWeather = () => {
 					? new Date(response.data.timestamp).toISOString().split("T")[0]
 					: ""
 				setObservation({ ...response.data, timestamp })
+				setEtag(response.headers.etag)
 			})
 			.catch(error => console.error("Error fetching observation:", error))
 	}, [id])
@@ -56,14 +59,28 @@ const UpdateWeather = () => {
 			weather_status: observation.weather_status,
 		}
 		axios
-			.put(`http://localhost:8080/weather/${id}`, formattedObservation)
+			.put(`http://localhost:8080/weather/${id}`, formattedObservation, {
+				headers: { "If-Match": etag },
+			})
 			.then(() => navigate(`/details/${id}`)) // Redirect to the main page after successful update
-			.catch(error => console.error("Error updating observation:", error))
+			.catch(error => {
+				if (error.response && error.response.status === 412) {
+					setConflict(true)
+					return
+				}
+				console.error("Error updating observation:", error)
+			})
 	}
 
 	return (
 		<div className="update-container">
 			<h1 className="update-title">Edit Weather Observation</h1>
+			{conflict && (
+				<p className="update-conflict">
+					This observation was changed by someone else. Reload the page to
+					see the latest version before saving.
+				</p>
+			)}
 			<form onSubmit={handleSubmit} className="update-form">
 				<div className="form-group">
 					<label>Date:</label>
@@ -137,13 +154,26 @@ const UpdateWeather = () => {
 				</div>
 				<div className="form-group">
 					<label>Weather Status:</label>
-					<input
+					<select
 						name="weather_status"
-						type="text"
 						value={observation.weather_status}
 						onChange={handleChange}
-						className="form-control"
-					/>
+						className="form-control">
+						{/* a status stored before the list existed is shown, but has to be replaced */}
+						{observation.weather_status &&
+							!weatherStatuses.some(
+								status => status.value === observation.weather_status
+							) && (
+								<option value={observation.weather_status} disabled>
+									{observation.weather_status} (no longer accepted)
+								</option>
+							)}
+						{weatherStatuses.map(status => (
+							<option key={status.value} value={status.value}>
+								{status.label}
+							</option>
+						))}
+					</select>
 				</div>
 
 				<div className="form-actions">
@@ -1,15 +1,13 @@
 import axios from "axios"
-import Cookies from "js-cookie"
 import React, { useEffect, useState } from "react"
 import { Link, useParams } from "react-router-dom"
+import { hasPermission } from "../auth"
 import "./WeatherDetails.css" // Импорт файла стилей
 
 const WeatherDetails = () => {
 	const { id } = useParams()
 	const [observation, setObservation] = useState(null)
 
-	var userRole = Cookies.get("X-User-Role")
-
 	useEffect(() => {
 		axios
 			.get(`http://localhost:8080/weather/${id}`)
@@ -54,7 +52,7 @@ const WeatherDetails = () => {
 				<Link to="/" className="back-button">
 					Back to List
 				</Link>
-				{userRole === "admin" && (
+				{hasPermission("weather:update") && (
 					<Link to={`/update/${id}`} className="edit-button">
 						Edit Observation
 					</Link>
@@ -9,7 +9,7 @@ const WeatherList = () => {
 	useEffect(() => {
 		axios
 			.get("http://localhost:8080/weathers")
-			.then(response => setWeatherObservations(response.data))
+			.then(response => setWeatherObservations(response.data.items))
 			.catch(error => console.error("Error fetching data:", error))
 	}, [])
 
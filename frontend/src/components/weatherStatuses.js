@@ -0,0 +1,16 @@
+// Weather statuses the backend accepts, in the order they are offered.
+const weatherStatuses = [
+	{ value: "clear", label: "Clear" },
+	{ value: "partly_cloudy", label: "Partly cloudy" },
+	{ value: "cloudy", label: "Cloudy" },
+	{ value: "overcast", label: "Overcast" },
+	{ value: "fog", label: "Fog" },
+	{ value: "drizzle", label: "Drizzle" },
+	{ value: "rain", label: "Rain" },
+	{ value: "thunderstorm", label: "Thunderstorm" },
+	{ value: "snow", label: "Snow" },
+	{ value: "sleet", label: "Sleet" },
+	{ value: "hail", label: "Hail" },
+]
+
+export default weatherStatuses
@@ -1,6 +1,7 @@
 import React from "react"
 import ReactDOM from "react-dom/client"
 import App from "./App"
+import "./auth"
 import "./index.css"
 import reportWebVitals from "./reportWebVitals"
 